@@ -0,0 +1,338 @@
+// Command realtime-hub serves the REST API and WebSocket signaling for cline-meet
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/cline-meet/backend/internal/config"
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/cline-meet/backend/internal/handler"
+	"github.com/cline-meet/backend/internal/infrastructure/ical"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/cline-meet/backend/internal/infrastructure/metrics"
+	"github.com/cline-meet/backend/internal/infrastructure/postgres"
+	"github.com/cline-meet/backend/internal/infrastructure/realtime"
+	redisstore "github.com/cline-meet/backend/internal/infrastructure/redis"
+	"github.com/cline-meet/backend/internal/infrastructure/scheduler"
+	"github.com/cline-meet/backend/internal/infrastructure/token"
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := run(cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// app holds the wired dependencies of a realtime-hub process
+type app struct {
+	rooms    repository.Room
+	users    repository.User
+	messages repository.Message
+	waiting  repository.WaitingRoom
+	invites  repository.Invite
+	series   repository.Series
+	outbox   repository.Outbox
+	tx       repository.Transactor
+	sessions service.SessionManager
+	limiter  service.RateLimiter
+	notifier service.RealtimeNotifier
+	signer   *token.HMACSigner
+	elector  service.LeaderElector
+	policy   model.RoomPolicy
+	hub      *realtime.Hub
+	relay    *realtime.Relay
+	logger   *slog.Logger
+	metrics  *metrics.Metrics
+
+	closers []func() error
+}
+
+func (a *app) close() {
+	for i := len(a.closers) - 1; i >= 0; i-- {
+		if err := a.closers[i](); err != nil {
+			log.Printf("close: %v", err)
+		}
+	}
+}
+
+func run(cfg *config.Config) error {
+	// SIGTERM (Kubernetes のローリングアップデート) でシャットダウンを開始する
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	a, err := wire(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	events := usecase.NewEvents(a.outbox, a.tx, a.notifier, a.metrics, a.logger)
+	rooms := usecase.NewRoom(a.rooms, a.users, a.waiting, a.tx, events, a.notifier, a.sessions, a.signer, a.limiter, a.policy, a.metrics, a.logger)
+
+	var ready atomic.Bool
+	wsServer := &http.Server{Addr: cfg.WebSocketAddr, Handler: a.hub}
+	httpServer := &http.Server{Addr: cfg.HTTPAddr, Handler: newHTTPHandler(a, rooms, events, cfg, &ready)}
+
+	hubCtx, cancelHub := context.WithCancel(context.Background())
+	defer cancelHub()
+
+	hubDone := make(chan struct{})
+	go func() {
+		defer close(hubDone)
+		a.hub.Run(hubCtx)
+	}()
+	if a.relay != nil {
+		go func() {
+			if err := a.relay.Run(hubCtx); err != nil {
+				log.Printf("relay: %v", err)
+			}
+		}()
+	}
+
+	// バックグラウンドジョブとクラスタ全体のルームのメトリクスはリーダーに選ばれたPodだけが担当する
+	jobs := newScheduler(a, rooms, events, cfg)
+	a.metrics.SetLeader(jobs.IsLeader)
+
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		jobs.Run(jobsCtx)
+	}()
+
+	serveErr := make(chan error, 2)
+	for _, server := range []*http.Server{wsServer, httpServer} {
+		go func(server *http.Server) {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- fmt.Errorf("listen %s: %w", server.Addr, err)
+			}
+		}(server)
+	}
+
+	ready.Store(true)
+	log.Printf("realtime-hub %s: websocket on %s, http on %s", cfg.PodName, cfg.WebSocketAddr, cfg.HTTPAddr)
+
+	select {
+	case <-ctx.Done():
+	case err = <-serveErr:
+	}
+
+	log.Printf("shutting down, draining connections for up to %s", cfg.ShutdownTimeout)
+	shutdown(a, &ready, wsServer, httpServer, cancelHub, hubDone, cfg)
+
+	// リーダーの場合はリースを解放して他のPodに引き継ぐ
+	cancelJobs()
+	<-jobsDone
+	return err
+}
+
+// shutdown drains the pod so rolling updates do not drop calls
+func shutdown(a *app, ready *atomic.Bool, wsServer, httpServer *http.Server, cancelHub context.CancelFunc, hubDone <-chan struct{}, cfg *config.Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// 1. readinessProbe を失敗させて新しい接続を他のPodに振り分ける
+	ready.Store(false)
+
+	// 2. 新規WebSocket接続の受付を停止する（確立済みの接続はHubが管理する）
+	if err := wsServer.Shutdown(ctx); err != nil {
+		log.Printf("websocket server shutdown: %v", err)
+	}
+
+	// 3. 既存の接続にcloseフレームを送り、クライアントに再接続を促す
+	cancelHub()
+	select {
+	case <-hubDone:
+	case <-ctx.Done():
+		log.Printf("timed out draining %d websocket connections", a.hub.ClientCount())
+	}
+
+	// 4. 処理中のAPIリクエストを完了させる
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Printf("http server shutdown: %v", err)
+	}
+}
+
+// newHTTPHandler serves the REST API plus Kubernetes health checks
+// Prometheus scrapes /metrics, which includes the failures of best-effort usecase steps
+func newHTTPHandler(a *app, rooms *usecase.Room, events *usecase.Events, cfg *config.Config, ready *atomic.Bool) http.Handler {
+	gin.SetMode(gin.ReleaseMode)
+
+	router := handler.NewRouter(
+		rooms,
+		usecase.NewUser(a.users, a.notifier, a.sessions, a.metrics, a.logger),
+		usecase.NewMessage(a.messages, a.rooms, a.users, a.tx, events),
+		usecase.NewInvite(a.invites, a.rooms, a.users, a.signer, a.tx, rooms),
+		usecase.NewSeries(a.series, a.rooms, a.users, a.tx, rooms),
+		usecase.NewCalendar(a.rooms, a.series, a.users, a.invites, a.signer, ical.NewEncoder(), cfg.PublicURL),
+		a.logger,
+	)
+
+	router.GET("/healthz", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/readyz", func(c *gin.Context) {
+		if !ready.Load() {
+			c.Status(http.StatusServiceUnavailable)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	router.GET("/metrics", gin.WrapH(a.metrics))
+
+	return router
+}
+
+// newScheduler registers the background jobs
+// Stale sessions are reaped twice per session timeout, and outbox events that could not be
+// delivered by the request that recorded them are retried every OutboxInterval
+func newScheduler(a *app, rooms *usecase.Room, events *usecase.Events, cfg *config.Config) *scheduler.Scheduler {
+	maintenance := usecase.NewMaintenance(a.rooms, a.messages, a.tx, events, a.sessions, rooms, cfg.SessionTimeout, a.metrics, a.logger)
+
+	jobs := scheduler.NewScheduler(a.elector, scheduler.DefaultLease)
+	jobs.Add(scheduler.Job{
+		Name:     "cleanup-expired-rooms",
+		Interval: cfg.CleanupInterval,
+		Run:      maintenance.CleanupExpiredRooms,
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "reap-stale-sessions",
+		Interval: max(cfg.SessionTimeout/2, time.Second),
+		Run:      maintenance.ReapStaleSessions,
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "dispatch-events",
+		Interval: cfg.OutboxInterval,
+		Run:      events.DispatchEvents,
+	})
+	return jobs
+}
+
+// wire builds repositories, session manager and notifier from the configuration
+// Without DATABASE_URL or REDIS_ADDR the in-memory implementations are used
+func wire(ctx context.Context, cfg *config.Config) (*app, error) {
+	policy, err := model.NewRoomPolicy(cfg.MaxRoomCapacity, cfg.MaxRoomLifetime, cfg.MaxRoomExtension, cfg.WaitingRoomDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid room policy: %w", err)
+	}
+
+	// 握りつぶしたエラーはJSONで出力してリクエストIDで追跡できるようにする
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil)).With("pod", cfg.PodName)
+
+	a := &app{policy: policy, logger: logger}
+
+	if cfg.DatabaseURL != "" {
+		db, err := sql.Open("pgx", cfg.DatabaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		a.closers = append(a.closers, db.Close)
+
+		if err := postgres.Migrate(ctx, db); err != nil {
+			a.close()
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+
+		a.rooms = postgres.NewRoom(db)
+		a.users = postgres.NewUser(db)
+		a.invites = postgres.NewInvite(db)
+		a.series = postgres.NewSeries(db)
+		a.outbox = postgres.NewOutbox(db)
+		a.tx = postgres.NewTransactor(db)
+	} else {
+		log.Printf("DATABASE_URL is not set, using in-memory repositories")
+		a.rooms = memory.NewRoom()
+		a.users = memory.NewUser()
+		a.invites = memory.NewInvite()
+		a.series = memory.NewSeries()
+		a.outbox = memory.NewOutbox()
+		a.tx = memory.NewTransactor()
+	}
+
+	secret := []byte(cfg.InviteSecret)
+	if len(secret) == 0 {
+		// 再起動や他のPodでは招待リンクや接続トークンを検証できなくなる
+		log.Printf("INVITE_SECRET is not set, generating a random secret for this pod")
+		secret = make([]byte, token.MinSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			a.close()
+			return nil, fmt.Errorf("failed to generate invite secret: %w", err)
+		}
+	}
+	signer, err := token.NewHMACSigner(secret)
+	if err != nil {
+		a.close()
+		return nil, fmt.Errorf("invalid INVITE_SECRET: %w", err)
+	}
+	a.signer = signer
+
+	// WebSocket接続は招待と同じ秘密鍵で署名した接続トークンで認証する
+	a.hub = realtime.NewHub(a.rooms, a.signer)
+	a.hub.SetMaxConnections(cfg.MaxConnections)
+	a.hub.SetAllowedOrigins(cfg.AllowedOrigins)
+
+	if cfg.RedisAddr != "" {
+		client := goredis.NewClient(&goredis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+		})
+		a.closers = append(a.closers, client.Close)
+
+		if err := client.Ping(ctx).Err(); err != nil {
+			a.close()
+			return nil, fmt.Errorf("failed to connect to redis: %w", err)
+		}
+
+		sessions := redisstore.NewSessionManager(client, cfg.PodName)
+		a.sessions = sessions
+		a.messages = redisstore.NewMessage(client, a.rooms)
+		a.waiting = redisstore.NewWaitingRoom(client, a.rooms)
+		a.limiter = redisstore.NewRateLimiter(client, usecase.MaxPasscodeAttempts, usecase.PasscodeAttemptWindow)
+		a.relay = realtime.NewRelay(a.hub, client, sessions, cfg.PodName)
+		a.notifier = a.relay
+		a.elector = redisstore.NewLeaderElector(client, "scheduler", cfg.PodName)
+	} else {
+		log.Printf("REDIS_ADDR is not set, running as a single pod")
+		a.sessions = memory.NewSessionManager(cfg.PodName)
+		a.messages = memory.NewMessage()
+		a.waiting = memory.NewWaitingRoom()
+		a.limiter = memory.NewRateLimiter(usecase.MaxPasscodeAttempts, usecase.PasscodeAttemptWindow)
+		a.notifier = a.hub
+		a.elector = memory.NewLeaderElector()
+	}
+
+	a.hub.SetSessionManager(a.sessions)
+	a.hub.SetWaitingRoom(a.waiting)
+
+	// 通知の失敗はユースケースのログとは別にPodごとに集計する
+	a.metrics = metrics.NewMetrics(a.hub.ClientCount, a.rooms)
+	a.hub.SetMetrics(a.metrics)
+	a.notifier = a.metrics.Notifier(a.notifier)
+
+	return a, nil
+}
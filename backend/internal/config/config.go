@@ -0,0 +1,189 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the realtime-hub settings read from the environment (Kubernetes ConfigMap/Secret)
+type Config struct {
+	// WebSocketAddr is the listen address for WebSocket connections (README: port 8080)
+	WebSocketAddr string
+
+	// HTTPAddr is the listen address for the REST API and health checks (README: port 8081)
+	HTTPAddr string
+
+	// MaxConnections is the number of WebSocket connections accepted per pod
+	MaxConnections int
+
+	// DatabaseURL is the PostgreSQL connection string, empty for in-memory repositories
+	DatabaseURL string
+
+	// RedisAddr is the Redis address, empty for a single pod without Pub/Sub
+	RedisAddr     string
+	RedisPassword string
+
+	// InviteSecret signs invite and WebSocket connection tokens and must be shared by all pods
+	// When empty a random secret is generated, so invite links only work on this pod until it restarts
+	InviteSecret string
+
+	// Room policy limits applied to every room of this deployment
+	// Rooms get the default capacity (10) and lifetime (24h) capped by these limits unless the host sets them
+	MaxRoomCapacity    int
+	MaxRoomLifetime    time.Duration
+	MaxRoomExtension   time.Duration
+	WaitingRoomDefault bool
+
+	// PublicURL is the URL of the web app that join links in calendar entries point to
+	PublicURL string
+
+	// AllowedOrigins are the origins browsers may open WebSocket connections from
+	// It defaults to the origin of PublicURL
+	AllowedOrigins []string
+
+	// CleanupInterval is how often the leader pod removes expired rooms
+	CleanupInterval time.Duration
+
+	// OutboxInterval is how often the leader pod retries outbox events that could not be delivered
+	OutboxInterval time.Duration
+
+	// SessionTimeout is how long a session can go unseen before the leader pod reaps it
+	// It must stay well above the WebSocket ping period (54s)
+	SessionTimeout time.Duration
+
+	// PodName identifies this pod on sessions and Pub/Sub events
+	PodName string
+
+	// ShutdownTimeout bounds connection draining on SIGTERM
+	// It should stay below the pod's terminationGracePeriodSeconds
+	ShutdownTimeout time.Duration
+}
+
+// Load reads the configuration from environment variables, applying defaults for unset values
+func Load() (*Config, error) {
+	cfg := &Config{
+		WebSocketAddr:    ":" + getEnv("WS_PORT", "8080"),
+		HTTPAddr:         ":" + getEnv("HTTP_PORT", "8081"),
+		DatabaseURL:      os.Getenv("DATABASE_URL"),
+		RedisAddr:        os.Getenv("REDIS_ADDR"),
+		RedisPassword:    os.Getenv("REDIS_PASSWORD"),
+		InviteSecret:     os.Getenv("INVITE_SECRET"),
+		PublicURL:        getEnv("PUBLIC_URL", "http://localhost:3000"),
+		PodName:          os.Getenv("POD_NAME"),
+		MaxConnections:   10000,
+		ShutdownTimeout:  25 * time.Second,
+		MaxRoomCapacity:  10,
+		MaxRoomLifetime:  24 * time.Hour,
+		MaxRoomExtension: 24 * time.Hour,
+		CleanupInterval:  time.Hour,
+		OutboxInterval:   time.Second,
+		SessionTimeout:   5 * time.Minute,
+	}
+
+	// WebSocketはWebアプリのページからだけ接続を受け付ける
+	if u, err := url.Parse(cfg.PublicURL); err == nil && u.Host != "" {
+		cfg.AllowedOrigins = []string{u.Scheme + "://" + u.Host}
+	}
+	if value := os.Getenv("ALLOWED_ORIGINS"); value != "" {
+		cfg.AllowedOrigins = nil
+		for _, origin := range strings.Split(value, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				cfg.AllowedOrigins = append(cfg.AllowedOrigins, origin)
+			}
+		}
+	}
+
+	if value := os.Getenv("MAX_CONNECTIONS"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid MAX_CONNECTIONS %q", value)
+		}
+		cfg.MaxConnections = n
+	}
+
+	if value := os.Getenv("SHUTDOWN_TIMEOUT"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid SHUTDOWN_TIMEOUT %q", value)
+		}
+		cfg.ShutdownTimeout = d
+	}
+
+	if value := os.Getenv("MAX_ROOM_CAPACITY"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid MAX_ROOM_CAPACITY %q", value)
+		}
+		cfg.MaxRoomCapacity = n
+	}
+
+	if value := os.Getenv("MAX_ROOM_LIFETIME"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid MAX_ROOM_LIFETIME %q", value)
+		}
+		cfg.MaxRoomLifetime = d
+	}
+
+	if value := os.Getenv("MAX_ROOM_EXTENSION"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid MAX_ROOM_EXTENSION %q", value)
+		}
+		cfg.MaxRoomExtension = d
+	}
+
+	if value := os.Getenv("WAITING_ROOM_DEFAULT"); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WAITING_ROOM_DEFAULT %q", value)
+		}
+		cfg.WaitingRoomDefault = b
+	}
+
+	if value := os.Getenv("CLEANUP_INTERVAL"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid CLEANUP_INTERVAL %q", value)
+		}
+		cfg.CleanupInterval = d
+	}
+
+	if value := os.Getenv("OUTBOX_INTERVAL"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid OUTBOX_INTERVAL %q", value)
+		}
+		cfg.OutboxInterval = d
+	}
+
+	if value := os.Getenv("SESSION_TIMEOUT"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid SESSION_TIMEOUT %q", value)
+		}
+		cfg.SessionTimeout = d
+	}
+
+	// Pod名が未設定の場合はホスト名（KubernetesではPod名）を使う
+	if cfg.PodName == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve pod name: %w", err)
+		}
+		cfg.PodName = hostname
+	}
+
+	return cfg, nil
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
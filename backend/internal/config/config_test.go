@@ -0,0 +1,139 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoad_Defaults(t *testing.T) {
+	for _, key := range []string{"WS_PORT", "HTTP_PORT", "MAX_CONNECTIONS", "DATABASE_URL", "REDIS_ADDR", "POD_NAME", "SHUTDOWN_TIMEOUT", "INVITE_SECRET", "PUBLIC_URL", "ALLOWED_ORIGINS",
+		"MAX_ROOM_CAPACITY", "MAX_ROOM_LIFETIME", "MAX_ROOM_EXTENSION", "WAITING_ROOM_DEFAULT",
+		"CLEANUP_INTERVAL", "SESSION_TIMEOUT"} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.WebSocketAddr != ":8080" {
+		t.Errorf("Expected WebSocketAddr :8080, got %s", cfg.WebSocketAddr)
+	}
+	if cfg.HTTPAddr != ":8081" {
+		t.Errorf("Expected HTTPAddr :8081, got %s", cfg.HTTPAddr)
+	}
+	if cfg.MaxConnections != 10000 {
+		t.Errorf("Expected MaxConnections 10000, got %d", cfg.MaxConnections)
+	}
+	if cfg.ShutdownTimeout != 25*time.Second {
+		t.Errorf("Expected ShutdownTimeout 25s, got %v", cfg.ShutdownTimeout)
+	}
+	if cfg.PodName == "" {
+		t.Error("Expected PodName to fall back to hostname")
+	}
+	if cfg.InviteSecret != "" {
+		t.Errorf("Expected empty InviteSecret, got %q", cfg.InviteSecret)
+	}
+	if cfg.PublicURL != "http://localhost:3000" {
+		t.Errorf("Expected PublicURL http://localhost:3000, got %s", cfg.PublicURL)
+	}
+	if len(cfg.AllowedOrigins) != 1 || cfg.AllowedOrigins[0] != "http://localhost:3000" {
+		t.Errorf("Expected AllowedOrigins to default to the PublicURL origin, got %v", cfg.AllowedOrigins)
+	}
+	if cfg.MaxRoomCapacity != 10 || cfg.MaxRoomLifetime != 24*time.Hour || cfg.MaxRoomExtension != 24*time.Hour || cfg.WaitingRoomDefault {
+		t.Errorf("Expected default room policy 10/24h/24h without waiting room, got %d/%v/%v/%t",
+			cfg.MaxRoomCapacity, cfg.MaxRoomLifetime, cfg.MaxRoomExtension, cfg.WaitingRoomDefault)
+	}
+	if cfg.CleanupInterval != time.Hour || cfg.SessionTimeout != 5*time.Minute {
+		t.Errorf("Expected CleanupInterval 1h and SessionTimeout 5m, got %v/%v", cfg.CleanupInterval, cfg.SessionTimeout)
+	}
+	if cfg.OutboxInterval != time.Second {
+		t.Errorf("Expected OutboxInterval 1s, got %v", cfg.OutboxInterval)
+	}
+}
+
+func TestLoad_FromEnv(t *testing.T) {
+	t.Setenv("WS_PORT", "9090")
+	t.Setenv("HTTP_PORT", "9091")
+	t.Setenv("MAX_CONNECTIONS", "500")
+	t.Setenv("DATABASE_URL", "postgres://localhost/clinemeet")
+	t.Setenv("REDIS_ADDR", "localhost:6379")
+	t.Setenv("POD_NAME", "realtime-hub-1")
+	t.Setenv("SHUTDOWN_TIMEOUT", "10s")
+	t.Setenv("INVITE_SECRET", "0123456789abcdef0123456789abcdef")
+	t.Setenv("MAX_ROOM_CAPACITY", "50")
+	t.Setenv("MAX_ROOM_LIFETIME", "8h")
+	t.Setenv("MAX_ROOM_EXTENSION", "0s")
+	t.Setenv("WAITING_ROOM_DEFAULT", "true")
+	t.Setenv("CLEANUP_INTERVAL", "15m")
+	t.Setenv("SESSION_TIMEOUT", "3m")
+	t.Setenv("OUTBOX_INTERVAL", "500ms")
+	t.Setenv("ALLOWED_ORIGINS", "https://meet.example.com, https://admin.example.com")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.WebSocketAddr != ":9090" || cfg.HTTPAddr != ":9091" {
+		t.Errorf("Expected ports 9090/9091, got %s/%s", cfg.WebSocketAddr, cfg.HTTPAddr)
+	}
+	if cfg.MaxConnections != 500 {
+		t.Errorf("Expected MaxConnections 500, got %d", cfg.MaxConnections)
+	}
+	if cfg.DatabaseURL != "postgres://localhost/clinemeet" {
+		t.Errorf("Expected DatabaseURL, got %s", cfg.DatabaseURL)
+	}
+	if cfg.RedisAddr != "localhost:6379" {
+		t.Errorf("Expected RedisAddr, got %s", cfg.RedisAddr)
+	}
+	if cfg.PodName != "realtime-hub-1" {
+		t.Errorf("Expected PodName realtime-hub-1, got %s", cfg.PodName)
+	}
+	if cfg.ShutdownTimeout != 10*time.Second {
+		t.Errorf("Expected ShutdownTimeout 10s, got %v", cfg.ShutdownTimeout)
+	}
+	if cfg.InviteSecret != "0123456789abcdef0123456789abcdef" {
+		t.Errorf("Expected InviteSecret, got %q", cfg.InviteSecret)
+	}
+	if cfg.MaxRoomCapacity != 50 || cfg.MaxRoomLifetime != 8*time.Hour || cfg.MaxRoomExtension != 0 || !cfg.WaitingRoomDefault {
+		t.Errorf("Expected room policy 50/8h/0s with waiting room, got %d/%v/%v/%t",
+			cfg.MaxRoomCapacity, cfg.MaxRoomLifetime, cfg.MaxRoomExtension, cfg.WaitingRoomDefault)
+	}
+	if cfg.CleanupInterval != 15*time.Minute || cfg.SessionTimeout != 3*time.Minute {
+		t.Errorf("Expected CleanupInterval 15m and SessionTimeout 3m, got %v/%v", cfg.CleanupInterval, cfg.SessionTimeout)
+	}
+	if len(cfg.AllowedOrigins) != 2 || cfg.AllowedOrigins[0] != "https://meet.example.com" || cfg.AllowedOrigins[1] != "https://admin.example.com" {
+		t.Errorf("Expected two AllowedOrigins, got %v", cfg.AllowedOrigins)
+	}
+	if cfg.OutboxInterval != 500*time.Millisecond {
+		t.Errorf("Expected OutboxInterval 500ms, got %v", cfg.OutboxInterval)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{"non-numeric max connections", "MAX_CONNECTIONS", "many"},
+		{"negative max connections", "MAX_CONNECTIONS", "-1"},
+		{"invalid shutdown timeout", "SHUTDOWN_TIMEOUT", "soon"},
+		{"zero room capacity", "MAX_ROOM_CAPACITY", "0"},
+		{"zero room lifetime", "MAX_ROOM_LIFETIME", "0s"},
+		{"negative room extension", "MAX_ROOM_EXTENSION", "-1h"},
+		{"invalid waiting room default", "WAITING_ROOM_DEFAULT", "sometimes"},
+		{"zero cleanup interval", "CLEANUP_INTERVAL", "0s"},
+		{"invalid outbox interval", "OUTBOX_INTERVAL", "often"},
+		{"invalid session timeout", "SESSION_TIMEOUT", "later"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			if _, err := Load(); err == nil {
+				t.Errorf("Expected error for %s=%s", tt.key, tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CalendarUIDDomain is the domain part of iCalendar UIDs
+const CalendarUIDDomain = "cline-meet"
+
+// CalendarMethod is the iTIP method of a calendar (RFC 5546)
+type CalendarMethod string
+
+const (
+	CalendarMethodPublish CalendarMethod = "PUBLISH"
+	CalendarMethodRequest CalendarMethod = "REQUEST"
+	CalendarMethodCancel  CalendarMethod = "CANCEL"
+)
+
+// EventStatus is the STATUS of a calendar event
+type EventStatus string
+
+const (
+	EventStatusConfirmed EventStatus = "CONFIRMED"
+	EventStatusCancelled EventStatus = "CANCELLED"
+)
+
+// AttendeeRole is the ROLE of a calendar attendee
+type AttendeeRole string
+
+const (
+	AttendeeRoleChair    AttendeeRole = "CHAIR"
+	AttendeeRoleRequired AttendeeRole = "REQ-PARTICIPANT"
+	AttendeeRoleOptional AttendeeRole = "OPT-PARTICIPANT"
+)
+
+// Calendar is an iCalendar object with the events handed out for rooms and series
+type Calendar struct {
+	Method CalendarMethod
+	Events []*CalendarEvent
+}
+
+// CalendarAddress is an organizer or attendee of an event
+type CalendarAddress struct {
+	Email string
+	Name  string
+	Role  AttendeeRole // 主催者では空
+}
+
+// CalendarEvent is a VEVENT for a scheduled room or a series
+// Start and End are written in TimeZone, or in UTC when it is empty
+type CalendarEvent struct {
+	UID            string
+	Sequence       int
+	Status         EventStatus
+	Summary        string
+	Description    string
+	URL            string // 参加URL
+	Start          time.Time
+	End            time.Time
+	TimeZone       string
+	RRule          string
+	ExceptionDates []time.Time
+	RecurrenceID   *time.Time // 定期的な会議の特定の回を表す場合のみ
+	Organizer      CalendarAddress
+	Attendees      []CalendarAddress
+	Stamp          time.Time
+}
+
+// NewCalendar creates a calendar with the given method and events
+func NewCalendar(method CalendarMethod, events ...*CalendarEvent) *Calendar {
+	return &Calendar{Method: method, Events: events}
+}
+
+// NewRoomEvent creates the event for a scheduled room organized by its host
+// Rooms of a series occurrence share the series' UID and identify the occurrence with RecurrenceID
+func NewRoomEvent(room *Room, host *User, joinURL string) (*CalendarEvent, error) {
+	if room.Schedule == nil {
+		return nil, fmt.Errorf("%w: room is not scheduled", ErrInvalidSchedule)
+	}
+
+	event := newCalendarEvent(room.ID.String(), room.Name, host, joinURL)
+	event.Sequence = room.Schedule.Sequence
+	event.Start = room.Schedule.StartsAt
+	event.End = room.Schedule.EndsAt
+	if room.Occurrence != nil {
+		event.UID = calendarUID(room.Occurrence.SeriesID.String())
+		recurrenceID := room.Occurrence.StartsAt
+		event.RecurrenceID = &recurrenceID
+	}
+	return event, nil
+}
+
+// NewSeriesEvent creates the recurring event for a series organized by its host
+// Cancelled occurrences are listed as exception dates
+func NewSeriesEvent(series *Series, host *User, joinURL string) *CalendarEvent {
+	event := newCalendarEvent(series.ID.String(), series.Name, host, joinURL)
+	event.Sequence = series.Sequence
+	event.Start = series.StartsAt
+	event.End = series.StartsAt.Add(series.Duration())
+	event.TimeZone = series.TimeZone
+	event.RRule = series.RRule
+	event.ExceptionDates = append([]time.Time{}, series.ExceptionDates...)
+	return event
+}
+
+func newCalendarEvent(id, name string, host *User, joinURL string) *CalendarEvent {
+	return &CalendarEvent{
+		UID:         calendarUID(id),
+		Status:      EventStatusConfirmed,
+		Summary:     name,
+		Description: "Join: " + joinURL,
+		URL:         joinURL,
+		Organizer:   CalendarAddress{Email: host.Email, Name: host.Name},
+		Attendees:   []CalendarAddress{},
+		Stamp:       time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func calendarUID(id string) string {
+	return id + "@" + CalendarUIDDomain
+}
+
+// AddAttendee adds an attendee unless the email is empty, the organizer's, or already listed
+func (e *CalendarEvent) AddAttendee(email, name string, role AttendeeRole) {
+	email = strings.TrimSpace(email)
+	if email == "" || strings.EqualFold(email, e.Organizer.Email) {
+		return
+	}
+	for _, a := range e.Attendees {
+		if strings.EqualFold(a.Email, email) {
+			return
+		}
+	}
+
+	e.Attendees = append(e.Attendees, CalendarAddress{Email: email, Name: name, Role: role})
+}
+
+// Cancel marks the event as cancelled
+// The sequence is incremented so calendar clients apply the cancellation over the last update
+func (e *CalendarEvent) Cancel() {
+	e.Status = EventStatusCancelled
+	e.Sequence++
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRoomEvent(t *testing.T) {
+	host := NewUser("google-id", "host@example.com", "Host", "")
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	schedule, _ := NewSchedule(start, start.Add(30*time.Minute), 0)
+	room := NewScheduledRoom("Planning", host.ID, false, schedule)
+
+	event, err := NewRoomEvent(room, host, "https://meet.example.com/rooms/"+room.ID.String())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if event.UID != room.ID.String()+"@"+CalendarUIDDomain {
+		t.Errorf("Expected UID from room ID, got %s", event.UID)
+	}
+	if !event.Start.Equal(start) || !event.End.Equal(schedule.EndsAt) || event.Sequence != 0 {
+		t.Errorf("Expected event at %v with sequence 0, got %v (%d)", start, event.Start, event.Sequence)
+	}
+	if event.Organizer.Email != host.Email || event.Status != EventStatusConfirmed || event.RecurrenceID != nil {
+		t.Errorf("Expected confirmed event organized by host, got %+v", event)
+	}
+
+	// 予定を変更するとSEQUENCEが増える
+	rescheduled, _ := NewSchedule(start.Add(time.Hour), start.Add(2*time.Hour), 0)
+	room.SetSchedule(rescheduled)
+	event, _ = NewRoomEvent(room, host, "")
+	if event.Sequence != 1 {
+		t.Errorf("Expected sequence 1 after rescheduling, got %d", event.Sequence)
+	}
+
+	if _, err := NewRoomEvent(NewRoom("Ad hoc", host.ID, false), host, ""); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("Expected ErrInvalidSchedule for unscheduled room, got %v", err)
+	}
+}
+
+func TestNewRoomEvent_Occurrence(t *testing.T) {
+	host := NewUser("google-id", "host@example.com", "Host", "")
+	series, _ := NewSeries("Standup", host.ID, false, time.Now().Add(time.Hour), 15*time.Minute, 0, "FREQ=DAILY", "")
+	occurrences, _ := series.Occurrences(time.Now(), 2)
+	room, _ := series.NewOccurrenceRoom(&occurrences[1])
+
+	event, _ := NewRoomEvent(room, host, "")
+	if event.UID != NewSeriesEvent(series, host, "").UID {
+		t.Errorf("Expected occurrence to share the series UID, got %s", event.UID)
+	}
+	if event.RecurrenceID == nil || !event.RecurrenceID.Equal(occurrences[1].StartsAt) {
+		t.Errorf("Expected RecurrenceID %v, got %v", occurrences[1].StartsAt, event.RecurrenceID)
+	}
+}
+
+func TestNewSeriesEvent(t *testing.T) {
+	host := NewUser("google-id", "host@example.com", "Host", "")
+	series, _ := NewSeries("Standup", host.ID, false, time.Now().Add(time.Hour), 15*time.Minute, 0, "FREQ=WEEKLY;BYDAY=MO", "Asia/Tokyo")
+	occurrences, _ := series.Occurrences(time.Now(), 1)
+	series.CancelOccurrence(occurrences[0].StartsAt)
+
+	event := NewSeriesEvent(series, host, "")
+	if event.RRule != series.RRule || event.TimeZone != "Asia/Tokyo" {
+		t.Errorf("Expected rule and time zone of the series, got %q %q", event.RRule, event.TimeZone)
+	}
+	if event.End.Sub(event.Start) != 15*time.Minute {
+		t.Errorf("Expected 15 minute events, got %v", event.End.Sub(event.Start))
+	}
+	if len(event.ExceptionDates) != 1 || event.Sequence != 1 {
+		t.Errorf("Expected 1 exception at sequence 1, got %v at %d", event.ExceptionDates, event.Sequence)
+	}
+}
+
+func TestCalendarEvent_AddAttendeeAndCancel(t *testing.T) {
+	host := NewUser("google-id", "host@example.com", "Host", "")
+	schedule, _ := NewSchedule(time.Now().Add(time.Hour), time.Now().Add(2*time.Hour), 0)
+	event, _ := NewRoomEvent(NewScheduledRoom("Planning", uuid.New(), false, schedule), host, "")
+
+	event.AddAttendee("alice@example.com", "Alice", AttendeeRoleRequired)
+	event.AddAttendee("ALICE@example.com", "", AttendeeRoleOptional)
+	event.AddAttendee("Host@Example.com", "", AttendeeRoleRequired)
+	event.AddAttendee(" ", "", AttendeeRoleRequired)
+	if len(event.Attendees) != 1 || event.Attendees[0].Role != AttendeeRoleRequired {
+		t.Errorf("Expected Alice only, got %+v", event.Attendees)
+	}
+
+	event.Cancel()
+	if event.Status != EventStatusCancelled || event.Sequence != 1 {
+		t.Errorf("Expected cancelled event at sequence 1, got %s at %d", event.Status, event.Sequence)
+	}
+}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType represents the type of a domain event
+type EventType string
+
+const (
+	EventTypeRoomCreated       EventType = "room_created"
+	EventTypeParticipantJoined EventType = "participant_joined"
+	EventTypeParticipantLeft   EventType = "participant_left"
+	EventTypeParticipantMuted  EventType = "participant_muted"
+	EventTypeRoomExtended      EventType = "room_extended"
+	EventTypeChatPosted        EventType = "chat_posted"
+	EventTypeRoomClosed        EventType = "room_closed"
+	EventTypeAdmissionDenied   EventType = "admission_denied"
+)
+
+// Event is a domain event recorded in the outbox together with the change it describes
+// Events are delivered to the room's participants at least once, so the same event may arrive twice
+type Event struct {
+	ID            uuid.UUID       `json:"id"`
+	Type          EventType       `json:"type"`
+	RoomID        uuid.UUID       `json:"roomId"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	Attempts      int             `json:"attempts"`      // 配信に失敗した回数
+	NextAttemptAt time.Time       `json:"nextAttemptAt"` // これ以降に配信する
+}
+
+// ParticipantMutedPayload represents a participant muted event payload
+// ActorID is the user who muted or unmuted the participant
+type ParticipantMutedPayload struct {
+	ActorID uuid.UUID `json:"actorId"`
+	UserID  uuid.UUID `json:"userId"`
+	IsMuted bool      `json:"isMuted"`
+}
+
+// AdmissionDeniedPayload represents an admission denied event payload
+// ActorID is the user the waiting user sees as denying the admission
+type AdmissionDeniedPayload struct {
+	ActorID uuid.UUID `json:"actorId"`
+	UserID  uuid.UUID `json:"userId"`
+	Reason  string    `json:"reason"`
+}
+
+// NewEvent creates a new event that is due immediately
+func NewEvent(eventType EventType, roomID uuid.UUID, payload interface{}) *Event {
+	// ペイロードはモデルの型なので常にJSONに変換できる
+	data, _ := json.Marshal(payload)
+	now := time.Now()
+	return &Event{
+		ID:            uuid.New(),
+		Type:          eventType,
+		RoomID:        roomID,
+		Payload:       data,
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}
+}
+
+// NewRoomCreatedEvent creates a new room created event carrying the room
+func NewRoomCreatedEvent(room *Room) *Event {
+	return NewEvent(EventTypeRoomCreated, room.ID, room)
+}
+
+// NewParticipantJoinedEvent creates a new participant joined event
+func NewParticipantJoinedEvent(roomID, userID uuid.UUID, userName string) *Event {
+	return NewEvent(EventTypeParticipantJoined, roomID, ParticipantPayload{UserID: userID, UserName: userName})
+}
+
+// NewParticipantLeftEvent creates a new participant left event
+func NewParticipantLeftEvent(roomID, userID uuid.UUID, userName string) *Event {
+	return NewEvent(EventTypeParticipantLeft, roomID, ParticipantPayload{UserID: userID, UserName: userName})
+}
+
+// NewParticipantMutedEvent creates a new event for a participant muted or unmuted by the actor
+func NewParticipantMutedEvent(roomID, actorID, userID uuid.UUID, isMuted bool) *Event {
+	return NewEvent(EventTypeParticipantMuted, roomID, ParticipantMutedPayload{ActorID: actorID, UserID: userID, IsMuted: isMuted})
+}
+
+// NewRoomExtendedEvent creates a new room extended event carrying the room
+func NewRoomExtendedEvent(room *Room) *Event {
+	return NewEvent(EventTypeRoomExtended, room.ID, room)
+}
+
+// NewChatPostedEvent creates a new chat posted event carrying the chat message
+func NewChatPostedEvent(message *Message) *Event {
+	return NewEvent(EventTypeChatPosted, message.RoomID, message)
+}
+
+// NewRoomClosedEvent creates a new event telling the remaining participants that the room ended
+func NewRoomClosedEvent(roomID uuid.UUID, reason string) *Event {
+	return NewEvent(EventTypeRoomClosed, roomID, RoomClosedPayload{Reason: reason})
+}
+
+// NewAdmissionDeniedEvent creates a new event turning a waiting user away
+func NewAdmissionDeniedEvent(roomID, actorID, userID uuid.UUID, reason string) *Event {
+	return NewEvent(EventTypeAdmissionDenied, roomID, AdmissionDeniedPayload{ActorID: actorID, UserID: userID, Reason: reason})
+}
+
+// DecodePayload decodes the event payload into v
+func (e *Event) DecodePayload(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
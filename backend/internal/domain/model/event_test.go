@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewEvent_DueImmediately(t *testing.T) {
+	roomID := uuid.New()
+	event := NewParticipantJoinedEvent(roomID, uuid.New(), "Test User")
+
+	if event.ID == uuid.Nil || event.Type != EventTypeParticipantJoined || event.RoomID != roomID {
+		t.Errorf("Unexpected event %+v", event)
+	}
+	if !event.NextAttemptAt.Equal(event.OccurredAt) || event.Attempts != 0 {
+		t.Errorf("Expected the event to be due immediately, got %+v", event)
+	}
+}
+
+func TestEvent_DecodePayload(t *testing.T) {
+	userID := uuid.New()
+	actorID := uuid.New()
+
+	var muted ParticipantMutedPayload
+	if err := NewParticipantMutedEvent(uuid.New(), actorID, userID, true).DecodePayload(&muted); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if muted.ActorID != actorID || muted.UserID != userID || !muted.IsMuted {
+		t.Errorf("Unexpected payload %+v", muted)
+	}
+
+	room := NewRoom("Test Room", userID, false)
+	room.AddParticipant(userID)
+	var decoded Room
+	if err := NewRoomExtendedEvent(room).DecodePayload(&decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if decoded.ID != room.ID || len(decoded.Participants) != 1 || decoded.Version != room.Version {
+		t.Errorf("Expected the room snapshot, got %+v", decoded)
+	}
+
+	message := NewChatMessage(userID, room.ID, "Hello", "Test User")
+	chat, err := FromJSON(NewChatPostedEvent(message).Payload)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if payload, ok := chat.Payload.(ChatPayload); !ok || payload.Message != "Hello" || chat.ID != message.ID {
+		t.Errorf("Expected the chat message, got %+v", chat)
+	}
+
+	var closed RoomClosedPayload
+	if err := NewRoomClosedEvent(room.ID, RoomCloseReasonDeleted).DecodePayload(&closed); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if closed.Reason != RoomCloseReasonDeleted {
+		t.Errorf("Expected reason %q, got %q", RoomCloseReasonDeleted, closed.Reason)
+	}
+
+	var denied AdmissionDeniedPayload
+	if err := NewAdmissionDeniedEvent(room.ID, actorID, userID, "room was closed").DecodePayload(&denied); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if denied.ActorID != actorID || denied.UserID != userID || denied.Reason != "room was closed" {
+		t.Errorf("Unexpected payload %+v", denied)
+	}
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when redeeming an invite, usable with errors.Is
+var (
+	ErrInviteExpired       = errors.New("invite has expired")
+	ErrInviteRevoked       = errors.New("invite has been revoked")
+	ErrInviteExhausted     = errors.New("invite has no uses left")
+	ErrInviteEmailMismatch = errors.New("invite was issued to a different email")
+)
+
+// Invite is a shareable invitation to a room
+// The invite is handed out as a signed token; uses and revocation are tracked here
+type Invite struct {
+	ID        uuid.UUID  `json:"id"`
+	RoomID    uuid.UUID  `json:"roomId"`
+	CreatedBy uuid.UUID  `json:"createdBy"`
+	Email     string     `json:"email,omitempty"` // 空の場合は誰でも利用できる
+	Role      Role       `json:"role"`
+	MaxUses   int        `json:"maxUses"` // 0は無制限
+	Uses      int        `json:"uses"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// NewInvite creates a new invite valid for ttl
+func NewInvite(roomID, createdBy uuid.UUID, email string, role Role, ttl time.Duration, maxUses int) *Invite {
+	now := time.Now()
+	return &Invite{
+		ID:        uuid.New(),
+		RoomID:    roomID,
+		CreatedBy: createdBy,
+		Email:     strings.TrimSpace(email),
+		Role:      role,
+		MaxUses:   maxUses,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+// IsExpired checks if the invite has expired
+func (i *Invite) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+// IsRevoked checks if the invite has been revoked
+func (i *Invite) IsRevoked() bool {
+	return i.RevokedAt != nil
+}
+
+// Revoke stops the invite from being redeemed
+func (i *Invite) Revoke() {
+	if i.RevokedAt == nil {
+		now := time.Now()
+		i.RevokedAt = &now
+	}
+}
+
+// Redeem records a use of the invite by a user with the given email
+func (i *Invite) Redeem(email string) error {
+	switch {
+	case i.IsRevoked():
+		return ErrInviteRevoked
+	case i.IsExpired():
+		return ErrInviteExpired
+	case i.MaxUses > 0 && i.Uses >= i.MaxUses:
+		return ErrInviteExhausted
+	case i.Email != "" && !strings.EqualFold(i.Email, strings.TrimSpace(email)):
+		return ErrInviteEmailMismatch
+	}
+
+	i.Uses++
+	return nil
+}
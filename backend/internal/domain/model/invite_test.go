@@ -0,0 +1,73 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewInvite(t *testing.T) {
+	roomID := uuid.New()
+	hostID := uuid.New()
+
+	invite := NewInvite(roomID, hostID, " alice@example.com ", RolePresenter, time.Hour, 3)
+
+	if invite.ID == uuid.Nil {
+		t.Error("Expected ID to be generated")
+	}
+	if invite.RoomID != roomID || invite.CreatedBy != hostID {
+		t.Errorf("Expected room %s by %s, got %+v", roomID, hostID, invite)
+	}
+	if invite.Email != "alice@example.com" {
+		t.Errorf("Expected trimmed email, got %q", invite.Email)
+	}
+	if invite.ExpiresAt.Sub(invite.CreatedAt) != time.Hour {
+		t.Errorf("Expected invite to expire after 1h, got %v", invite.ExpiresAt.Sub(invite.CreatedAt))
+	}
+}
+
+func TestInvite_Redeem(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*Invite)
+		email    string
+		expected error
+	}{
+		{"valid", func(i *Invite) {}, "Alice@Example.com", nil},
+		{"other email", func(i *Invite) {}, "bob@example.com", ErrInviteEmailMismatch},
+		{"expired", func(i *Invite) { i.ExpiresAt = time.Now().Add(-time.Minute) }, "alice@example.com", ErrInviteExpired},
+		{"revoked", func(i *Invite) { i.Revoke() }, "alice@example.com", ErrInviteRevoked},
+		{"exhausted", func(i *Invite) { i.Uses = i.MaxUses }, "alice@example.com", ErrInviteExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invite := NewInvite(uuid.New(), uuid.New(), "alice@example.com", RoleAttendee, time.Hour, 2)
+			tt.setup(invite)
+			uses := invite.Uses
+
+			err := invite.Redeem(tt.email)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, err)
+			}
+			if tt.expected == nil && invite.Uses != uses+1 {
+				t.Errorf("Expected Uses %d, got %d", uses+1, invite.Uses)
+			}
+			if tt.expected != nil && invite.Uses != uses {
+				t.Errorf("Expected Uses to stay %d, got %d", uses, invite.Uses)
+			}
+		})
+	}
+}
+
+func TestInvite_RedeemUnlimited(t *testing.T) {
+	invite := NewInvite(uuid.New(), uuid.New(), "", RoleViewer, time.Hour, 0)
+
+	for i := 0; i < 100; i++ {
+		if err := invite.Redeem("anyone@example.com"); err != nil {
+			t.Fatalf("Expected unlimited invite to be redeemable, got %v", err)
+		}
+	}
+}
@@ -21,6 +21,10 @@ const (
 	MessageTypeLeaveRoom MessageType = "leave_room"
 	MessageTypeUserJoined MessageType = "user_joined"
 	MessageTypeUserLeft   MessageType = "user_left"
+	MessageTypeRoomUpdate MessageType = "room_update"
+	MessageTypeUserWaiting MessageType = "user_waiting"
+	MessageTypeHostChanged MessageType = "host_changed"
+	MessageTypeRoomClosed  MessageType = "room_closed"
 
 	// チャット
 	MessageTypeChatMessage MessageType = "chat_message"
@@ -28,6 +32,7 @@ const (
 	// 制御
 	MessageTypeMuteUser    MessageType = "mute_user"
 	MessageTypeAdmitUser   MessageType = "admit_user"
+	MessageTypeKickUser    MessageType = "kick_user"
 	MessageTypeScreenShare MessageType = "screen_share"
 )
 
@@ -48,6 +53,36 @@ type ChatPayload struct {
 	UserName string `json:"userName"`
 }
 
+// ParticipantPayload represents user joined/left payload
+type ParticipantPayload struct {
+	UserID   uuid.UUID `json:"userId"`
+	UserName string    `json:"userName"`
+}
+
+// HostChangedPayload represents a host change payload
+type HostChangedPayload struct {
+	PreviousHostID uuid.UUID `json:"previousHostId"`
+	NewHostID      uuid.UUID `json:"newHostId"`
+	Reason         string    `json:"reason"`
+}
+
+// Reasons for a host change
+const (
+	HostChangeReasonLeft        = "host_left"
+	HostChangeReasonTransferred = "transferred"
+)
+
+// RoomClosedPayload represents a room closed payload
+type RoomClosedPayload struct {
+	Reason string `json:"reason"`
+}
+
+// Reasons for closing a room
+const (
+	RoomCloseReasonExpired = "expired"
+	RoomCloseReasonDeleted = "deleted"
+)
+
 // WebRTCPayload represents WebRTC signaling payload
 type WebRTCPayload struct {
 	SDP  string `json:"sdp,omitempty"`
@@ -123,6 +158,102 @@ func NewICECandidate(senderID, targetID, roomID uuid.UUID, candidate, sdpMid str
 	return msg
 }
 
+// NewUserJoinedMessage creates a new user joined message
+func NewUserJoinedMessage(roomID, userID uuid.UUID, userName string) *Message {
+	payload := ParticipantPayload{
+		UserID:   userID,
+		UserName: userName,
+	}
+	return NewMessage(MessageTypeUserJoined, userID, roomID, payload)
+}
+
+// NewUserLeftMessage creates a new user left message
+func NewUserLeftMessage(roomID, userID uuid.UUID, userName string) *Message {
+	payload := ParticipantPayload{
+		UserID:   userID,
+		UserName: userName,
+	}
+	return NewMessage(MessageTypeUserLeft, userID, roomID, payload)
+}
+
+// NewMuteUserMessage creates a new mute/unmute notification message sent by the actor who muted or unmuted the user
+func NewMuteUserMessage(roomID, actorID, userID uuid.UUID, isMuted bool) *Message {
+	action := "unmute"
+	if isMuted {
+		action = "mute"
+	}
+	payload := ControlPayload{
+		Action:   action,
+		TargetID: userID,
+	}
+	return NewMessage(MessageTypeMuteUser, actorID, roomID, payload)
+}
+
+// NewUserWaitingMessage creates a message telling the host that a user is waiting for admission
+func NewUserWaitingMessage(roomID, hostID, userID uuid.UUID, userName string) *Message {
+	payload := ParticipantPayload{
+		UserID:   userID,
+		UserName: userName,
+	}
+	msg := NewMessage(MessageTypeUserWaiting, userID, roomID, payload)
+	msg.TargetUserID = hostID
+	return msg
+}
+
+// NewAdmitUserMessage creates a message telling a waiting user whether the host admitted them
+func NewAdmitUserMessage(roomID, hostID, userID uuid.UUID, admitted bool, reason string) *Message {
+	action := "deny"
+	if admitted {
+		action = "admit"
+	}
+	payload := ControlPayload{
+		Action:   action,
+		TargetID: userID,
+		Reason:   reason,
+	}
+	msg := NewMessage(MessageTypeAdmitUser, hostID, roomID, payload)
+	msg.TargetUserID = userID
+	return msg
+}
+
+// NewKickUserMessage creates a message telling a user they were removed from the room
+func NewKickUserMessage(roomID, actorID, userID uuid.UUID, banned bool, reason string) *Message {
+	action := "kick"
+	if banned {
+		action = "ban"
+	}
+	payload := ControlPayload{
+		Action:   action,
+		TargetID: userID,
+		Reason:   reason,
+	}
+	msg := NewMessage(MessageTypeKickUser, actorID, roomID, payload)
+	msg.TargetUserID = userID
+	return msg
+}
+
+// NewHostChangedMessage creates a message announcing a new host to the room
+func NewHostChangedMessage(roomID, previousHostID, newHostID uuid.UUID, reason string) *Message {
+	payload := HostChangedPayload{
+		PreviousHostID: previousHostID,
+		NewHostID:      newHostID,
+		Reason:         reason,
+	}
+	return NewMessage(MessageTypeHostChanged, previousHostID, roomID, payload)
+}
+
+// NewRoomClosedMessage creates a message telling the remaining participants that the room ended
+func NewRoomClosedMessage(roomID uuid.UUID, reason string) *Message {
+	return NewMessage(MessageTypeRoomClosed, uuid.Nil, roomID, RoomClosedPayload{Reason: reason})
+}
+
+// NewRoomUpdateMessage creates a new room update message
+func NewRoomUpdateMessage(room *Room) *Message {
+	snapshot := *room
+	snapshot.Participants = append([]Participant{}, room.Participants...)
+	return NewMessage(MessageTypeRoomUpdate, uuid.Nil, room.ID, snapshot)
+}
+
 // IsValid validates the message
 func (m *Message) IsValid() bool {
 	if m.Type == "" || m.RoomID == uuid.Nil {
@@ -135,13 +266,19 @@ func (m *Message) IsValid() bool {
 		return m.SenderUserID != uuid.Nil
 	case MessageTypeWebRTCOffer, MessageTypeWebRTCAnswer, MessageTypeICECandidate:
 		return m.SenderUserID != uuid.Nil && m.TargetUserID != uuid.Nil
-	case MessageTypeMuteUser, MessageTypeAdmitUser:
+	case MessageTypeMuteUser, MessageTypeAdmitUser, MessageTypeKickUser:
 		return m.SenderUserID != uuid.Nil
 	default:
 		return true
 	}
 }
 
+// IsAdmitted reports whether the message is an admit user message letting its target user in
+func (m *Message) IsAdmitted() bool {
+	payload, ok := m.Payload.(ControlPayload)
+	return m.Type == MessageTypeAdmitUser && ok && payload.Action == "admit"
+}
+
 // IsDirectMessage checks if the message is targeted to a specific user
 func (m *Message) IsDirectMessage() bool {
 	return m.TargetUserID != uuid.Nil
@@ -163,11 +300,68 @@ func (m *Message) ToJSON() ([]byte, error) {
 }
 
 // FromJSON creates message from JSON
+// Payloads of known message types are decoded into their typed structs
 func FromJSON(data []byte) (*Message, error) {
-	var msg Message
-	err := json.Unmarshal(data, &msg)
+	var raw struct {
+		Message
+		Payload json.RawMessage `json:"payload"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := raw.Message
+	msg.Payload, err = decodePayload(msg.Type, raw.Payload)
 	if err != nil {
 		return nil, err
 	}
 	return &msg, nil
 }
+
+// decodePayload decodes a raw payload according to the message type
+func decodePayload(msgType MessageType, data json.RawMessage) (interface{}, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var err error
+	switch msgType {
+	case MessageTypeChatMessage:
+		var payload ChatPayload
+		err = json.Unmarshal(data, &payload)
+		return payload, err
+	case MessageTypeWebRTCOffer, MessageTypeWebRTCAnswer:
+		var payload WebRTCPayload
+		err = json.Unmarshal(data, &payload)
+		return payload, err
+	case MessageTypeICECandidate:
+		var payload ICECandidatePayload
+		err = json.Unmarshal(data, &payload)
+		return payload, err
+	case MessageTypeUserJoined, MessageTypeUserLeft, MessageTypeUserWaiting:
+		var payload ParticipantPayload
+		err = json.Unmarshal(data, &payload)
+		return payload, err
+	case MessageTypeMuteUser, MessageTypeAdmitUser, MessageTypeKickUser:
+		var payload ControlPayload
+		err = json.Unmarshal(data, &payload)
+		return payload, err
+	case MessageTypeHostChanged:
+		var payload HostChangedPayload
+		err = json.Unmarshal(data, &payload)
+		return payload, err
+	case MessageTypeRoomClosed:
+		var payload RoomClosedPayload
+		err = json.Unmarshal(data, &payload)
+		return payload, err
+	case MessageTypeRoomUpdate:
+		var payload Room
+		err = json.Unmarshal(data, &payload)
+		return payload, err
+	default:
+		var payload interface{}
+		err = json.Unmarshal(data, &payload)
+		return payload, err
+	}
+}
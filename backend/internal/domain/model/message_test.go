@@ -152,6 +152,148 @@ func TestNewICECandidate(t *testing.T) {
 	}
 }
 
+func TestNewUserJoinedMessage(t *testing.T) {
+	roomID := uuid.New()
+	userID := uuid.New()
+
+	message := NewUserJoinedMessage(roomID, userID, "Test User")
+
+	if message.Type != MessageTypeUserJoined {
+		t.Errorf("Expected Type %s, got %s", MessageTypeUserJoined, message.Type)
+	}
+	if message.RoomID != roomID {
+		t.Errorf("Expected RoomID %s, got %s", roomID, message.RoomID)
+	}
+
+	payload, ok := message.Payload.(ParticipantPayload)
+	if !ok {
+		t.Fatal("Expected Payload to be ParticipantPayload")
+	}
+	if payload.UserID != userID || payload.UserName != "Test User" {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
+
+	left := NewUserLeftMessage(roomID, userID, "Test User")
+	if left.Type != MessageTypeUserLeft {
+		t.Errorf("Expected Type %s, got %s", MessageTypeUserLeft, left.Type)
+	}
+}
+
+func TestNewMuteUserMessage(t *testing.T) {
+	roomID := uuid.New()
+	actorID := uuid.New()
+	userID := uuid.New()
+
+	muted := NewMuteUserMessage(roomID, actorID, userID, true)
+	payload := muted.Payload.(ControlPayload)
+	if payload.Action != "mute" || payload.TargetID != userID {
+		t.Errorf("Unexpected mute payload %+v", payload)
+	}
+	if muted.SenderUserID != actorID {
+		t.Errorf("Expected SenderUserID %s, got %s", actorID, muted.SenderUserID)
+	}
+	if muted.IsDirectMessage() {
+		t.Error("Expected mute notification to be broadcast")
+	}
+	if !muted.IsValid() {
+		t.Error("Expected mute notification to be valid")
+	}
+
+	unmuted := NewMuteUserMessage(roomID, userID, userID, false)
+	if unmuted.Payload.(ControlPayload).Action != "unmute" {
+		t.Errorf("Expected Action unmute, got %s", unmuted.Payload.(ControlPayload).Action)
+	}
+	if !unmuted.IsValid() {
+		t.Error("Expected unmute notification to be valid")
+	}
+}
+
+func TestNewUserWaitingMessage(t *testing.T) {
+	roomID := uuid.New()
+	hostID := uuid.New()
+	userID := uuid.New()
+
+	message := NewUserWaitingMessage(roomID, hostID, userID, "Alice")
+
+	if message.Type != MessageTypeUserWaiting {
+		t.Errorf("Expected Type %s, got %s", MessageTypeUserWaiting, message.Type)
+	}
+	if message.TargetUserID != hostID {
+		t.Errorf("Expected message to target host %s, got %s", hostID, message.TargetUserID)
+	}
+	payload := message.Payload.(ParticipantPayload)
+	if payload.UserID != userID || payload.UserName != "Alice" {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
+}
+
+func TestNewAdmitUserMessage(t *testing.T) {
+	roomID := uuid.New()
+	hostID := uuid.New()
+	userID := uuid.New()
+
+	admitted := NewAdmitUserMessage(roomID, hostID, userID, true, "")
+	if admitted.TargetUserID != userID || admitted.SenderUserID != hostID {
+		t.Errorf("Expected message from host to user, got %+v", admitted)
+	}
+	if !admitted.IsValid() {
+		t.Error("Expected admit message to be valid")
+	}
+	if payload := admitted.Payload.(ControlPayload); payload.Action != "admit" || payload.TargetID != userID {
+		t.Errorf("Unexpected admit payload %+v", payload)
+	}
+
+	denied := NewAdmitUserMessage(roomID, hostID, userID, false, "meeting is private")
+	payload := denied.Payload.(ControlPayload)
+	if payload.Action != "deny" {
+		t.Errorf("Expected Action deny, got %s", payload.Action)
+	}
+	if payload.Reason != "meeting is private" {
+		t.Errorf("Expected Reason, got %s", payload.Reason)
+	}
+}
+
+func TestNewKickUserMessage(t *testing.T) {
+	roomID := uuid.New()
+	hostID := uuid.New()
+	userID := uuid.New()
+
+	kicked := NewKickUserMessage(roomID, hostID, userID, false, "")
+	if kicked.Type != MessageTypeKickUser || kicked.TargetUserID != userID || kicked.SenderUserID != hostID {
+		t.Errorf("Expected kick message from host to user, got %+v", kicked)
+	}
+	if !kicked.IsValid() {
+		t.Error("Expected kick message to be valid")
+	}
+	if payload := kicked.Payload.(ControlPayload); payload.Action != "kick" {
+		t.Errorf("Expected Action kick, got %s", payload.Action)
+	}
+
+	banned := NewKickUserMessage(roomID, hostID, userID, true, "spam")
+	if payload := banned.Payload.(ControlPayload); payload.Action != "ban" || payload.Reason != "spam" {
+		t.Errorf("Unexpected ban payload %+v", payload)
+	}
+}
+
+func TestNewRoomUpdateMessage(t *testing.T) {
+	room := NewRoom("Test Room", uuid.New(), false)
+
+	message := NewRoomUpdateMessage(room)
+
+	if message.Type != MessageTypeRoomUpdate {
+		t.Errorf("Expected Type %s, got %s", MessageTypeRoomUpdate, message.Type)
+	}
+	if message.RoomID != room.ID {
+		t.Errorf("Expected RoomID %s, got %s", room.ID, message.RoomID)
+	}
+
+	// 後からルームを変更してもメッセージには影響しない
+	room.Name = "Changed"
+	if message.Payload.(Room).Name != "Test Room" {
+		t.Error("Expected payload to be a snapshot of the room")
+	}
+}
+
 func TestMessage_IsValid(t *testing.T) {
 	senderID := uuid.New()
 	targetID := uuid.New()
@@ -342,6 +484,137 @@ func TestMessage_ToJSON_FromJSON(t *testing.T) {
 	}
 }
 
+func TestFromJSON_TypedPayload(t *testing.T) {
+	senderID := uuid.New()
+	targetID := uuid.New()
+	roomID := uuid.New()
+
+	tests := []struct {
+		name    string
+		message *Message
+		check   func(t *testing.T, payload interface{})
+	}{
+		{
+			name:    "chat message",
+			message: NewChatMessage(senderID, roomID, "Hello", "Test User"),
+			check: func(t *testing.T, payload interface{}) {
+				chat, ok := payload.(ChatPayload)
+				if !ok {
+					t.Fatalf("Expected ChatPayload, got %T", payload)
+				}
+				if chat.Message != "Hello" || chat.UserName != "Test User" {
+					t.Errorf("Unexpected payload %+v", chat)
+				}
+			},
+		},
+		{
+			name:    "webrtc offer",
+			message: NewWebRTCOffer(senderID, targetID, roomID, "v=0"),
+			check: func(t *testing.T, payload interface{}) {
+				offer, ok := payload.(WebRTCPayload)
+				if !ok {
+					t.Fatalf("Expected WebRTCPayload, got %T", payload)
+				}
+				if offer.SDP != "v=0" || offer.Type != "offer" {
+					t.Errorf("Unexpected payload %+v", offer)
+				}
+			},
+		},
+		{
+			name:    "ice candidate",
+			message: NewICECandidate(senderID, targetID, roomID, "candidate:1", "0", 1),
+			check: func(t *testing.T, payload interface{}) {
+				candidate, ok := payload.(ICECandidatePayload)
+				if !ok {
+					t.Fatalf("Expected ICECandidatePayload, got %T", payload)
+				}
+				if candidate.SDPMLineIndex != 1 {
+					t.Errorf("Expected SDPMLineIndex 1, got %d", candidate.SDPMLineIndex)
+				}
+			},
+		},
+		{
+			name:    "mute user",
+			message: NewMessage(MessageTypeMuteUser, senderID, roomID, ControlPayload{Action: "mute", TargetID: targetID}),
+			check: func(t *testing.T, payload interface{}) {
+				control, ok := payload.(ControlPayload)
+				if !ok {
+					t.Fatalf("Expected ControlPayload, got %T", payload)
+				}
+				if control.TargetID != targetID {
+					t.Errorf("Expected TargetID %s, got %s", targetID, control.TargetID)
+				}
+			},
+		},
+		{
+			name:    "user waiting",
+			message: NewUserWaitingMessage(roomID, targetID, senderID, "Alice"),
+			check: func(t *testing.T, payload interface{}) {
+				waiting, ok := payload.(ParticipantPayload)
+				if !ok {
+					t.Fatalf("Expected ParticipantPayload, got %T", payload)
+				}
+				if waiting.UserID != senderID {
+					t.Errorf("Expected UserID %s, got %s", senderID, waiting.UserID)
+				}
+			},
+		},
+		{
+			name:    "host changed",
+			message: NewHostChangedMessage(roomID, senderID, targetID, HostChangeReasonLeft),
+			check: func(t *testing.T, payload interface{}) {
+				change, ok := payload.(HostChangedPayload)
+				if !ok {
+					t.Fatalf("Expected HostChangedPayload, got %T", payload)
+				}
+				if change.PreviousHostID != senderID || change.NewHostID != targetID || change.Reason != HostChangeReasonLeft {
+					t.Errorf("Unexpected payload %+v", change)
+				}
+			},
+		},
+		{
+			name:    "room closed",
+			message: NewRoomClosedMessage(roomID, RoomCloseReasonExpired),
+			check: func(t *testing.T, payload interface{}) {
+				closed, ok := payload.(RoomClosedPayload)
+				if !ok {
+					t.Fatalf("Expected RoomClosedPayload, got %T", payload)
+				}
+				if closed.Reason != RoomCloseReasonExpired {
+					t.Errorf("Expected Reason %s, got %s", RoomCloseReasonExpired, closed.Reason)
+				}
+			},
+		},
+		{
+			name:    "nil payload",
+			message: NewMessage(MessageTypeScreenShare, senderID, roomID, nil),
+			check: func(t *testing.T, payload interface{}) {
+				if payload != nil {
+					t.Errorf("Expected nil payload, got %v", payload)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.message.ToJSON()
+			if err != nil {
+				t.Fatalf("Expected no error from ToJSON, got %v", err)
+			}
+
+			parsed, err := FromJSON(data)
+			if err != nil {
+				t.Fatalf("Expected no error from FromJSON, got %v", err)
+			}
+			if parsed.Type != tt.message.Type {
+				t.Errorf("Expected Type %s, got %s", tt.message.Type, parsed.Type)
+			}
+			tt.check(t, parsed.Payload)
+		})
+	}
+}
+
 func TestFromJSON_InvalidJSON(t *testing.T) {
 	invalidJSON := []byte(`{"invalid": json}`)
 
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Role is a participant's role in a room
+type Role string
+
+const (
+	RoleHost      Role = "host"
+	RoleCoHost    Role = "co_host"
+	RolePresenter Role = "presenter"
+	RoleAttendee  Role = "attendee"
+	RoleViewer    Role = "viewer"
+)
+
+// Permission is an action in a room that depends on the participant's role
+type Permission string
+
+const (
+	PermissionUpdateRoom         Permission = "update the room"
+	PermissionDeleteRoom         Permission = "delete the room"
+	PermissionExtendRoom         Permission = "extend the room"
+	PermissionTransferHost       Permission = "transfer the host role"
+	PermissionManageRoles        Permission = "change participant roles"
+	PermissionManageWaitingRoom  Permission = "manage the waiting room"
+	PermissionManageInvites      Permission = "manage invites"
+	PermissionMuteParticipants   Permission = "mute participants"
+	PermissionRemoveParticipants Permission = "remove participants"
+	PermissionDeleteHistory      Permission = "delete chat history"
+	PermissionShareScreen        Permission = "share the screen"
+	PermissionSendChat           Permission = "send chat messages"
+	PermissionSpeak              Permission = "unmute"
+)
+
+// rolePermissions is the permission matrix consulted by Room.Authorize
+var rolePermissions = map[Role][]Permission{
+	RoleHost: {
+		PermissionUpdateRoom, PermissionDeleteRoom, PermissionExtendRoom, PermissionTransferHost,
+		PermissionManageRoles, PermissionManageWaitingRoom, PermissionManageInvites, PermissionMuteParticipants, PermissionRemoveParticipants, PermissionDeleteHistory,
+		PermissionShareScreen, PermissionSendChat, PermissionSpeak,
+	},
+	RoleCoHost: {
+		PermissionUpdateRoom, PermissionExtendRoom,
+		PermissionManageRoles, PermissionManageWaitingRoom, PermissionManageInvites, PermissionMuteParticipants, PermissionRemoveParticipants, PermissionDeleteHistory,
+		PermissionShareScreen, PermissionSendChat, PermissionSpeak,
+	},
+	RolePresenter: {PermissionShareScreen, PermissionSendChat, PermissionSpeak},
+	RoleAttendee:  {PermissionSendChat, PermissionSpeak},
+	RoleViewer:    {},
+}
+
+// roleRanks orders roles so a participant can only manage roles below their own
+var roleRanks = map[Role]int{
+	RoleViewer:    1,
+	RoleAttendee:  2,
+	RolePresenter: 3,
+	RoleCoHost:    4,
+	RoleHost:      5,
+}
+
+// Errors returned by role checks, usable with errors.Is
+var (
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrNotHost          = errors.New("only host can perform this operation")
+	ErrInvalidRole      = errors.New("invalid role")
+)
+
+// PermissionError is returned when a user's role does not grant a permission
+// It matches ErrPermissionDenied with errors.Is, and ErrNotHost when only the host has the permission
+type PermissionError struct {
+	Role       Role
+	Permission Permission
+}
+
+func (e *PermissionError) Error() string {
+	if e.Role == "" {
+		return fmt.Sprintf("permission denied: only participants can %s", e.Permission)
+	}
+	return fmt.Sprintf("permission denied: %s cannot %s", e.Role, e.Permission)
+}
+
+// Is reports whether target is ErrPermissionDenied, or ErrNotHost for a host permission
+func (e *PermissionError) Is(target error) bool {
+	switch target {
+	case ErrPermissionDenied:
+		return true
+	case ErrNotHost:
+		return e.Permission.IsHostOnly()
+	}
+	return false
+}
+
+// IsHostOnly checks if the host is the only role granted the permission
+func (p Permission) IsHostOnly() bool {
+	for role := range rolePermissions {
+		if role != RoleHost && role.Can(p) {
+			return false
+		}
+	}
+	return RoleHost.Can(p)
+}
+
+// IsValid checks if the role is one of the defined roles
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Can checks if the role grants the permission
+func (r Role) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CanSignal checks if the role may send a signaling message of the type
+// Screen shares need PermissionShareScreen and offers, which start sending media, PermissionSpeak or
+// PermissionShareScreen; every role can answer offers and exchange ICE candidates to receive media
+func (r Role) CanSignal(messageType MessageType) bool {
+	switch messageType {
+	case MessageTypeScreenShare:
+		return r.Can(PermissionShareScreen)
+	case MessageTypeWebRTCOffer:
+		return r.Can(PermissionSpeak) || r.Can(PermissionShareScreen)
+	case MessageTypeWebRTCAnswer, MessageTypeICECandidate:
+		return r.IsValid()
+	}
+	return false
+}
+
+// Outranks checks if the role is above other
+func (r Role) Outranks(other Role) bool {
+	return roleRanks[r] > roleRanks[other]
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRole_Can(t *testing.T) {
+	tests := []struct {
+		role       Role
+		permission Permission
+		expected   bool
+	}{
+		{RoleHost, PermissionDeleteRoom, true},
+		{RoleHost, PermissionTransferHost, true},
+		{RoleCoHost, PermissionMuteParticipants, true},
+		{RoleCoHost, PermissionDeleteRoom, false},
+		{RoleCoHost, PermissionTransferHost, false},
+		{RolePresenter, PermissionShareScreen, true},
+		{RolePresenter, PermissionMuteParticipants, false},
+		{RoleAttendee, PermissionSendChat, true},
+		{RoleAttendee, PermissionShareScreen, false},
+		{RoleViewer, PermissionSendChat, false},
+		{RoleViewer, PermissionSpeak, false},
+		{Role(""), PermissionSendChat, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.Can(tt.permission); got != tt.expected {
+			t.Errorf("Expected %s.Can(%q) to be %v, got %v", tt.role, tt.permission, tt.expected, got)
+		}
+	}
+}
+
+func TestRole_CanSignal(t *testing.T) {
+	tests := []struct {
+		role        Role
+		messageType MessageType
+		expected    bool
+	}{
+		{RolePresenter, MessageTypeScreenShare, true},
+		{RoleAttendee, MessageTypeScreenShare, false},
+		{RoleAttendee, MessageTypeWebRTCOffer, true},
+		{RoleViewer, MessageTypeWebRTCOffer, false},
+		{RoleViewer, MessageTypeWebRTCAnswer, true},
+		{RoleViewer, MessageTypeICECandidate, true},
+		{RoleViewer, MessageTypeScreenShare, false},
+		{Role(""), MessageTypeICECandidate, false},
+		{RoleHost, MessageTypeChatMessage, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.CanSignal(tt.messageType); got != tt.expected {
+			t.Errorf("Expected %s.CanSignal(%s) to be %v, got %v", tt.role, tt.messageType, tt.expected, got)
+		}
+	}
+}
+
+func TestPermissionError_Is(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		notHost bool
+	}{
+		{"host permission", &PermissionError{Role: RoleCoHost, Permission: PermissionDeleteRoom}, true},
+		{"transfer host", &PermissionError{Role: RoleAttendee, Permission: PermissionTransferHost}, true},
+		{"non-participant", &PermissionError{Permission: PermissionDeleteRoom}, true},
+		{"co-host permission", &PermissionError{Role: RoleAttendee, Permission: PermissionMuteParticipants}, false},
+		{"attendee permission", &PermissionError{Role: RoleViewer, Permission: PermissionSendChat}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, ErrPermissionDenied) {
+				t.Errorf("Expected %v to match ErrPermissionDenied", tt.err)
+			}
+			if got := errors.Is(tt.err, ErrNotHost); got != tt.notHost {
+				t.Errorf("Expected errors.Is(%v, ErrNotHost) to be %v, got %v", tt.err, tt.notHost, got)
+			}
+		})
+	}
+}
+
+func TestRole_Outranks(t *testing.T) {
+	order := []Role{RoleViewer, RoleAttendee, RolePresenter, RoleCoHost, RoleHost}
+	for i := 1; i < len(order); i++ {
+		if !order[i].Outranks(order[i-1]) {
+			t.Errorf("Expected %s to outrank %s", order[i], order[i-1])
+		}
+		if order[i-1].Outranks(order[i]) {
+			t.Errorf("Expected %s not to outrank %s", order[i-1], order[i])
+		}
+	}
+	if RoleHost.Outranks(RoleHost) {
+		t.Error("Expected a role not to outrank itself")
+	}
+}
+
+func TestRole_IsValid(t *testing.T) {
+	for _, role := range []Role{RoleHost, RoleCoHost, RolePresenter, RoleAttendee, RoleViewer} {
+		if !role.IsValid() {
+			t.Errorf("Expected %s to be valid", role)
+		}
+	}
+	if Role("owner").IsValid() {
+		t.Error("Expected unknown role to be invalid")
+	}
+}
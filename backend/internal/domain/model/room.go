@@ -2,69 +2,164 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// Errors returned by Room operations, usable with errors.Is
+var (
+	ErrAlreadyInRoom       = errors.New("user already in room")
+	ErrRoomFull            = errors.New("room is full")
+	ErrRoomExpired         = errors.New("room has expired")
+	ErrParticipantNotFound = errors.New("participant not found")
+	ErrUserBanned          = errors.New("user is banned from this room")
+	ErrRoomLocked          = errors.New("room is locked")
+	ErrInvalidPasscode     = errors.New("invalid passcode")
+)
+
+// DefaultMaxCapacity is the number of participants a room accepts (Google Meetクローンの要件)
+const DefaultMaxCapacity = 10
+
 // Room represents a meeting room
 type Room struct {
-	ID            uuid.UUID     `json:"id"`
-	Name          string        `json:"name"`
-	HostID        uuid.UUID     `json:"hostId"`
-	IsWaitingRoom bool          `json:"isWaitingRoom"`
-	CreatedAt     time.Time     `json:"createdAt"`
-	ExpiresAt     time.Time     `json:"expiresAt"`
-	Participants  []Participant `json:"participants"`
-	MaxCapacity   int           `json:"maxCapacity"`
+	ID                  uuid.UUID      `json:"id"`
+	Name                string         `json:"name"`
+	HostID              uuid.UUID      `json:"hostId"`
+	IsWaitingRoom       bool           `json:"isWaitingRoom"`
+	CreatedAt           time.Time      `json:"createdAt"`
+	ExpiresAt           time.Time      `json:"expiresAt"`
+	Participants        []Participant  `json:"participants"`
+	MaxCapacity         int            `json:"maxCapacity"`
+	BannedUserIDs       []uuid.UUID    `json:"bannedUserIds,omitempty"`
+	IsLocked            bool           `json:"isLocked"`
+	PasscodeHash        string         `json:"-"` // bcryptハッシュ（平文は保存しない）
+	Schedule            *Schedule      `json:"schedule,omitempty"`
+	CoHostIDs           []uuid.UUID    `json:"coHostIds,omitempty"` // 参加時に共同ホストになるユーザー
+	Occurrence          *OccurrenceRef `json:"occurrence,omitempty"`
+	AutoDelete          bool           `json:"autoDelete"`          // 最後の参加者が退出したら削除する
+	MaxExtensionMinutes int            `json:"maxExtensionMinutes"` // 延長できる合計
+	ExtendedMinutes     int            `json:"extendedMinutes"`     // 延長済みの合計
+	Version             int            `json:"version"`             // 楽観的排他制御（保存するたびに増える）
 }
 
 // Participant represents a participant in a room
 type Participant struct {
 	UserID   uuid.UUID `json:"userId"`
 	IsHost   bool      `json:"isHost"`
+	Role     Role      `json:"role"`
 	IsMuted  bool      `json:"isMuted"`
 	JoinedAt time.Time `json:"joinedAt"`
 }
 
-// NewRoom creates a new room
+// NewRoom creates a new room with DefaultRoomOptions
 func NewRoom(name string, hostID uuid.UUID, isWaitingRoom bool) *Room {
+	options := DefaultRoomOptions()
+	options.IsWaitingRoom = isWaitingRoom
+	return NewRoomWithOptions(name, hostID, options)
+}
+
+// NewRoomWithOptions creates a new room that expires after options.Lifetime
+// Options should be validated with RoomPolicy.Validate first
+func NewRoomWithOptions(name string, hostID uuid.UUID, options RoomOptions) *Room {
 	now := time.Now()
 	return &Room{
-		ID:            uuid.New(),
-		Name:          name,
-		HostID:        hostID,
-		IsWaitingRoom: isWaitingRoom,
-		CreatedAt:     now,
-		ExpiresAt:     now.Add(24 * time.Hour), // 24時間後に期限切れ
-		Participants:  []Participant{},
-		MaxCapacity:   10, // Google Meetクローンの要件
+		ID:                  uuid.New(),
+		Name:                name,
+		HostID:              hostID,
+		IsWaitingRoom:       options.IsWaitingRoom,
+		CreatedAt:           now,
+		ExpiresAt:           now.Add(options.Lifetime),
+		Participants:        []Participant{},
+		MaxCapacity:         options.MaxCapacity,
+		AutoDelete:          options.AutoDelete,
+		MaxExtensionMinutes: int(options.MaxExtension / time.Minute),
+		Version:             1,
+	}
+}
+
+// NewScheduledRoom creates a new room for a scheduled meeting
+// The room expires ScheduleGracePeriod after the planned end
+func NewScheduledRoom(name string, hostID uuid.UUID, isWaitingRoom bool, schedule *Schedule) *Room {
+	room := NewRoom(name, hostID, isWaitingRoom)
+	room.SetSchedule(schedule)
+	return room
+}
+
+// SetSchedule (re)schedules the room and derives ExpiresAt from the planned end
+// Rescheduling increments Schedule.Sequence so calendar clients replace the previous entry,
+// and resets earlier extensions since they were relative to the previous end
+func (r *Room) SetSchedule(schedule *Schedule) {
+	if r.Schedule != nil {
+		schedule.Sequence = r.Schedule.Sequence + 1
 	}
+	r.Schedule = schedule
+	r.ExpiresAt = schedule.EndsAt.Add(ScheduleGracePeriod)
+	r.ExtendedMinutes = 0
+}
+
+// IsOpen checks if participants other than the host can join
+// Unscheduled rooms are always open; scheduled rooms open at Schedule.JoinOpensAt
+func (r *Room) IsOpen() bool {
+	return r.Schedule == nil || !time.Now().Before(r.Schedule.JoinOpensAt())
 }
 
-// AddParticipant adds a participant to the room
+// AddParticipant adds a participant to the room as an attendee
 func (r *Room) AddParticipant(userID uuid.UUID) error {
+	return r.AddParticipantWithRole(userID, RoleAttendee)
+}
+
+// AddParticipantWithRole adds a participant to the room with the given role
+// The host always joins with RoleHost, ignoring role; nobody else can be given RoleHost
+func (r *Room) AddParticipantWithRole(userID uuid.UUID, role Role) error {
+	if userID != r.HostID && (!role.IsValid() || role == RoleHost) {
+		return fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+
 	// 既に参加しているかチェック
 	for _, p := range r.Participants {
 		if p.UserID == userID {
-			return errors.New("user already in room")
+			return ErrAlreadyInRoom
 		}
 	}
 
+	// 退出させられたユーザーは再入室できない
+	if r.IsBanned(userID) {
+		return ErrUserBanned
+	}
+
+	// 開始前のルームにはホストしか入れない
+	if userID != r.HostID && !r.IsOpen() {
+		return ErrRoomNotStarted
+	}
+
 	// 定員チェック
 	if len(r.Participants) >= r.MaxCapacity {
-		return errors.New("room is full")
+		return ErrRoomFull
 	}
 
 	// 期限チェック
 	if time.Now().After(r.ExpiresAt) {
-		return errors.New("room has expired")
+		return ErrRoomExpired
+	}
+
+	// 指定された共同ホストは共同ホストとして参加する
+	if r.IsDesignatedCoHost(userID) && RoleCoHost.Outranks(role) {
+		role = RoleCoHost
+	}
+
+	if userID == r.HostID {
+		role = RoleHost
 	}
 
+	// 発言できない役割 (視聴者) はミュートで参加する
 	participant := Participant{
 		UserID:   userID,
 		IsHost:   userID == r.HostID,
-		IsMuted:  false,
+		Role:     role,
+		IsMuted:  !role.Can(PermissionSpeak),
 		JoinedAt: time.Now(),
 	}
 
@@ -72,6 +167,15 @@ func (r *Room) AddParticipant(userID uuid.UUID) error {
 	return nil
 }
 
+// ShouldDeleteWhenEmpty checks if the room is deleted once its last participant has left
+// Scheduled rooms, including the occurrences of a series, stay until their planned end so participants can rejoin
+func (r *Room) ShouldDeleteWhenEmpty() bool {
+	if !r.AutoDelete || len(r.Participants) > 0 {
+		return false
+	}
+	return r.Schedule == nil || !time.Now().Before(r.Schedule.EndsAt)
+}
+
 // RemoveParticipant removes a participant from the room
 func (r *Room) RemoveParticipant(userID uuid.UUID) error {
 	for i, p := range r.Participants {
@@ -81,7 +185,50 @@ func (r *Room) RemoveParticipant(userID uuid.UUID) error {
 			return nil
 		}
 	}
-	return errors.New("participant not found")
+	return ErrParticipantNotFound
+}
+
+// KickParticipant removes a participant from the room (requires PermissionRemoveParticipants)
+// Participants can only remove roles below their own; with ban the user cannot rejoin for the room's lifetime
+func (r *Room) KickParticipant(actorID, userID uuid.UUID, ban bool) error {
+	if err := r.Authorize(actorID, PermissionRemoveParticipants); err != nil {
+		return err
+	}
+
+	participant, err := r.GetParticipant(userID)
+	if err != nil {
+		return err
+	}
+
+	actorRole := r.RoleOf(actorID)
+	if !actorRole.Outranks(participant.Role) {
+		return &PermissionError{Role: actorRole, Permission: PermissionRemoveParticipants}
+	}
+
+	if err := r.RemoveParticipant(userID); err != nil {
+		return err
+	}
+	if ban {
+		r.Ban(userID)
+	}
+	return nil
+}
+
+// Ban prevents a user from joining the room again
+func (r *Room) Ban(userID uuid.UUID) {
+	if !r.IsBanned(userID) {
+		r.BannedUserIDs = append(r.BannedUserIDs, userID)
+	}
+}
+
+// IsBanned checks if a user is banned from the room
+func (r *Room) IsBanned(userID uuid.UUID) bool {
+	for _, id := range r.BannedUserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
 }
 
 // GetParticipant returns a participant by user ID
@@ -91,13 +238,14 @@ func (r *Room) GetParticipant(userID uuid.UUID) (*Participant, error) {
 			return &r.Participants[i], nil
 		}
 	}
-	return nil, errors.New("participant not found")
+	return nil, ErrParticipantNotFound
 }
 
-// MuteParticipant mutes a participant (only host can do this)
-func (r *Room) MuteParticipant(hostID, targetUserID uuid.UUID) error {
-	if hostID != r.HostID {
-		return errors.New("only host can mute participants")
+// MuteParticipant mutes a participant (requires PermissionMuteParticipants)
+// Participants can only mute roles below their own
+func (r *Room) MuteParticipant(actorID, targetUserID uuid.UUID) error {
+	if err := r.Authorize(actorID, PermissionMuteParticipants); err != nil {
+		return err
 	}
 
 	participant, err := r.GetParticipant(targetUserID)
@@ -105,26 +253,181 @@ func (r *Room) MuteParticipant(hostID, targetUserID uuid.UUID) error {
 		return err
 	}
 
+	actorRole := r.RoleOf(actorID)
+	if !actorRole.Outranks(r.RoleOf(targetUserID)) {
+		return &PermissionError{Role: actorRole, Permission: PermissionMuteParticipants}
+	}
+
 	participant.IsMuted = true
 	return nil
 }
 
 // UnmuteParticipant unmutes a participant
+// Returns a PermissionError if the participant's role does not grant PermissionSpeak
 func (r *Room) UnmuteParticipant(userID uuid.UUID) error {
 	participant, err := r.GetParticipant(userID)
 	if err != nil {
 		return err
 	}
 
+	if err := r.Authorize(userID, PermissionSpeak); err != nil {
+		return err
+	}
+
 	participant.IsMuted = false
 	return nil
 }
 
+// RoleOf returns the user's role, or an empty Role if the user is not a participant
+// The host always has RoleHost
+func (r *Room) RoleOf(userID uuid.UUID) Role {
+	if userID == r.HostID {
+		return RoleHost
+	}
+
+	participant, err := r.GetParticipant(userID)
+	if err != nil {
+		return ""
+	}
+	return participant.Role
+}
+
+// Can checks if the user's role grants the permission
+func (r *Room) Can(userID uuid.UUID, permission Permission) bool {
+	return r.RoleOf(userID).Can(permission)
+}
+
+// Authorize returns a PermissionError unless the user's role grants the permission
+func (r *Room) Authorize(userID uuid.UUID, permission Permission) error {
+	role := r.RoleOf(userID)
+	if !role.Can(permission) {
+		return &PermissionError{Role: role, Permission: permission}
+	}
+	return nil
+}
+
+// SetRole changes a participant's role (requires PermissionManageRoles)
+// Participants can only change roles below their own, and the host role moves only with TransferHost
+func (r *Room) SetRole(actorID, userID uuid.UUID, role Role) error {
+	if !role.IsValid() || role == RoleHost {
+		return fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+
+	if err := r.Authorize(actorID, PermissionManageRoles); err != nil {
+		return err
+	}
+
+	participant, err := r.GetParticipant(userID)
+	if err != nil {
+		return err
+	}
+
+	// 自分以上の役割は変更・付与できない
+	actorRole := r.RoleOf(actorID)
+	if !actorRole.Outranks(participant.Role) || !actorRole.Outranks(role) {
+		return &PermissionError{Role: actorRole, Permission: PermissionManageRoles}
+	}
+
+	participant.Role = role
+
+	// 発言できない役割に変更されたらミュートする
+	if !role.Can(PermissionSpeak) {
+		participant.IsMuted = true
+	}
+	return nil
+}
+
+// TransferHost makes another participant the host
+// The previous host becomes an attendee
+func (r *Room) TransferHost(newHostID uuid.UUID) error {
+	if _, err := r.GetParticipant(newHostID); err != nil {
+		return err
+	}
+
+	r.HostID = newHostID
+	for i := range r.Participants {
+		p := &r.Participants[i]
+		switch {
+		case p.UserID == newHostID:
+			p.IsHost, p.Role = true, RoleHost
+		case p.IsHost:
+			// 元のホストは一般参加者になる
+			p.IsHost, p.Role = false, RoleAttendee
+		}
+	}
+	return nil
+}
+
+// NextHost returns the participant who should take over from the current host
+// Co-hosts are preferred over other participants; ties go to whoever joined first
+func (r *Room) NextHost() (uuid.UUID, bool) {
+	var next *Participant
+	for i := range r.Participants {
+		p := &r.Participants[i]
+		if p.UserID == r.HostID {
+			continue
+		}
+		isCoHost, nextIsCoHost := p.Role == RoleCoHost, next != nil && next.Role == RoleCoHost
+		if next == nil ||
+			(isCoHost && !nextIsCoHost) ||
+			(isCoHost == nextIsCoHost && p.JoinedAt.Before(next.JoinedAt)) {
+			next = p
+		}
+	}
+
+	if next == nil {
+		return uuid.Nil, false
+	}
+	return next.UserID, true
+}
+
+// SetPasscode stores a bcrypt hash of the passcode; an empty passcode removes it
+func (r *Room) SetPasscode(passcode string) error {
+	if passcode == "" {
+		r.PasscodeHash = ""
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(passcode), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash passcode: %w", err)
+	}
+	r.PasscodeHash = string(hash)
+	return nil
+}
+
+// HasPasscode checks if joining the room requires a passcode
+func (r *Room) HasPasscode() bool {
+	return r.PasscodeHash != ""
+}
+
+// CheckPasscode returns ErrInvalidPasscode unless the passcode matches
+// Rooms without a passcode accept any input
+func (r *Room) CheckPasscode(passcode string) error {
+	if !r.HasPasscode() {
+		return nil
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(r.PasscodeHash), []byte(passcode)); err != nil {
+		return ErrInvalidPasscode
+	}
+	return nil
+}
+
 // IsHost checks if a user is the host
 func (r *Room) IsHost(userID uuid.UUID) bool {
 	return r.HostID == userID
 }
 
+// IsDesignatedCoHost checks if a user becomes a co-host when joining
+func (r *Room) IsDesignatedCoHost(userID uuid.UUID) bool {
+	for _, id := range r.CoHostIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
 // IsParticipant checks if a user is a participant
 func (r *Room) IsParticipant(userID uuid.UUID) bool {
 	_, err := r.GetParticipant(userID)
@@ -147,6 +450,24 @@ func (r *Room) IsFull() bool {
 }
 
 // ExtendExpiry extends the room expiry time
-func (r *Room) ExtendExpiry(duration time.Duration) {
+// Returns ErrExtensionLimit if the room's extensions would exceed MaxExtensionMinutes in total
+func (r *Room) ExtendExpiry(duration time.Duration) error {
+	return r.extendExpiry(duration, r.MaxExtensionMinutes)
+}
+
+// ExtendExpiryWithin extends the room expiry time like ExtendExpiry, also keeping the extensions within limit in total
+// MaxExtensionMinutes is left as is, so the room's own limit applies again if limit is raised
+func (r *Room) ExtendExpiryWithin(duration, limit time.Duration) error {
+	return r.extendExpiry(duration, min(r.MaxExtensionMinutes, int(limit/time.Minute)))
+}
+
+func (r *Room) extendExpiry(duration time.Duration, maxMinutes int) error {
+	minutes := int(duration / time.Minute)
+	if r.ExtendedMinutes+minutes > maxMinutes {
+		return fmt.Errorf("%w: %d of %d minutes left", ErrExtensionLimit, max(maxMinutes-r.ExtendedMinutes, 0), maxMinutes)
+	}
+
 	r.ExpiresAt = r.ExpiresAt.Add(duration)
+	r.ExtendedMinutes += minutes
+	return nil
 }
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Room lifetime defaults
+const (
+	// DefaultRoomLifetime is how long an unscheduled room stays open
+	DefaultRoomLifetime = 24 * time.Hour
+
+	// DefaultMaxRoomExtension is how far a room's expiry can be extended in total
+	DefaultMaxRoomExtension = 24 * time.Hour
+)
+
+// Errors returned by room options and policies, usable with errors.Is
+var (
+	ErrInvalidRoomOptions = errors.New("invalid room options")
+	ErrExtensionLimit     = errors.New("room cannot be extended any further")
+)
+
+// RoomOptions configure a new room
+type RoomOptions struct {
+	MaxCapacity   int
+	Lifetime      time.Duration // 予定された会議では予定の終了時刻が優先される
+	MaxExtension  time.Duration // 延長できる合計時間
+	IsWaitingRoom bool
+	AutoDelete    bool // 最後の参加者が退出したら削除する
+}
+
+// DefaultRoomOptions returns the options rooms had before policies were configurable
+func DefaultRoomOptions() RoomOptions {
+	return RoomOptions{
+		MaxCapacity:  DefaultMaxCapacity,
+		Lifetime:     DefaultRoomLifetime,
+		MaxExtension: DefaultMaxRoomExtension,
+		AutoDelete:   true,
+	}
+}
+
+// RoomPolicy is the tenant-level limits that room options are validated against
+// Defaults fill in the options a host does not set
+type RoomPolicy struct {
+	MaxCapacity  int
+	MaxLifetime  time.Duration
+	MaxExtension time.Duration
+	Defaults     RoomOptions
+}
+
+// DefaultRoomPolicy returns the policy matching DefaultRoomOptions
+func DefaultRoomPolicy() RoomPolicy {
+	return RoomPolicy{
+		MaxCapacity:  DefaultMaxCapacity,
+		MaxLifetime:  DefaultRoomLifetime,
+		MaxExtension: DefaultMaxRoomExtension,
+		Defaults:     DefaultRoomOptions(),
+	}
+}
+
+// NewRoomPolicy creates a policy with the given limits
+// Default capacity and lifetime are DefaultRoomOptions capped by the limits, and rooms can be extended up to the limit
+func NewRoomPolicy(maxCapacity int, maxLifetime, maxExtension time.Duration, waitingRoomByDefault bool) (RoomPolicy, error) {
+	policy := RoomPolicy{
+		MaxCapacity:  maxCapacity,
+		MaxLifetime:  maxLifetime,
+		MaxExtension: maxExtension,
+		Defaults: RoomOptions{
+			MaxCapacity:   min(DefaultMaxCapacity, maxCapacity),
+			Lifetime:      min(DefaultRoomLifetime, maxLifetime),
+			MaxExtension:  maxExtension,
+			IsWaitingRoom: waitingRoomByDefault,
+			AutoDelete:    true,
+		},
+	}
+
+	switch {
+	case maxCapacity < 1:
+		return RoomPolicy{}, fmt.Errorf("%w: policy capacity must be at least 1", ErrInvalidRoomOptions)
+	case maxLifetime <= 0:
+		return RoomPolicy{}, fmt.Errorf("%w: policy lifetime must be positive", ErrInvalidRoomOptions)
+	case maxExtension < 0:
+		return RoomPolicy{}, fmt.Errorf("%w: policy extension must not be negative", ErrInvalidRoomOptions)
+	}
+	return policy, nil
+}
+
+// Validate checks the options against the policy's limits
+func (p RoomPolicy) Validate(options RoomOptions) error {
+	switch {
+	case options.MaxCapacity < 1 || options.MaxCapacity > p.MaxCapacity:
+		return fmt.Errorf("%w: maxCapacity must be between 1 and %d", ErrInvalidRoomOptions, p.MaxCapacity)
+	case options.Lifetime <= 0 || options.Lifetime > p.MaxLifetime:
+		return fmt.Errorf("%w: lifetime must be positive and at most %s", ErrInvalidRoomOptions, p.MaxLifetime)
+	case options.MaxExtension < 0 || options.MaxExtension > p.MaxExtension:
+		return fmt.Errorf("%w: maxExtension must be between 0 and %s", ErrInvalidRoomOptions, p.MaxExtension)
+	}
+	return nil
+}
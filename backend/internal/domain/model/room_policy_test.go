@@ -0,0 +1,94 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRoom_DefaultOptions(t *testing.T) {
+	room := NewRoom("Test Room", uuid.New(), true)
+
+	if room.MaxCapacity != DefaultMaxCapacity || !room.AutoDelete || !room.IsWaitingRoom {
+		t.Errorf("Expected default options, got %+v", room)
+	}
+	if room.MaxExtensionMinutes != int(DefaultMaxRoomExtension/time.Minute) {
+		t.Errorf("Expected %v max extension, got %d minutes", DefaultMaxRoomExtension, room.MaxExtensionMinutes)
+	}
+	if lifetime := room.ExpiresAt.Sub(room.CreatedAt); lifetime != DefaultRoomLifetime {
+		t.Errorf("Expected lifetime %v, got %v", DefaultRoomLifetime, lifetime)
+	}
+}
+
+func TestNewRoomPolicy(t *testing.T) {
+	policy, err := NewRoomPolicy(50, 8*time.Hour, 2*time.Hour, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 既定値は従来の値を上限で切り詰めたもの
+	defaults := policy.Defaults
+	if defaults.MaxCapacity != DefaultMaxCapacity || defaults.Lifetime != 8*time.Hour || defaults.MaxExtension != 2*time.Hour {
+		t.Errorf("Expected capped defaults, got %+v", defaults)
+	}
+	if !defaults.IsWaitingRoom || !defaults.AutoDelete {
+		t.Errorf("Expected waiting room and auto-delete by default, got %+v", defaults)
+	}
+	if err := policy.Validate(defaults); err != nil {
+		t.Errorf("Expected defaults to satisfy the policy, got %v", err)
+	}
+
+	small, _ := NewRoomPolicy(4, time.Hour, 0, false)
+	if small.Defaults.MaxCapacity != 4 || small.Defaults.Lifetime != time.Hour {
+		t.Errorf("Expected defaults capped at the limits, got %+v", small.Defaults)
+	}
+
+	for _, tt := range []struct {
+		capacity            int
+		lifetime, extension time.Duration
+	}{
+		{0, time.Hour, 0},
+		{10, 0, 0},
+		{10, time.Hour, -time.Hour},
+	} {
+		if _, err := NewRoomPolicy(tt.capacity, tt.lifetime, tt.extension, false); !errors.Is(err, ErrInvalidRoomOptions) {
+			t.Errorf("Expected ErrInvalidRoomOptions for %+v, got %v", tt, err)
+		}
+	}
+}
+
+func TestRoomPolicy_Validate(t *testing.T) {
+	policy := DefaultRoomPolicy()
+
+	tests := []struct {
+		name   string
+		modify func(*RoomOptions)
+		valid  bool
+	}{
+		{"defaults", func(o *RoomOptions) {}, true},
+		{"smaller room", func(o *RoomOptions) { o.MaxCapacity = 2; o.Lifetime = time.Hour; o.MaxExtension = 0 }, true},
+		{"no capacity", func(o *RoomOptions) { o.MaxCapacity = 0 }, false},
+		{"capacity over limit", func(o *RoomOptions) { o.MaxCapacity = DefaultMaxCapacity + 1 }, false},
+		{"no lifetime", func(o *RoomOptions) { o.Lifetime = 0 }, false},
+		{"lifetime over limit", func(o *RoomOptions) { o.Lifetime = DefaultRoomLifetime + time.Minute }, false},
+		{"negative extension", func(o *RoomOptions) { o.MaxExtension = -time.Hour }, false},
+		{"extension over limit", func(o *RoomOptions) { o.MaxExtension = DefaultMaxRoomExtension + time.Hour }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := policy.Defaults
+			tt.modify(&options)
+
+			err := policy.Validate(options)
+			if tt.valid && err != nil {
+				t.Errorf("Expected valid options, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidRoomOptions) {
+				t.Errorf("Expected ErrInvalidRoomOptions, got %v", err)
+			}
+		})
+	}
+}
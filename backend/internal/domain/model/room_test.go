@@ -1,6 +1,9 @@
 package model
 
 import (
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -114,6 +117,9 @@ func TestRoom_AddParticipant_DuplicateUser(t *testing.T) {
 	if err.Error() != "user already in room" {
 		t.Errorf("Expected 'user already in room' error, got %v", err)
 	}
+	if !errors.Is(err, ErrAlreadyInRoom) {
+		t.Errorf("Expected ErrAlreadyInRoom, got %v", err)
+	}
 }
 
 func TestRoom_AddParticipant_RoomFull(t *testing.T) {
@@ -138,6 +144,9 @@ func TestRoom_AddParticipant_RoomFull(t *testing.T) {
 	if err.Error() != "room is full" {
 		t.Errorf("Expected 'room is full' error, got %v", err)
 	}
+	if !errors.Is(err, ErrRoomFull) {
+		t.Errorf("Expected ErrRoomFull, got %v", err)
+	}
 }
 
 func TestRoom_AddParticipant_ExpiredRoom(t *testing.T) {
@@ -155,6 +164,9 @@ func TestRoom_AddParticipant_ExpiredRoom(t *testing.T) {
 	if err.Error() != "room has expired" {
 		t.Errorf("Expected 'room has expired' error, got %v", err)
 	}
+	if !errors.Is(err, ErrRoomExpired) {
+		t.Errorf("Expected ErrRoomExpired, got %v", err)
+	}
 }
 
 func TestRoom_RemoveParticipant(t *testing.T) {
@@ -185,6 +197,9 @@ func TestRoom_RemoveParticipant(t *testing.T) {
 	if err.Error() != "participant not found" {
 		t.Errorf("Expected 'participant not found' error, got %v", err)
 	}
+	if !errors.Is(err, ErrParticipantNotFound) {
+		t.Errorf("Expected ErrParticipantNotFound, got %v", err)
+	}
 }
 
 func TestRoom_MuteParticipant(t *testing.T) {
@@ -212,8 +227,33 @@ func TestRoom_MuteParticipant(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for non-host muting, got nil")
 	}
-	if err.Error() != "only host can mute participants" {
-		t.Errorf("Expected 'only host can mute participants' error, got %v", err)
+	if err.Error() != "permission denied: only participants can mute participants" {
+		t.Errorf("Expected 'permission denied: only participants can mute participants' error, got %v", err)
+	}
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied, got %v", err)
+	}
+
+	// 一般参加者もミュートできない
+	if err := room.MuteParticipant(userID, userID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied for attendee, got %v", err)
+	}
+
+	// 共同ホストは自分と同じか上の役割をミュートできない
+	coHostID := uuid.New()
+	otherCoHostID := uuid.New()
+	room.AddParticipant(hostID)
+	room.AddParticipant(coHostID)
+	room.AddParticipant(otherCoHostID)
+	room.SetRole(hostID, coHostID, RoleCoHost)
+	room.SetRole(hostID, otherCoHostID, RoleCoHost)
+	for _, targetID := range []uuid.UUID{hostID, otherCoHostID} {
+		if err := room.MuteParticipant(coHostID, targetID); !errors.Is(err, ErrPermissionDenied) {
+			t.Errorf("Expected ErrPermissionDenied muting an equal or higher role, got %v", err)
+		}
+	}
+	if err := room.MuteParticipant(coHostID, userID); err != nil {
+		t.Errorf("Expected co-host to mute an attendee, got %v", err)
 	}
 }
 
@@ -290,10 +330,388 @@ func TestRoom_ExtendExpiry(t *testing.T) {
 
 	// 1時間延長
 	extension := 1 * time.Hour
-	room.ExtendExpiry(extension)
+	if err := room.ExtendExpiry(extension); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
 
 	expectedExpiry := originalExpiry.Add(extension)
 	if !room.ExpiresAt.Equal(expectedExpiry) {
 		t.Errorf("Expected ExpiresAt to be %v, got %v", expectedExpiry, room.ExpiresAt)
 	}
+	if room.ExtendedMinutes != 60 {
+		t.Errorf("Expected 60 extended minutes, got %d", room.ExtendedMinutes)
+	}
+}
+
+func TestRoom_ExtendExpiryLimit(t *testing.T) {
+	options := DefaultRoomOptions()
+	options.MaxExtension = 2 * time.Hour
+	room := NewRoomWithOptions("Test Room", uuid.New(), options)
+
+	if err := room.ExtendExpiry(90 * time.Minute); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expiry := room.ExpiresAt
+
+	// 合計が上限を超える延長は拒否する
+	if err := room.ExtendExpiry(time.Hour); !errors.Is(err, ErrExtensionLimit) {
+		t.Errorf("Expected ErrExtensionLimit, got %v", err)
+	}
+	if !room.ExpiresAt.Equal(expiry) {
+		t.Errorf("Expected ExpiresAt to stay %v, got %v", expiry, room.ExpiresAt)
+	}
+	if err := room.ExtendExpiry(30 * time.Minute); err != nil {
+		t.Errorf("Expected the remaining 30 minutes to be allowed, got %v", err)
+	}
+
+	// 予定を変更すると延長はやり直しになる
+	schedule, _ := NewSchedule(time.Now().Add(time.Hour), time.Now().Add(2*time.Hour), 0)
+	room.SetSchedule(schedule)
+	if room.ExtendedMinutes != 0 {
+		t.Errorf("Expected extensions to be reset, got %d", room.ExtendedMinutes)
+	}
+}
+
+func TestRoom_NextHost(t *testing.T) {
+	hostID := uuid.New()
+	first := uuid.New()
+	second := uuid.New()
+	third := uuid.New()
+	joinedAt := time.Now()
+
+	newRoom := func() *Room {
+		room := NewRoom("Test Room", hostID, false)
+		for i, userID := range []uuid.UUID{hostID, first, second, third} {
+			room.AddParticipant(userID)
+			room.Participants[i].JoinedAt = joinedAt.Add(time.Duration(i) * time.Minute)
+		}
+		return room
+	}
+
+	tests := []struct {
+		name     string
+		coHosts  []uuid.UUID
+		expected uuid.UUID
+	}{
+		{"longest-tenured participant", nil, first},
+		{"co-host first", []uuid.UUID{third}, third},
+		{"longest-tenured co-host", []uuid.UUID{third, second}, second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newRoom()
+			for _, userID := range tt.coHosts {
+				if err := room.SetRole(hostID, userID, RoleCoHost); err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+			}
+
+			next, ok := room.NextHost()
+			if !ok || next != tt.expected {
+				t.Errorf("Expected next host %s, got %s", tt.expected, next)
+			}
+		})
+	}
+
+	// ホストしかいない場合は後継者なし
+	alone := NewRoom("Test Room", hostID, false)
+	alone.AddParticipant(hostID)
+	if _, ok := alone.NextHost(); ok {
+		t.Error("Expected no next host in a room with only the host")
+	}
+}
+
+func TestRoom_TransferHost(t *testing.T) {
+	hostID := uuid.New()
+	userID := uuid.New()
+	room := NewRoom("Test Room", hostID, false)
+	room.AddParticipant(hostID)
+	room.AddParticipant(userID)
+	room.SetRole(hostID, userID, RoleCoHost)
+
+	if err := room.TransferHost(userID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if room.HostID != userID {
+		t.Errorf("Expected HostID %s, got %s", userID, room.HostID)
+	}
+	oldHost, _ := room.GetParticipant(hostID)
+	newHost, _ := room.GetParticipant(userID)
+	if oldHost.IsHost || !newHost.IsHost {
+		t.Errorf("Expected IsHost flags to move, got old=%v new=%v", oldHost.IsHost, newHost.IsHost)
+	}
+	if newHost.Role != RoleHost || oldHost.Role != RoleAttendee {
+		t.Errorf("Expected roles to move, got old=%s new=%s", oldHost.Role, newHost.Role)
+	}
+
+	if err := room.TransferHost(uuid.New()); !errors.Is(err, ErrParticipantNotFound) {
+		t.Errorf("Expected ErrParticipantNotFound, got %v", err)
+	}
+}
+
+func TestRoom_SetRole(t *testing.T) {
+	hostID := uuid.New()
+	coHostID := uuid.New()
+	userID := uuid.New()
+	room := NewRoom("Test Room", hostID, false)
+	for _, id := range []uuid.UUID{hostID, coHostID, userID} {
+		room.AddParticipant(id)
+	}
+
+	if err := room.SetRole(hostID, coHostID, RoleCoHost); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		actorID  uuid.UUID
+		userID   uuid.UUID
+		role     Role
+		expected error
+	}{
+		{"co-host promotes attendee", coHostID, userID, RolePresenter, nil},
+		{"co-host demotes to viewer", coHostID, userID, RoleViewer, nil},
+		{"attendee cannot manage roles", userID, coHostID, RoleViewer, ErrPermissionDenied},
+		{"co-host cannot grant co-host", coHostID, userID, RoleCoHost, ErrPermissionDenied},
+		{"co-host cannot demote host", coHostID, hostID, RoleAttendee, ErrPermissionDenied},
+		{"host role only moves by transfer", hostID, userID, RoleHost, ErrInvalidRole},
+		{"unknown role", hostID, userID, Role("owner"), ErrInvalidRole},
+		{"unknown participant", hostID, uuid.New(), RoleAttendee, ErrParticipantNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := room.SetRole(tt.actorID, tt.userID, tt.role)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, err)
+			}
+			if tt.expected == nil && room.RoleOf(tt.userID) != tt.role {
+				t.Errorf("Expected role %s, got %s", tt.role, room.RoleOf(tt.userID))
+			}
+		})
+	}
+}
+
+func TestRoom_Authorize(t *testing.T) {
+	hostID := uuid.New()
+	userID := uuid.New()
+	room := NewRoom("Test Room", hostID, false)
+	room.AddParticipant(hostID)
+	room.AddParticipant(userID)
+
+	if room.RoleOf(hostID) != RoleHost || room.RoleOf(userID) != RoleAttendee {
+		t.Fatalf("Expected host and attendee roles, got %s and %s", room.RoleOf(hostID), room.RoleOf(userID))
+	}
+	if err := room.Authorize(hostID, PermissionDeleteRoom); err != nil {
+		t.Errorf("Expected host to be authorized, got %v", err)
+	}
+
+	err := room.Authorize(userID, PermissionDeleteRoom)
+	var permissionErr *PermissionError
+	if !errors.As(err, &permissionErr) || permissionErr.Role != RoleAttendee || permissionErr.Permission != PermissionDeleteRoom {
+		t.Errorf("Expected PermissionError for attendee, got %v", err)
+	}
+}
+
+func TestRoom_KickParticipant(t *testing.T) {
+	hostID := uuid.New()
+	coHostID := uuid.New()
+	userID := uuid.New()
+	room := NewRoom("Test Room", hostID, false)
+	for _, id := range []uuid.UUID{hostID, coHostID, userID} {
+		room.AddParticipant(id)
+	}
+	room.SetRole(hostID, coHostID, RoleCoHost)
+
+	if err := room.KickParticipant(userID, coHostID, false); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied for attendee, got %v", err)
+	}
+	if err := room.KickParticipant(coHostID, hostID, false); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied kicking the host, got %v", err)
+	}
+	if err := room.KickParticipant(hostID, uuid.New(), false); !errors.Is(err, ErrParticipantNotFound) {
+		t.Errorf("Expected ErrParticipantNotFound, got %v", err)
+	}
+
+	// 退出のみなら再入室できる
+	if err := room.KickParticipant(coHostID, userID, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if room.IsParticipant(userID) || room.IsBanned(userID) {
+		t.Error("Expected kicked user to be removed but not banned")
+	}
+	if err := room.AddParticipant(userID); err != nil {
+		t.Fatalf("Expected kicked user to rejoin, got %v", err)
+	}
+
+	// 禁止された場合は再入室できない
+	if err := room.KickParticipant(hostID, userID, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !room.IsBanned(userID) {
+		t.Error("Expected user to be banned")
+	}
+	if err := room.AddParticipant(userID); !errors.Is(err, ErrUserBanned) {
+		t.Errorf("Expected ErrUserBanned, got %v", err)
+	}
+}
+
+func TestRoom_Passcode(t *testing.T) {
+	room := NewRoom("Test Room", uuid.New(), false)
+
+	if room.HasPasscode() {
+		t.Error("Expected new room to have no passcode")
+	}
+	if err := room.CheckPasscode(""); err != nil {
+		t.Errorf("Expected room without passcode to accept any input, got %v", err)
+	}
+
+	if err := room.SetPasscode("1234"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !room.HasPasscode() || room.PasscodeHash == "1234" {
+		t.Errorf("Expected passcode to be stored hashed, got %q", room.PasscodeHash)
+	}
+	if err := room.CheckPasscode("1234"); err != nil {
+		t.Errorf("Expected correct passcode to be accepted, got %v", err)
+	}
+	for _, wrong := range []string{"", "4321"} {
+		if err := room.CheckPasscode(wrong); !errors.Is(err, ErrInvalidPasscode) {
+			t.Errorf("Expected ErrInvalidPasscode for %q, got %v", wrong, err)
+		}
+	}
+
+	// ハッシュはJSONに含めない
+	data, _ := json.Marshal(room)
+	if strings.Contains(string(data), room.PasscodeHash) {
+		t.Error("Expected passcode hash to be omitted from JSON")
+	}
+
+	room.SetPasscode("")
+	if room.HasPasscode() {
+		t.Error("Expected passcode to be removed")
+	}
+}
+
+func TestRoom_AddParticipantWithRole(t *testing.T) {
+	hostID := uuid.New()
+	room := NewRoom("Test Room", hostID, false)
+	guestID := uuid.New()
+
+	if err := room.AddParticipantWithRole(guestID, RolePresenter); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if role := room.RoleOf(guestID); role != RolePresenter {
+		t.Errorf("Expected role %s, got %s", RolePresenter, role)
+	}
+
+	// ホストは常にRoleHostで参加する
+	if err := room.AddParticipantWithRole(hostID, RoleViewer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if role := room.RoleOf(hostID); role != RoleHost {
+		t.Errorf("Expected host role %s, got %s", RoleHost, role)
+	}
+
+	for _, role := range []Role{RoleHost, Role("owner")} {
+		if err := room.AddParticipantWithRole(uuid.New(), role); !errors.Is(err, ErrInvalidRole) {
+			t.Errorf("Expected ErrInvalidRole for %q, got %v", role, err)
+		}
+	}
+}
+
+func TestRoom_ViewersStayMuted(t *testing.T) {
+	hostID := uuid.New()
+	viewerID := uuid.New()
+	userID := uuid.New()
+	room := NewRoom("Test Room", hostID, false)
+	room.AddParticipant(hostID)
+	room.AddParticipant(userID)
+
+	// 視聴者はミュートで参加し、自分でミュートを解除できない
+	if err := room.AddParticipantWithRole(viewerID, RoleViewer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if viewer, _ := room.GetParticipant(viewerID); !viewer.IsMuted {
+		t.Error("Expected viewer to join muted")
+	}
+	if err := room.UnmuteParticipant(viewerID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied, got %v", err)
+	}
+
+	// 視聴者に変更されるとミュートされ、発言できる役割に戻れば解除できる
+	if err := room.SetRole(hostID, userID, RoleViewer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user, _ := room.GetParticipant(userID); !user.IsMuted {
+		t.Error("Expected demoted viewer to be muted")
+	}
+	if err := room.SetRole(hostID, userID, RoleAttendee); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := room.UnmuteParticipant(userID); err != nil {
+		t.Errorf("Expected attendee to unmute, got %v", err)
+	}
+}
+
+func TestRoom_ShouldDeleteWhenEmpty(t *testing.T) {
+	hostID := uuid.New()
+	schedule := func(endsAt time.Time) *Schedule {
+		return &Schedule{StartsAt: endsAt.Add(-time.Hour), EndsAt: endsAt}
+	}
+
+	tests := []struct {
+		name     string
+		schedule *Schedule
+		auto     bool
+		expected bool
+	}{
+		{"unscheduled", nil, true, true},
+		{"auto delete disabled", nil, false, false},
+		{"before the planned end", schedule(time.Now().Add(time.Hour)), true, false},
+		{"after the planned end", schedule(time.Now().Add(-time.Minute)), true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := NewRoom("Test Room", hostID, false)
+			room.AutoDelete = tt.auto
+			if tt.schedule != nil {
+				room.SetSchedule(tt.schedule)
+			}
+			if got := room.ShouldDeleteWhenEmpty(); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+
+			// 参加者が残っている間は削除しない
+			room.Participants = append(room.Participants, Participant{UserID: hostID})
+			if room.ShouldDeleteWhenEmpty() {
+				t.Error("Expected a room with participants to be kept")
+			}
+		})
+	}
+}
+
+func TestRoom_ExtendExpiryWithin(t *testing.T) {
+	options := DefaultRoomOptions()
+	options.MaxExtension = 2 * time.Hour
+	room := NewRoomWithOptions("Test Room", uuid.New(), options)
+
+	// より厳しい上限でも確認するが、ルームの上限は変えない
+	if err := room.ExtendExpiryWithin(90*time.Minute, time.Hour); !errors.Is(err, ErrExtensionLimit) {
+		t.Errorf("Expected ErrExtensionLimit, got %v", err)
+	}
+	if err := room.ExtendExpiryWithin(time.Hour, time.Hour); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if room.MaxExtensionMinutes != 120 || room.ExtendedMinutes != 60 {
+		t.Errorf("Expected 60 of 120 minutes used, got %d of %d", room.ExtendedMinutes, room.MaxExtensionMinutes)
+	}
+
+	// 上限が緩めばルームの上限まで延長できる
+	if err := room.ExtendExpiryWithin(time.Hour, 24*time.Hour); err != nil {
+		t.Errorf("Expected the room's own limit to apply, got %v", err)
+	}
 }
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Scheduling limits
+const (
+	// DefaultEarlyJoinWindow is how long before the start participants can join by default
+	DefaultEarlyJoinWindow = 10 * time.Minute
+	MaxEarlyJoinWindow     = time.Hour
+
+	// MaxMeetingDuration matches the lifetime of unscheduled rooms
+	MaxMeetingDuration = 24 * time.Hour
+
+	// ScheduleGracePeriod keeps a scheduled room open after its planned end for meetings that run over
+	ScheduleGracePeriod = time.Hour
+)
+
+// Errors returned by scheduling, usable with errors.Is
+var (
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	ErrRoomNotStarted  = errors.New("room is not open yet")
+)
+
+// Schedule is the planned time of a meeting
+type Schedule struct {
+	StartsAt         time.Time `json:"startsAt"`
+	EndsAt           time.Time `json:"endsAt"`
+	EarlyJoinMinutes int       `json:"earlyJoinMinutes"`
+	Sequence         int       `json:"sequence"` // 予定を変更するたびに増える（iCalendarのSEQUENCE）
+}
+
+// NewSchedule creates a schedule from startsAt to endsAt that participants can join earlyJoin before the start
+func NewSchedule(startsAt, endsAt time.Time, earlyJoin time.Duration) (*Schedule, error) {
+	switch {
+	case !endsAt.After(startsAt):
+		return nil, fmt.Errorf("%w: end must be after start", ErrInvalidSchedule)
+	case endsAt.Sub(startsAt) > MaxMeetingDuration:
+		return nil, fmt.Errorf("%w: meetings cannot be longer than %s", ErrInvalidSchedule, MaxMeetingDuration)
+	case !endsAt.After(time.Now()):
+		return nil, fmt.Errorf("%w: end must be in the future", ErrInvalidSchedule)
+	case earlyJoin < 0 || earlyJoin > MaxEarlyJoinWindow:
+		return nil, fmt.Errorf("%w: early join window must be between 0 and %s", ErrInvalidSchedule, MaxEarlyJoinWindow)
+	}
+
+	return &Schedule{
+		StartsAt:         startsAt,
+		EndsAt:           endsAt,
+		EarlyJoinMinutes: int(earlyJoin / time.Minute),
+	}, nil
+}
+
+// JoinOpensAt returns when participants other than the host can start joining
+func (s *Schedule) JoinOpensAt() time.Time {
+	return s.StartsAt.Add(-time.Duration(s.EarlyJoinMinutes) * time.Minute)
+}
+
+// IsUpcoming checks if the meeting has not ended yet
+func (s *Schedule) IsUpcoming() bool {
+	return time.Now().Before(s.EndsAt)
+}
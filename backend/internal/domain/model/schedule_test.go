@@ -0,0 +1,88 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewSchedule(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		startsAt  time.Time
+		endsAt    time.Time
+		earlyJoin time.Duration
+		wantErr   bool
+	}{
+		{"valid", start, start.Add(30 * time.Minute), DefaultEarlyJoinWindow, false},
+		{"no early join", start, start.Add(time.Minute), 0, false},
+		{"already started", time.Now().Add(-time.Minute), start, 0, false},
+		{"end before start", start, start.Add(-time.Minute), 0, true},
+		{"zero length", start, start, 0, true},
+		{"too long", start, start.Add(MaxMeetingDuration + time.Minute), 0, true},
+		{"in the past", time.Now().Add(-2 * time.Hour), time.Now().Add(-time.Hour), 0, true},
+		{"negative early join", start, start.Add(time.Hour), -time.Minute, true},
+		{"early join too long", start, start.Add(time.Hour), MaxEarlyJoinWindow + time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewSchedule(tt.startsAt, tt.endsAt, tt.earlyJoin)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSchedule) {
+					t.Errorf("Expected ErrInvalidSchedule, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !schedule.JoinOpensAt().Equal(tt.startsAt.Add(-tt.earlyJoin)) {
+				t.Errorf("Expected join to open at %v, got %v", tt.startsAt.Add(-tt.earlyJoin), schedule.JoinOpensAt())
+			}
+		})
+	}
+}
+
+func TestNewScheduledRoom(t *testing.T) {
+	start := time.Now().Add(2 * time.Hour)
+	schedule, _ := NewSchedule(start, start.Add(time.Hour), DefaultEarlyJoinWindow)
+
+	room := NewScheduledRoom("Standup", uuid.New(), false, schedule)
+
+	if !room.ExpiresAt.Equal(schedule.EndsAt.Add(ScheduleGracePeriod)) {
+		t.Errorf("Expected ExpiresAt %v, got %v", schedule.EndsAt.Add(ScheduleGracePeriod), room.ExpiresAt)
+	}
+	if room.IsOpen() {
+		t.Error("Expected room not to be open before the early join window")
+	}
+	if !schedule.IsUpcoming() {
+		t.Error("Expected schedule to be upcoming")
+	}
+}
+
+func TestRoom_AddParticipant_BeforeStart(t *testing.T) {
+	hostID := uuid.New()
+	start := time.Now().Add(time.Hour)
+	schedule, _ := NewSchedule(start, start.Add(time.Hour), DefaultEarlyJoinWindow)
+	room := NewScheduledRoom("Standup", hostID, false, schedule)
+
+	if err := room.AddParticipant(uuid.New()); !errors.Is(err, ErrRoomNotStarted) {
+		t.Errorf("Expected ErrRoomNotStarted, got %v", err)
+	}
+
+	// ホストは開始前でも入室できる
+	if err := room.AddParticipant(hostID); err != nil {
+		t.Errorf("Expected host to join early, got %v", err)
+	}
+
+	// 早期入室の時間帯に入れば参加できる
+	room.Schedule.StartsAt = time.Now().Add(5 * time.Minute)
+	if err := room.AddParticipant(uuid.New()); err != nil {
+		t.Errorf("Expected join within the early join window, got %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/teambition/rrule-go"
+)
+
+// MaxListedOccurrences limits how many occurrences are computed at once
+const MaxListedOccurrences = 50
+
+// Errors returned by Series operations, usable with errors.Is
+var (
+	ErrInvalidRecurrence  = errors.New("invalid recurrence rule")
+	ErrOccurrenceNotFound = errors.New("occurrence not found")
+)
+
+// Series is a recurring meeting described by an RFC 5545 RRULE
+// Each occurrence is materialized as its own scheduled Room that reuses the series' settings
+type Series struct {
+	ID               uuid.UUID   `json:"id"`
+	Name             string      `json:"name"`
+	HostID           uuid.UUID   `json:"hostId"`
+	IsWaitingRoom    bool        `json:"isWaitingRoom"`
+	MaxCapacity      int         `json:"maxCapacity"`
+	CoHostIDs        []uuid.UUID `json:"coHostIds"`
+	StartsAt         time.Time   `json:"startsAt"` // 最初の回の開始時刻（DTSTART）
+	DurationMinutes  int         `json:"durationMinutes"`
+	EarlyJoinMinutes int         `json:"earlyJoinMinutes"`
+	RRule            string      `json:"rrule"`          // 例: FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR
+	TimeZone         string      `json:"timeZone"`       // IANAタイムゾーン（夏時間でも同じ現地時刻に繰り返す）
+	ExceptionDates   []time.Time `json:"exceptionDates"` // 中止した回の開始時刻（EXDATE）
+	Sequence         int         `json:"sequence"`       // 変更するたびに増える（iCalendarのSEQUENCE）
+	Version          int         `json:"version"`        // 楽観的排他制御（保存するたびに増える）
+	CreatedAt        time.Time   `json:"createdAt"`
+
+	// 各回のルームの設定（ルームの寿命は予定の終了時刻で決まる）
+	MaxExtensionMinutes int  `json:"maxExtensionMinutes"`
+	AutoDelete          bool `json:"autoDelete"`
+}
+
+// Occurrence is a single meeting of a series
+type Occurrence struct {
+	SeriesID uuid.UUID  `json:"seriesId"`
+	StartsAt time.Time  `json:"startsAt"`
+	EndsAt   time.Time  `json:"endsAt"`
+	RoomID   *uuid.UUID `json:"roomId,omitempty"` // 部屋が作成済みの場合のみ
+}
+
+// OccurrenceRef links a room to the series occurrence it was materialized for
+type OccurrenceRef struct {
+	SeriesID uuid.UUID `json:"seriesId"`
+	StartsAt time.Time `json:"startsAt"` // 元の開始時刻（予定変更後も変わらない）
+}
+
+// NewSeries creates a new meeting series
+// rule is an RRULE without DTSTART (which is startsAt) and must repeat at most daily;
+// an empty timeZone means UTC
+func NewSeries(name string, hostID uuid.UUID, isWaitingRoom bool, startsAt time.Time, duration, earlyJoin time.Duration, rule, timeZone string) (*Series, error) {
+	switch {
+	case duration <= 0 || duration > MaxMeetingDuration:
+		return nil, fmt.Errorf("%w: meetings must last between 1 minute and %s", ErrInvalidSchedule, MaxMeetingDuration)
+	case earlyJoin < 0 || earlyJoin > MaxEarlyJoinWindow:
+		return nil, fmt.Errorf("%w: early join window must be between 0 and %s", ErrInvalidSchedule, MaxEarlyJoinWindow)
+	}
+
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+
+	s := &Series{
+		ID:               uuid.New(),
+		Name:             name,
+		HostID:           hostID,
+		IsWaitingRoom:    isWaitingRoom,
+		MaxCapacity:      DefaultMaxCapacity,
+		CoHostIDs:        []uuid.UUID{},
+		StartsAt:         startsAt.Truncate(time.Second),
+		DurationMinutes:  int(duration / time.Minute),
+		EarlyJoinMinutes: int(earlyJoin / time.Minute),
+		RRule:            strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"),
+		TimeZone:         timeZone,
+		ExceptionDates:   []time.Time{},
+		Version:          1,
+		CreatedAt:        time.Now(),
+	}
+	if s.DurationMinutes == 0 {
+		return nil, fmt.Errorf("%w: meetings must last at least 1 minute", ErrInvalidSchedule)
+	}
+	defaults := DefaultRoomOptions()
+	s.MaxExtensionMinutes = int(defaults.MaxExtension / time.Minute)
+	s.AutoDelete = defaults.AutoDelete
+
+	if _, err := s.recurrence(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetCoHosts sets the users who join every occurrence as co-hosts
+// The host and duplicates are ignored
+func (s *Series) SetCoHosts(userIDs []uuid.UUID) {
+	s.CoHostIDs = []uuid.UUID{}
+	for _, id := range userIDs {
+		if id != s.HostID && !s.IsCoHost(id) {
+			s.CoHostIDs = append(s.CoHostIDs, id)
+		}
+	}
+}
+
+// IsCoHost checks if a user is a co-host of the series
+func (s *Series) IsCoHost(userID uuid.UUID) bool {
+	for _, id := range s.CoHostIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManage checks if a user can change the series' occurrences (the host and co-hosts)
+func (s *Series) CanManage(userID uuid.UUID) bool {
+	return userID == s.HostID || s.IsCoHost(userID)
+}
+
+// Duration returns how long each occurrence lasts
+func (s *Series) Duration() time.Duration {
+	return time.Duration(s.DurationMinutes) * time.Minute
+}
+
+// Occurrences returns up to n occurrences that have not ended by from, in order
+// Cancelled occurrences are skipped
+func (s *Series) Occurrences(from time.Time, n int) ([]Occurrence, error) {
+	rule, err := s.recurrence()
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := []Occurrence{}
+	next := rule.Iterator()
+	for len(occurrences) < n {
+		start, ok := next()
+		if !ok {
+			break
+		}
+
+		end := start.Add(s.Duration())
+		if !end.After(from) || s.isException(start) {
+			continue
+		}
+		occurrences = append(occurrences, Occurrence{SeriesID: s.ID, StartsAt: start, EndsAt: end})
+	}
+
+	return occurrences, nil
+}
+
+// Occurrence returns the occurrence starting at startsAt
+// Returns ErrOccurrenceNotFound if the rule does not produce it or it was cancelled
+func (s *Series) Occurrence(startsAt time.Time) (*Occurrence, error) {
+	rule, err := s.recurrence()
+	if err != nil {
+		return nil, err
+	}
+
+	if start := rule.After(startsAt, true); !start.Equal(startsAt) || s.isException(start) {
+		return nil, ErrOccurrenceNotFound
+	}
+	return &Occurrence{SeriesID: s.ID, StartsAt: startsAt, EndsAt: startsAt.Add(s.Duration())}, nil
+}
+
+// CancelOccurrence adds the occurrence starting at startsAt to the exceptions
+func (s *Series) CancelOccurrence(startsAt time.Time) error {
+	if _, err := s.Occurrence(startsAt); err != nil {
+		return err
+	}
+
+	s.ExceptionDates = append(s.ExceptionDates, startsAt)
+	s.Sequence++
+	return nil
+}
+
+// RoomOptions returns the options the rooms of its occurrences are created with
+func (s *Series) RoomOptions() RoomOptions {
+	return RoomOptions{
+		MaxCapacity:   s.MaxCapacity,
+		Lifetime:      s.Duration(),
+		MaxExtension:  time.Duration(s.MaxExtensionMinutes) * time.Minute,
+		IsWaitingRoom: s.IsWaitingRoom,
+		AutoDelete:    s.AutoDelete,
+	}
+}
+
+// NewOccurrenceRoom creates the scheduled room for an occurrence with the series' settings
+func (s *Series) NewOccurrenceRoom(occurrence *Occurrence) (*Room, error) {
+	schedule, err := NewSchedule(occurrence.StartsAt, occurrence.EndsAt, time.Duration(s.EarlyJoinMinutes)*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	room := NewRoomWithOptions(s.Name, s.HostID, s.RoomOptions())
+	room.SetSchedule(schedule)
+	room.CoHostIDs = append([]uuid.UUID(nil), s.CoHostIDs...)
+	room.Occurrence = &OccurrenceRef{SeriesID: s.ID, StartsAt: occurrence.StartsAt}
+	return room, nil
+}
+
+// recurrence parses the RRULE with the series' start in its time zone
+func (s *Series) recurrence() (*rrule.RRule, error) {
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidRecurrence, s.TimeZone)
+	}
+
+	if strings.Contains(strings.ToUpper(s.RRule), "DTSTART") {
+		return nil, fmt.Errorf("%w: DTSTART is set from the series start", ErrInvalidRecurrence)
+	}
+	option, err := rrule.StrToROptionInLocation(s.RRule, location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	// 会議の繰り返しは1日1回まで
+	if option.Freq > rrule.DAILY {
+		return nil, fmt.Errorf("%w: meetings can repeat at most daily", ErrInvalidRecurrence)
+	}
+
+	option.Dtstart = s.StartsAt.In(location)
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	return rule, nil
+}
+
+// isException checks if the occurrence starting at startsAt was cancelled
+func (s *Series) isException(startsAt time.Time) bool {
+	for _, t := range s.ExceptionDates {
+		if t.Equal(startsAt) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTestSeries(t *testing.T, startsAt time.Time, rule string) *Series {
+	t.Helper()
+	series, err := NewSeries("Standup", uuid.New(), true, startsAt, 15*time.Minute, 5*time.Minute, rule, "Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("NewSeries failed: %v", err)
+	}
+	return series
+}
+
+func TestNewSeries_Invalid(t *testing.T) {
+	start := time.Now()
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		rule     string
+		timeZone string
+		expected error
+	}{
+		{"zero duration", 0, "FREQ=DAILY", "", ErrInvalidSchedule},
+		{"too long", 25 * time.Hour, "FREQ=DAILY", "", ErrInvalidSchedule},
+		{"missing freq", time.Hour, "COUNT=3", "", ErrInvalidRecurrence},
+		{"unknown property", time.Hour, "FREQ=DAILY;FOO=1", "", ErrInvalidRecurrence},
+		{"hourly", time.Hour, "FREQ=HOURLY", "", ErrInvalidRecurrence},
+		{"dtstart", time.Hour, "FREQ=DAILY;DTSTART=20240101T090000Z", "", ErrInvalidRecurrence},
+		{"unknown time zone", time.Hour, "FREQ=DAILY", "Mars/Olympus", ErrInvalidRecurrence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSeries("Standup", uuid.New(), false, start, tt.duration, 0, tt.rule, tt.timeZone)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestSeries_Occurrences(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	// 2030-01-07は月曜日
+	start := time.Date(2030, 1, 7, 9, 30, 0, 0, tokyo)
+	series := newTestSeries(t, start, "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=6")
+
+	occurrences, err := series.Occurrences(start.Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(occurrences) != 6 {
+		t.Fatalf("Expected 6 occurrences, got %d", len(occurrences))
+	}
+
+	expectedDays := []int{7, 9, 11, 14, 16, 18}
+	for i, occurrence := range occurrences {
+		local := occurrence.StartsAt.In(tokyo)
+		if local.Day() != expectedDays[i] || local.Hour() != 9 || local.Minute() != 30 {
+			t.Errorf("Expected occurrence %d on day %d at 09:30, got %v", i, expectedDays[i], local)
+		}
+		if occurrence.EndsAt.Sub(occurrence.StartsAt) != 15*time.Minute {
+			t.Errorf("Expected 15 minute occurrence, got %v", occurrence.EndsAt.Sub(occurrence.StartsAt))
+		}
+	}
+
+	// 件数の制限と開始位置
+	occurrences, _ = series.Occurrences(time.Date(2030, 1, 10, 0, 0, 0, 0, tokyo), 2)
+	if len(occurrences) != 2 || occurrences[0].StartsAt.In(tokyo).Day() != 11 {
+		t.Errorf("Expected 2 occurrences from Jan 11, got %+v", occurrences)
+	}
+}
+
+func TestSeries_DaylightSaving(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	start := time.Date(2030, 3, 8, 9, 0, 0, 0, newYork)
+	series, err := NewSeries("Standup", uuid.New(), false, start, 15*time.Minute, 0, "FREQ=DAILY;COUNT=3", "America/New_York")
+	if err != nil {
+		t.Fatalf("NewSeries failed: %v", err)
+	}
+
+	occurrences, _ := series.Occurrences(start.Add(-time.Hour), 3)
+	for _, occurrence := range occurrences {
+		if hour := occurrence.StartsAt.In(newYork).Hour(); hour != 9 {
+			t.Errorf("Expected occurrences at 09:00 local time across DST, got %v", occurrence.StartsAt.In(newYork))
+		}
+	}
+}
+
+func TestSeries_CancelOccurrence(t *testing.T) {
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	series := newTestSeries(t, start, "FREQ=DAILY")
+
+	second := start.AddDate(0, 0, 1)
+	if err := series.CancelOccurrence(second); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	occurrences, _ := series.Occurrences(time.Now(), 2)
+	if len(occurrences) != 2 || !occurrences[1].StartsAt.Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("Expected cancelled occurrence to be skipped, got %+v", occurrences)
+	}
+
+	if _, err := series.Occurrence(second); !errors.Is(err, ErrOccurrenceNotFound) {
+		t.Errorf("Expected ErrOccurrenceNotFound for cancelled occurrence, got %v", err)
+	}
+	if _, err := series.Occurrence(start.Add(time.Minute)); !errors.Is(err, ErrOccurrenceNotFound) {
+		t.Errorf("Expected ErrOccurrenceNotFound for a time off the rule, got %v", err)
+	}
+	if _, err := series.Occurrence(start); err != nil {
+		t.Errorf("Expected first occurrence, got %v", err)
+	}
+}
+
+func TestSeries_NewOccurrenceRoom(t *testing.T) {
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	series := newTestSeries(t, start, "FREQ=DAILY")
+	coHostID := uuid.New()
+	series.SetCoHosts([]uuid.UUID{coHostID, coHostID, series.HostID})
+	series.MaxCapacity = 4
+	series.MaxExtensionMinutes = 30
+	series.AutoDelete = false
+
+	occurrence, _ := series.Occurrence(start)
+	room, err := series.NewOccurrenceRoom(occurrence)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if room.HostID != series.HostID || !room.IsWaitingRoom || room.MaxCapacity != 4 ||
+		room.MaxExtensionMinutes != 30 || room.AutoDelete {
+		t.Errorf("Expected room with the series settings, got %+v", room)
+	}
+	if !room.ExpiresAt.Equal(room.Schedule.EndsAt.Add(ScheduleGracePeriod)) {
+		t.Errorf("Expected room to expire after its scheduled end, got %v", room.ExpiresAt)
+	}
+	if room.Schedule.EarlyJoinMinutes != 5 || !room.Schedule.EndsAt.Equal(start.Add(15*time.Minute)) {
+		t.Errorf("Expected schedule of the occurrence, got %+v", room.Schedule)
+	}
+	if room.Occurrence == nil || room.Occurrence.SeriesID != series.ID || !room.Occurrence.StartsAt.Equal(start) {
+		t.Errorf("Expected occurrence reference, got %+v", room.Occurrence)
+	}
+	if len(room.CoHostIDs) != 1 || room.CoHostIDs[0] != coHostID {
+		t.Errorf("Expected one designated co-host, got %v", room.CoHostIDs)
+	}
+
+	// 指定された共同ホストは開場後に共同ホストとして参加する
+	room.Schedule.StartsAt = time.Now()
+	if err := room.AddParticipant(coHostID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if role := room.RoleOf(coHostID); role != RoleCoHost {
+		t.Errorf("Expected designated co-host to join as %s, got %s", RoleCoHost, role)
+	}
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "errors"
+
+// Errors returned by repositories, usable with errors.Is
+// Implementations may return their own errors as long as they wrap these
+var (
+	// ErrNotFound is returned when the requested entity does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict is returned when an entity was modified since it was read
+	ErrConflict = errors.New("modified concurrently")
+)
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+// Invite defines the interface for room invite data operations
+type Invite interface {
+	// Create creates a new invite
+	Create(ctx context.Context, invite *model.Invite) error
+
+	// GetByID retrieves an invite by ID
+	// Returns an error wrapping ErrNotFound if the invite does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (*model.Invite, error)
+
+	// GetByRoomID retrieves the invites of a room, oldest first
+	GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*model.Invite, error)
+
+	// Update updates an existing invite
+	// The stored uses are kept, since only IncrementUses changes them
+	// Returns an error wrapping ErrNotFound if the invite does not exist
+	Update(ctx context.Context, invite *model.Invite) error
+
+	// IncrementUses records a use of the invite if it is not revoked and has uses left
+	// The check and the increment are atomic, so concurrent redemptions cannot exceed MaxUses
+	// Returns an error wrapping ErrNotFound if the invite does not exist,
+	// or ErrConflict if it was revoked or used up
+	IncrementUses(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+// Outbox defines the interface for domain events waiting to be delivered
+// Events are added within the transaction that makes the change they describe
+type Outbox interface {
+	// Add stores an event to be delivered
+	Add(ctx context.Context, event *model.Event) error
+
+	// GetDue returns up to limit events whose NextAttemptAt is not after now, oldest first
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*model.Event, error)
+
+	// Reschedule stores the event's Attempts and NextAttemptAt after a failed delivery
+	// Returns an error wrapping ErrNotFound if the event does not exist
+	Reschedule(ctx context.Context, event *model.Event) error
+
+	// Delete removes a delivered event
+	// Deleting an event that was already removed is a no-op, as events may be delivered twice
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -2,32 +2,53 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/cline-meet/backend/internal/domain/model"
 	"github.com/google/uuid"
 )
 
+// RoomCounts is the number of active rooms and of participants in them
+type RoomCounts struct {
+	Rooms        int
+	Participants int
+	BySize       map[int]int // 参加者数ごとのルーム数
+}
+
 // RoomRepository defines the interface for room data operations
 type Room interface {
 	// Create creates a new room
 	Create(ctx context.Context, room *model.Room) error
 	
 	// GetByID retrieves a room by ID
+	// Returns an error wrapping ErrNotFound if the room does not exist
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Room, error)
 	
 	// GetByHostID retrieves rooms by host ID
 	GetByHostID(ctx context.Context, hostID uuid.UUID) ([]*model.Room, error)
 	
-	// Update updates an existing room
+	// GetUpcomingByHostID retrieves scheduled rooms by host ID that end after from, ordered by start time
+	GetUpcomingByHostID(ctx context.Context, hostID uuid.UUID, from time.Time) ([]*model.Room, error)
+	
+	// GetBySeriesID retrieves the rooms materialized for a series, ordered by occurrence
+	GetBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*model.Room, error)
+	
+	// Update updates an existing room and increments its Version
+	// Returns an error wrapping ErrNotFound if the room does not exist,
+	// or ErrConflict if room.Version is not the stored version (the room was updated since it was read)
 	Update(ctx context.Context, room *model.Room) error
 	
 	// Delete deletes a room
+	// Returns an error wrapping ErrNotFound if the room does not exist
 	Delete(ctx context.Context, id uuid.UUID) error
 	
 	// GetActiveRooms retrieves all active (non-expired) rooms
 	GetActiveRooms(ctx context.Context) ([]*model.Room, error)
+
+	// CountActiveRooms counts the active (non-expired) rooms, overall and by their number of participants, without loading them
+	CountActiveRooms(ctx context.Context) (RoomCounts, error)
 	
-	// CleanupExpiredRooms removes expired rooms
+	// CleanupExpiredRooms removes expired rooms and returns them with their participants
 	// This method is called by a background scheduler every 1 hour
-	CleanupExpiredRooms(ctx context.Context) error
+	CleanupExpiredRooms(ctx context.Context) ([]*model.Room, error)
 }
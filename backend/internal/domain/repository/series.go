@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+// Series defines the interface for meeting series data operations
+type Series interface {
+	// Create creates a new series
+	Create(ctx context.Context, series *model.Series) error
+
+	// GetByID retrieves a series by ID
+	// Returns an error wrapping ErrNotFound if the series does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (*model.Series, error)
+
+	// GetByHostID retrieves the series of a host, oldest first
+	GetByHostID(ctx context.Context, hostID uuid.UUID) ([]*model.Series, error)
+
+	// Update updates an existing series and increments series.Version
+	// Returns an error wrapping ErrNotFound if the series does not exist,
+	// or ErrConflict if series.Version is not the stored version (the series was updated since it was read)
+	Update(ctx context.Context, series *model.Series) error
+
+	// Delete deletes a series
+	// Returns an error wrapping ErrNotFound if the series does not exist
+	Delete(ctx context.Context, id uuid.UUID) error
+}
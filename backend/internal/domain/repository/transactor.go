@@ -0,0 +1,17 @@
+package repository
+
+import "context"
+
+// Transactor runs the repository calls of a usecase as one unit of work
+// Repositories join the transaction through the context passed to fn; stores that cannot
+// (such as Redis sessions) should be written last so that their failure rolls back the rest
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction, committing if fn returns nil and rolling back otherwise
+	// Calls nested within fn join the outer transaction
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// AfterCommit runs fn once the outermost transaction carried by ctx commits, or right away outside a transaction
+	// fn is dropped if the transaction rolls back; use it for side effects such as notifications
+	// that must not happen for changes that are never committed
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
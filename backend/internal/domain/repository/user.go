@@ -13,17 +13,22 @@ type User interface {
 	Create(ctx context.Context, user *model.User) error
 	
 	// GetByID retrieves a user by ID
+	// Returns an error wrapping ErrNotFound if the user does not exist
 	GetByID(ctx context.Context, id uuid.UUID) (*model.User, error)
 	
 	// GetByGoogleID retrieves a user by Google ID
+	// Returns an error wrapping ErrNotFound if the user does not exist
 	GetByGoogleID(ctx context.Context, googleID string) (*model.User, error)
 	
 	// GetByEmail retrieves a user by email
+	// Returns an error wrapping ErrNotFound if the user does not exist
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
 	
 	// Update updates an existing user
+	// Returns an error wrapping ErrNotFound if the user does not exist
 	Update(ctx context.Context, user *model.User) error
 	
 	// Delete deletes a user
+	// Returns an error wrapping ErrNotFound if the user does not exist
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// WaitingRoom defines the interface for the lobby of rooms with IsWaitingRoom enabled
+// Users wait here until the host admits or denies them
+type WaitingRoom interface {
+	// AddWaitingUser places a user in the room's waiting set
+	// Adding a user who is already waiting is a no-op
+	AddWaitingUser(ctx context.Context, roomID, userID uuid.UUID) error
+
+	// RemoveWaitingUser removes a user from the room's waiting set
+	// Returns an error wrapping ErrNotFound if the user is not waiting
+	RemoveWaitingUser(ctx context.Context, roomID, userID uuid.UUID) error
+
+	// IsWaitingUser checks if a user is in the room's waiting set
+	IsWaitingUser(ctx context.Context, roomID, userID uuid.UUID) (bool, error)
+
+	// GetWaitingUsers returns all users waiting for the room, in no particular order
+	GetWaitingUsers(ctx context.Context, roomID uuid.UUID) ([]uuid.UUID, error)
+
+	// DeleteWaitingUsers removes the room's waiting set
+	DeleteWaitingUsers(ctx context.Context, roomID uuid.UUID) error
+}
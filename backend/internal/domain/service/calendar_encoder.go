@@ -0,0 +1,20 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+)
+
+// ErrInvalidCalendar is returned when a calendar cannot be encoded or parsed, usable with errors.Is
+var ErrInvalidCalendar = errors.New("invalid calendar")
+
+// CalendarEncoder serializes calendars handed out to calendar clients
+type CalendarEncoder interface {
+	// Encode serializes the calendar
+	// Returns an error wrapping ErrInvalidCalendar if an event cannot be represented
+	Encode(calendar *model.Calendar) ([]byte, error)
+
+	// ContentType is the media type of the encoded calendar
+	ContentType() string
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConnectionClaims is the content of a signed WebSocket connection token
+type ConnectionClaims struct {
+	UserID    uuid.UUID `json:"uid"`
+	RoomID    uuid.UUID `json:"rid"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// ConnectionSigner issues and verifies the short-lived tokens that authenticate WebSocket connections
+// Browsers cannot set headers on the upgrade request, so the token is passed in the URL instead
+type ConnectionSigner interface {
+	// SignConnection encodes and signs the claims into an opaque URL-safe token
+	SignConnection(claims *ConnectionClaims) (string, error)
+
+	// VerifyConnection checks the token's signature and expiry and returns its claims
+	// Tokens signed for another purpose, such as invites, are rejected
+	// Returns ErrInvalidToken or ErrTokenExpired
+	VerifyConnection(token string) (*ConnectionClaims, error)
+}
@@ -0,0 +1,7 @@
+package service
+
+// FailureCounter counts the failed steps that do not fail the usecase running them
+type FailureCounter interface {
+	// CountFailure counts one failure of step
+	CountFailure(step string)
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+// Errors returned by InviteSigner.Verify and ConnectionSigner.VerifyConnection, usable with errors.Is
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token has expired")
+)
+
+// InviteClaims is the content of a signed invite token
+// The token only identifies the invite; its room, email restriction, role and limits are read from the
+// invite repository when it is redeemed, so the token reveals nothing about the room
+type InviteClaims struct {
+	InviteID  uuid.UUID `json:"iid"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// NewInviteClaims builds the claims for an invite
+func NewInviteClaims(invite *model.Invite) *InviteClaims {
+	return &InviteClaims{
+		InviteID:  invite.ID,
+		ExpiresAt: invite.ExpiresAt,
+	}
+}
+
+// InviteSigner issues and verifies tamper-proof invite tokens
+type InviteSigner interface {
+	// Sign encodes and signs the claims into an opaque URL-safe token
+	Sign(claims *InviteClaims) (string, error)
+
+	// Verify checks the token's signature and expiry and returns its claims
+	// Returns ErrInvalidToken or ErrTokenExpired
+	Verify(token string) (*InviteClaims, error)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// LeaderElector elects one pod among all replicas to run background jobs
+// Leadership is a lease that the leader must renew before it expires
+type LeaderElector interface {
+	// Acquire takes the lease or renews it if this pod already holds it
+	// It reports whether this pod is the leader for the next ttl
+	Acquire(ctx context.Context, ttl time.Duration) (bool, error)
+
+	// Release gives up the lease if this pod holds it, so another pod can take over immediately
+	Release(ctx context.Context) error
+}
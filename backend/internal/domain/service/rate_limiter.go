@@ -0,0 +1,12 @@
+package service
+
+import "context"
+
+// RateLimiter limits how many attempts can be made for a key within a time window
+type RateLimiter interface {
+	// Allow records an attempt for key and reports whether it is within the limit
+	Allow(ctx context.Context, key string) (bool, error)
+
+	// Reset forgets the attempts recorded for key
+	Reset(ctx context.Context, key string) error
+}
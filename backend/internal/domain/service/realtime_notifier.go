@@ -16,8 +16,8 @@ type RealtimeNotifier interface {
 	// NotifyRoomLeft notifies all participants that a user left the room
 	NotifyRoomLeft(ctx context.Context, roomID, userID uuid.UUID, userName string) error
 	
-	// NotifyUserMuted notifies all participants that a user was muted
-	NotifyUserMuted(ctx context.Context, roomID, userID uuid.UUID, isMuted bool) error
+	// NotifyUserMuted notifies all participants that the actor muted or unmuted a user
+	NotifyUserMuted(ctx context.Context, roomID, actorID, userID uuid.UUID, isMuted bool) error
 	
 	// BroadcastChatMessage broadcasts a chat message to all room participants
 	BroadcastChatMessage(ctx context.Context, message *model.Message) error
@@ -27,4 +27,10 @@ type RealtimeNotifier interface {
 	
 	// NotifyRoomUpdate notifies participants about room setting changes
 	NotifyRoomUpdate(ctx context.Context, room *model.Room) error
+
+	// NotifyHostChanged notifies all participants that the host role moved to another user
+	NotifyHostChanged(ctx context.Context, roomID, previousHostID, newHostID uuid.UUID, reason string) error
+
+	// NotifyRoomClosed notifies the remaining participants that the room ended and disconnects them
+	NotifyRoomClosed(ctx context.Context, roomID uuid.UUID, reason string) error
 }
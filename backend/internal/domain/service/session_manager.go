@@ -2,16 +2,22 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrSessionNotFound is returned when a user has no active session
+var ErrSessionNotFound = errors.New("session not found")
+
 // SessionManager defines the interface for managing user sessions
 type SessionManager interface {
 	// CreateSession creates a new user session
 	CreateSession(ctx context.Context, userID uuid.UUID, connectionID string) error
 	
 	// GetSession retrieves a user session
+	// Returns an error wrapping ErrSessionNotFound if the user has no session
 	GetSession(ctx context.Context, userID uuid.UUID) (*UserSession, error)
 	
 	// UpdateSession updates a user session
@@ -22,6 +28,14 @@ type SessionManager interface {
 	
 	// GetActiveUsers returns all active users in a room
 	GetActiveUsers(ctx context.Context, roomID uuid.UUID) ([]uuid.UUID, error)
+
+	// TouchSession refreshes LastSeen while the user's connection is alive
+	// Returns an error wrapping ErrSessionNotFound if the user has no session
+	TouchSession(ctx context.Context, userID uuid.UUID) error
+
+	// ReapStaleSessions deletes the sessions last seen before the given time and returns them
+	// Sessions left behind by crashed pods or dropped connections are removed this way
+	ReapStaleSessions(ctx context.Context, before time.Time) ([]*UserSession, error)
 }
 
 // UserSession represents an active user session
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarHandler exposes usecase.Calendar over HTTP as .ics downloads
+type CalendarHandler struct {
+	calendar *usecase.Calendar
+}
+
+// NewCalendarHandler creates a new CalendarHandler
+func NewCalendarHandler(calendar *usecase.Calendar) *CalendarHandler {
+	return &CalendarHandler{calendar: calendar}
+}
+
+// Room returns the invitation for a scheduled room
+func (h *CalendarHandler) Room(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	file, err := h.calendar.RoomCalendar(c.Request.Context(), currentUser(c), roomID)
+	writeCalendar(c, file, err, "room.ics")
+}
+
+// RoomCancellation returns the cancellation of a scheduled room
+func (h *CalendarHandler) RoomCancellation(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	file, err := h.calendar.RoomCancellation(c.Request.Context(), currentUser(c), roomID)
+	writeCalendar(c, file, err, "room-cancellation.ics")
+}
+
+// Series returns the recurring invitation for a series
+func (h *CalendarHandler) Series(c *gin.Context) {
+	seriesID, ok := uuidParam(c, "seriesId")
+	if !ok {
+		return
+	}
+
+	file, err := h.calendar.SeriesCalendar(c.Request.Context(), currentUser(c), seriesID)
+	writeCalendar(c, file, err, "series.ics")
+}
+
+// OccurrenceCancellation returns the cancellation of the occurrence given by the startsAt query parameter
+func (h *CalendarHandler) OccurrenceCancellation(c *gin.Context) {
+	seriesID, ok := uuidParam(c, "seriesId")
+	if !ok {
+		return
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, c.Query("startsAt"))
+	if err != nil {
+		writeBadRequest(c, "invalid startsAt")
+		return
+	}
+
+	file, err := h.calendar.OccurrenceCancellation(c.Request.Context(), currentUser(c), seriesID, startsAt)
+	writeCalendar(c, file, err, "occurrence-cancellation.ics")
+}
+
+// writeCalendar writes the calendar as a download, or the JSON error
+func writeCalendar(c *gin.Context, file *usecase.CalendarFile, err error, filename string) {
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/infrastructure/ical"
+	"github.com/google/uuid"
+)
+
+// parseCalendar checks a .ics download and parses it
+func parseCalendar(t *testing.T, status int, contentType string, body []byte) *model.Calendar {
+	t.Helper()
+
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", status, body)
+	}
+	if !strings.HasPrefix(contentType, "text/calendar") {
+		t.Errorf("Expected text/calendar, got %s", contentType)
+	}
+	calendar, err := ical.Parse(body)
+	if err != nil {
+		t.Fatalf("Expected a valid calendar, got %v", err)
+	}
+	if len(calendar.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(calendar.Events))
+	}
+	return calendar
+}
+
+func TestCalendarHandler_Room(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	startsAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{
+		"name":     "Planning",
+		"schedule": map[string]interface{}{"startsAt": startsAt, "endsAt": startsAt.Add(30 * time.Minute)},
+	}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	api.do(t, http.MethodPost, roomPath+"/invites", host.ID, map[string]interface{}{"email": "alice@example.com"}, nil)
+	api.do(t, http.MethodPost, roomPath+"/invites", host.ID, map[string]interface{}{}, nil)
+
+	rec := api.do(t, http.MethodGet, roomPath+"/calendar.ics", host.ID, nil, nil)
+	calendar := parseCalendar(t, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes())
+	event := calendar.Events[0]
+	if calendar.Method != model.CalendarMethodRequest || event.Sequence != 0 || !event.Start.Equal(startsAt) {
+		t.Errorf("Expected REQUEST at sequence 0 starting %v, got %s %d %v", startsAt, calendar.Method, event.Sequence, event.Start)
+	}
+	if event.Organizer.Email != host.Email {
+		t.Errorf("Expected organizer %s, got %+v", host.Email, event.Organizer)
+	}
+
+	// 参加リンクはルームIDではなく、開いている招待のトークン
+	joinURL, err := url.Parse(event.URL)
+	if err != nil || !strings.HasPrefix(event.URL, testPublicURL+"/invite?") || strings.Contains(event.URL, room.ID.String()) {
+		t.Fatalf("Expected an invite link, got %s (%v)", event.URL, err)
+	}
+	claims, err := api.signer.Verify(joinURL.Query().Get("token"))
+	if err != nil {
+		t.Fatalf("Expected a valid invite token, got %v", err)
+	}
+	var list InvitesResponse
+	api.do(t, http.MethodGet, roomPath+"/invites", host.ID, nil, &list)
+	if len(list.Invites) != 2 || list.Invites[1].ID != claims.InviteID {
+		t.Errorf("Expected the open invite %s to be reused, got %+v", claims.InviteID, list.Invites)
+	}
+	if len(event.Attendees) != 1 || event.Attendees[0].Email != "alice@example.com" {
+		t.Errorf("Expected the email invitee only, got %+v", event.Attendees)
+	}
+
+	// 予定を変更すると同じUIDでSEQUENCEが増える
+	api.do(t, http.MethodPut, roomPath+"/schedule", host.ID, map[string]interface{}{
+		"startsAt": startsAt.Add(time.Hour), "endsAt": startsAt.Add(2 * time.Hour),
+	}, nil)
+	rec = api.do(t, http.MethodGet, roomPath+"/calendar.ics", host.ID, nil, nil)
+	updated := parseCalendar(t, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes()).Events[0]
+	if updated.UID != event.UID || updated.Sequence != 1 {
+		t.Errorf("Expected UID %s at sequence 1, got %s at %d", event.UID, updated.UID, updated.Sequence)
+	}
+	if updated.URL != event.URL {
+		t.Errorf("Expected the join URL to be kept, got %s", updated.URL)
+	}
+
+	// 取り消された招待は使わず、新しい招待を発行する
+	api.do(t, http.MethodDelete, roomPath+"/invites/"+claims.InviteID.String(), host.ID, nil, nil)
+	rec = api.do(t, http.MethodGet, roomPath+"/calendar.ics", host.ID, nil, nil)
+	reissued := parseCalendar(t, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes()).Events[0]
+	if reissued.URL == event.URL || !strings.HasPrefix(reissued.URL, testPublicURL+"/invite?") {
+		t.Errorf("Expected a new invite link, got %s", reissued.URL)
+	}
+
+	rec = api.do(t, http.MethodGet, roomPath+"/calendar/cancellation.ics", host.ID, nil, nil)
+	cancellation := parseCalendar(t, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes())
+	if cancellation.Method != model.CalendarMethodCancel || cancellation.Events[0].Status != model.EventStatusCancelled ||
+		cancellation.Events[0].Sequence != 2 {
+		t.Errorf("Expected CANCEL at sequence 2, got %+v", cancellation.Events[0])
+	}
+
+	rec = api.do(t, http.MethodGet, roomPath+"/calendar.ics", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	var adHoc model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Ad hoc"}, &adHoc)
+	rec = api.do(t, http.MethodGet, "/api/v1/rooms/"+adHoc.ID.String()+"/calendar.ics", host.ID, nil, nil)
+	expectError(t, rec, http.StatusBadRequest, "invalid_schedule")
+}
+
+func TestCalendarHandler_Series(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	coHost := api.createUser(t, "Co-host")
+	guest := api.createUser(t, "Guest")
+
+	var series model.Series
+	api.do(t, http.MethodPost, "/api/v1/series", host.ID, map[string]interface{}{
+		"name": "Standup", "coHostIds": []uuid.UUID{coHost.ID}, "startsAt": time.Now().Add(24 * time.Hour),
+		"durationMinutes": 15, "rrule": "FREQ=DAILY", "timeZone": "Asia/Tokyo",
+	}, &series)
+	seriesPath := "/api/v1/series/" + series.ID.String()
+
+	var list OccurrencesResponse
+	api.do(t, http.MethodGet, seriesPath+"/occurrences?count=2", uuid.Nil, nil, &list)
+	first := list.Occurrences[0].StartsAt
+	cancellationPath := seriesPath + "/occurrences/cancellation.ics?startsAt=" + url.QueryEscape(first.Format(time.RFC3339))
+
+	// 中止していない回の取消は返さない
+	rec := api.do(t, http.MethodGet, cancellationPath, host.ID, nil, nil)
+	expectError(t, rec, http.StatusNotFound, "occurrence_not_found")
+
+	api.do(t, http.MethodPost, seriesPath+"/occurrences/cancel", host.ID, map[string]interface{}{"startsAt": first}, nil)
+
+	rec = api.do(t, http.MethodGet, seriesPath+"/calendar.ics", coHost.ID, nil, nil)
+	event := parseCalendar(t, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes()).Events[0]
+	if event.RRule != "FREQ=DAILY" || event.TimeZone != "Asia/Tokyo" || event.Sequence != 1 {
+		t.Errorf("Expected daily Asia/Tokyo event at sequence 1, got %q %q %d", event.RRule, event.TimeZone, event.Sequence)
+	}
+	if len(event.ExceptionDates) != 1 || !event.ExceptionDates[0].Equal(first) {
+		t.Errorf("Expected exception date %v, got %v", first, event.ExceptionDates)
+	}
+	if len(event.Attendees) != 1 || event.Attendees[0].Role != model.AttendeeRoleChair {
+		t.Errorf("Expected the co-host as chair, got %+v", event.Attendees)
+	}
+
+	rec = api.do(t, http.MethodGet, cancellationPath, host.ID, nil, nil)
+	cancellation := parseCalendar(t, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes())
+	cancelled := cancellation.Events[0]
+	if cancellation.Method != model.CalendarMethodCancel || cancelled.UID != event.UID || cancelled.RRule != "" {
+		t.Errorf("Expected CANCEL of a single occurrence of %s, got %+v", event.UID, cancelled)
+	}
+	if cancelled.RecurrenceID == nil || !cancelled.RecurrenceID.Equal(first) {
+		t.Errorf("Expected RecurrenceID %v, got %v", first, cancelled.RecurrenceID)
+	}
+
+	rec = api.do(t, http.MethodGet, seriesPath+"/calendar.ics", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	rec = api.do(t, http.MethodGet, seriesPath+"/occurrences/cancellation.ics?startsAt=tomorrow", host.ID, nil, nil)
+	expectError(t, rec, http.StatusBadRequest, "invalid_input")
+}
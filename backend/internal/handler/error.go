@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the JSON body returned for every failed request
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes an API error
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// errorMapping maps a usecase error to an HTTP status and error code
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+}
+
+var errorMappings = []errorMapping{
+	{usecase.ErrInvalidInput, http.StatusBadRequest, "invalid_input"},
+	{usecase.ErrInvalidRole, http.StatusBadRequest, "invalid_role"},
+	{usecase.ErrInvalidInvite, http.StatusBadRequest, "invalid_invite"},
+	{usecase.ErrInvalidSchedule, http.StatusBadRequest, "invalid_schedule"},
+	{usecase.ErrInvalidRecurrence, http.StatusBadRequest, "invalid_recurrence"},
+	{usecase.ErrInvalidRoomOptions, http.StatusBadRequest, "invalid_room_options"},
+	{usecase.ErrPermissionDenied, http.StatusForbidden, "permission_denied"},
+	{usecase.ErrUserBanned, http.StatusForbidden, "user_banned"},
+	{usecase.ErrInvalidPasscode, http.StatusForbidden, "invalid_passcode"},
+	{usecase.ErrNotParticipant, http.StatusForbidden, "not_participant"},
+	{usecase.ErrInviteEmailMismatch, http.StatusForbidden, "invite_email_mismatch"},
+	{usecase.ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{usecase.ErrRoomNotFound, http.StatusNotFound, "room_not_found"},
+	{usecase.ErrParticipantNotFound, http.StatusNotFound, "participant_not_found"},
+	{usecase.ErrNotWaiting, http.StatusNotFound, "not_waiting"},
+	{usecase.ErrInviteNotFound, http.StatusNotFound, "invite_not_found"},
+	{usecase.ErrSeriesNotFound, http.StatusNotFound, "series_not_found"},
+	{usecase.ErrOccurrenceNotFound, http.StatusNotFound, "occurrence_not_found"},
+	{usecase.ErrAlreadyInRoom, http.StatusConflict, "already_in_room"},
+	{usecase.ErrRoomFull, http.StatusConflict, "room_full"},
+	{usecase.ErrRoomNotStarted, http.StatusConflict, "room_not_started"},
+	{usecase.ErrExtensionLimit, http.StatusConflict, "extension_limit_reached"},
+	{usecase.ErrConcurrentUpdate, http.StatusConflict, "concurrent_update"},
+	{usecase.ErrRoomExpired, http.StatusGone, "room_expired"},
+	{usecase.ErrInviteExpired, http.StatusGone, "invite_expired"},
+	{usecase.ErrInviteRevoked, http.StatusGone, "invite_revoked"},
+	{usecase.ErrInviteExhausted, http.StatusGone, "invite_exhausted"},
+	{usecase.ErrRoomLocked, http.StatusLocked, "room_locked"},
+	{usecase.ErrTooManyAttempts, http.StatusTooManyRequests, "too_many_attempts"},
+}
+
+// writeError writes the JSON error body matching err
+// Unknown errors are reported as 500 without leaking their message; logErrors logs them
+func writeError(c *gin.Context, err error) {
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			abort(c, m.status, m.code, err.Error())
+			return
+		}
+	}
+
+	c.Error(err)
+	abort(c, http.StatusInternalServerError, "internal_error", "internal server error")
+}
+
+// logErrors logs the unknown errors of a request, which were reported as 500, with the request ID
+func logErrors(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		ctx := c.Request.Context()
+		for _, err := range c.Errors {
+			logger.ErrorContext(ctx, "request failed",
+				"method", c.Request.Method,
+				"path", c.FullPath(),
+				"status", c.Writer.Status(),
+				"error", err.Err,
+				"request_id", usecase.RequestID(ctx),
+			)
+		}
+	}
+}
+
+// writeBadRequest writes a 400 error for malformed requests
+func writeBadRequest(c *gin.Context, message string) {
+	abort(c, http.StatusBadRequest, "invalid_input", message)
+}
+
+func abort(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, ErrorResponse{Error: ErrorBody{Code: code, Message: message}})
+}
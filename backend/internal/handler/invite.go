@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// InviteHandler exposes usecase.Invite over HTTP
+type InviteHandler struct {
+	invite *usecase.Invite
+}
+
+// NewInviteHandler creates a new InviteHandler
+func NewInviteHandler(invite *usecase.Invite) *InviteHandler {
+	return &InviteHandler{invite: invite}
+}
+
+type createInviteRequest struct {
+	Email          string     `json:"email"`
+	Role           model.Role `json:"role"`
+	ExpiresInHours int        `json:"expiresInHours" binding:"min=0"`
+	MaxUses        int        `json:"maxUses" binding:"min=0"`
+}
+
+type redeemInviteRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// InviteResponse is the body returned when creating an invite
+// The token is only returned here; it is not stored
+type InviteResponse struct {
+	Invite *model.Invite `json:"invite"`
+	Token  string        `json:"token"`
+}
+
+// InvitesResponse is the body returned when listing invites
+type InvitesResponse struct {
+	Invites []*model.Invite `json:"invites"`
+}
+
+// Create issues an invite to the room (host and co-hosts)
+func (h *InviteHandler) Create(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	// 既定値で招待する場合は空ボディでよい
+	var req createInviteRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeBadRequest(c, err.Error())
+			return
+		}
+	}
+
+	ttl := time.Duration(req.ExpiresInHours) * time.Hour
+	invite, token, err := h.invite.CreateInvite(c.Request.Context(), currentUser(c), roomID, req.Email, req.Role, ttl, req.MaxUses)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, InviteResponse{Invite: invite, Token: token})
+}
+
+// List returns the room's invites (host and co-hosts)
+func (h *InviteHandler) List(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	invites, err := h.invite.ListInvites(c.Request.Context(), currentUser(c), roomID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, InvitesResponse{Invites: invites})
+}
+
+// Revoke stops an invite from being redeemed (host and co-hosts)
+func (h *InviteHandler) Revoke(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+	inviteID, ok := uuidParam(c, "inviteId")
+	if !ok {
+		return
+	}
+
+	if err := h.invite.RevokeInvite(c.Request.Context(), currentUser(c), roomID, inviteID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Redeem joins the acting user to the room the invite token points to
+func (h *InviteHandler) Redeem(c *gin.Context) {
+	var req redeemInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	room, err := h.invite.RedeemInvite(c.Request.Context(), currentUser(c), req.Token)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
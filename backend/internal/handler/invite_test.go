@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+func TestInviteHandler_CreateAndRedeem(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	alice := api.createUser(t, "Alice")
+	bob := api.createUser(t, "Bob")
+
+	// 待機室とパスコードのあるルームでも招待なら直接参加できる
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room", "isWaitingRoom": true}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+	api.do(t, http.MethodPut, roomPath+"/passcode", host.ID, map[string]interface{}{"passcode": "1234"}, nil)
+
+	var created InviteResponse
+	rec := api.do(t, http.MethodPost, roomPath+"/invites", host.ID, map[string]interface{}{
+		"email": alice.Email, "role": "presenter", "expiresInHours": 24, "maxUses": 1,
+	}, &created)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if created.Token == "" || created.Invite.Role != model.RolePresenter {
+		t.Fatalf("Expected token and presenter invite, got %+v", created)
+	}
+
+	rec = api.do(t, http.MethodPost, "/api/v1/invites/redeem", bob.ID, map[string]interface{}{"token": created.Token}, nil)
+	expectError(t, rec, http.StatusForbidden, "invite_email_mismatch")
+
+	var joined model.Room
+	rec = api.do(t, http.MethodPost, "/api/v1/invites/redeem", alice.ID, map[string]interface{}{"token": created.Token}, &joined)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if joined.RoleOf(alice.ID) != model.RolePresenter || !joined.IsParticipant(alice.ID) {
+		t.Errorf("Expected Alice to join as presenter, got %+v", joined.Participants)
+	}
+
+	// 利用回数の上限
+	api.do(t, http.MethodPost, roomPath+"/leave", alice.ID, nil, nil)
+	rec = api.do(t, http.MethodPost, "/api/v1/invites/redeem", alice.ID, map[string]interface{}{"token": created.Token}, nil)
+	expectError(t, rec, http.StatusGone, "invite_exhausted")
+
+	var list InvitesResponse
+	api.do(t, http.MethodGet, roomPath+"/invites", host.ID, nil, &list)
+	if len(list.Invites) != 1 || list.Invites[0].Uses != 1 {
+		t.Errorf("Expected 1 invite used once, got %+v", list.Invites)
+	}
+}
+
+func TestInviteHandler_Revoke(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	var created InviteResponse
+	api.do(t, http.MethodPost, roomPath+"/invites", host.ID, nil, &created)
+	if created.Invite == nil || created.Invite.Role != model.RoleAttendee {
+		t.Fatalf("Expected default attendee invite, got %+v", created)
+	}
+
+	// 参加者以外は招待を管理できない
+	rec := api.do(t, http.MethodDelete, roomPath+"/invites/"+created.Invite.ID.String(), guest.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	rec = api.do(t, http.MethodDelete, roomPath+"/invites/"+created.Invite.ID.String(), host.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = api.do(t, http.MethodPost, "/api/v1/invites/redeem", guest.ID, map[string]interface{}{"token": created.Token}, nil)
+	expectError(t, rec, http.StatusGone, "invite_revoked")
+
+	rec = api.do(t, http.MethodDelete, roomPath+"/invites/"+uuid.NewString(), host.ID, nil, nil)
+	expectError(t, rec, http.StatusNotFound, "invite_not_found")
+}
+
+func TestInviteHandler_Errors(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	var created InviteResponse
+	api.do(t, http.MethodPost, roomPath+"/invites", host.ID, nil, &created)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+		status int
+		code   string
+	}{
+		{"host role", http.MethodPost, roomPath + "/invites", map[string]interface{}{"role": "host"}, http.StatusBadRequest, "invalid_role"},
+		{"bad email", http.MethodPost, roomPath + "/invites", map[string]interface{}{"email": "not-an-email"}, http.StatusBadRequest, "invalid_input"},
+		{"too long", http.MethodPost, roomPath + "/invites", map[string]interface{}{"expiresInHours": 24 * 365}, http.StatusBadRequest, "invalid_input"},
+		{"tampered token", http.MethodPost, "/api/v1/invites/redeem", map[string]interface{}{"token": created.Token + "x"}, http.StatusBadRequest, "invalid_invite"},
+		{"missing token", http.MethodPost, "/api/v1/invites/redeem", map[string]interface{}{}, http.StatusBadRequest, "invalid_input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor := host.ID
+			if tt.path == "/api/v1/invites/redeem" {
+				actor = guest.ID
+			}
+			rec := api.do(t, tt.method, tt.path, actor, tt.body, nil)
+			expectError(t, rec, tt.status, tt.code)
+		})
+	}
+
+	// ロック中のルームには招待でも参加できない
+	api.do(t, http.MethodPost, roomPath+"/lock", host.ID, nil, nil)
+	rec := api.do(t, http.MethodPost, "/api/v1/invites/redeem", guest.ID, map[string]interface{}{"token": created.Token}, nil)
+	expectError(t, rec, http.StatusLocked, "room_locked")
+
+	// 参加できなかった利用は数えない
+	var list InvitesResponse
+	api.do(t, http.MethodGet, roomPath+"/invites", host.ID, nil, &list)
+	if len(list.Invites) != 1 || list.Invites[0].Uses != 0 {
+		t.Errorf("Expected the invite to stay unused, got %+v", list.Invites)
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// MessageHandler exposes usecase.Message over HTTP
+type MessageHandler struct {
+	message *usecase.Message
+}
+
+// NewMessageHandler creates a new MessageHandler
+func NewMessageHandler(message *usecase.Message) *MessageHandler {
+	return &MessageHandler{message: message}
+}
+
+type sendMessageRequest struct {
+	Message string `json:"message" binding:"required"`
+}
+
+// MessagesResponse is the body returned for chat history
+type MessagesResponse struct {
+	Messages []*model.Message `json:"messages"`
+}
+
+// History returns the room's chat history in chronological order
+func (h *MessageHandler) History(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			writeBadRequest(c, "invalid limit")
+			return
+		}
+		limit = parsed
+	}
+
+	messages, err := h.message.GetHistory(c.Request.Context(), currentUser(c), roomID, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MessagesResponse{Messages: messages})
+}
+
+// Send posts a chat message to the room
+func (h *MessageHandler) Send(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	var req sendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	message, err := h.message.SendMessage(c.Request.Context(), currentUser(c), roomID, req.Message)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// DeleteHistory deletes the room's chat history (host and co-hosts)
+func (h *MessageHandler) DeleteHistory(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	if err := h.message.DeleteHistory(c.Request.Context(), currentUser(c), roomID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+)
+
+func TestMessageHandler_SendAndHistory(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	outsider := api.createUser(t, "Outsider")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	path := "/api/v1/rooms/" + room.ID.String() + "/messages"
+
+	for _, text := range []string{"first", "second", "third"} {
+		rec := api.do(t, http.MethodPost, path, host.ID, map[string]interface{}{"message": text}, nil)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	var resp MessagesResponse
+	rec := api.do(t, http.MethodGet, path+"?limit=2", host.ID, nil, &resp)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if len(resp.Messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(resp.Messages))
+	}
+	if resp.Messages[1].Payload.(map[string]interface{})["message"] != "third" {
+		t.Errorf("Expected latest message last, got %v", resp.Messages[1].Payload)
+	}
+
+	rec = api.do(t, http.MethodGet, path+"?limit=abc", host.ID, nil, nil)
+	expectError(t, rec, http.StatusBadRequest, "invalid_input")
+
+	rec = api.do(t, http.MethodPost, path, outsider.ID, map[string]interface{}{"message": "hi"}, nil)
+	expectError(t, rec, http.StatusForbidden, "not_participant")
+
+	rec = api.do(t, http.MethodGet, path, outsider.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "not_participant")
+}
+
+func TestMessageHandler_DeleteHistory(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	path := "/api/v1/rooms/" + room.ID.String() + "/messages"
+	api.do(t, http.MethodPost, path, host.ID, map[string]interface{}{"message": "hello"}, nil)
+
+	rec := api.do(t, http.MethodDelete, path, guest.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	rec = api.do(t, http.MethodDelete, path, host.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", rec.Code)
+	}
+
+	var resp MessagesResponse
+	api.do(t, http.MethodGet, path, host.ID, nil, &resp)
+	if len(resp.Messages) != 0 {
+		t.Errorf("Expected empty history, got %d messages", len(resp.Messages))
+	}
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// openAPISpec documents every route registered in NewRouter
+// router_test.go checks the two stay in sync
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+func serveOpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", openAPISpec)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UserIDHeader identifies the acting user until authentication is in place
+const UserIDHeader = "X-User-ID"
+
+const currentUserKey = "currentUserID"
+
+// RequestIDHeader carries the ID that correlates the logs of a request
+// A missing ID is generated, and the ID is echoed in the response
+const RequestIDHeader = "X-Request-ID"
+
+// requestID puts the request ID into the request context for the usecases to log
+func requestID(c *gin.Context) {
+	id := c.GetHeader(RequestIDHeader)
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	c.Header(RequestIDHeader, id)
+	c.Request = c.Request.WithContext(usecase.WithRequestID(c.Request.Context(), id))
+	c.Next()
+}
+
+// requireUser rejects requests without a valid X-User-ID header
+func requireUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetHeader(UserIDHeader))
+	if err != nil {
+		abort(c, http.StatusUnauthorized, "unauthorized", "missing or invalid "+UserIDHeader+" header")
+		return
+	}
+
+	c.Set(currentUserKey, userID)
+	c.Next()
+}
+
+// currentUser returns the acting user set by requireUser
+func currentUser(c *gin.Context) uuid.UUID {
+	return c.MustGet(currentUserKey).(uuid.UUID)
+}
+
+// uuidParam parses a UUID path parameter, writing a 400 error when invalid
+func uuidParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		writeBadRequest(c, "invalid "+name)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,558 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RoomHandler exposes usecase.Room over HTTP
+type RoomHandler struct {
+	room *usecase.Room
+}
+
+// NewRoomHandler creates a new RoomHandler
+func NewRoomHandler(room *usecase.Room) *RoomHandler {
+	return &RoomHandler{room: room}
+}
+
+// createRoomRequest creates a room; omitted options take the room policy's defaults
+type createRoomRequest struct {
+	Name              string           `json:"name" binding:"required"`
+	IsWaitingRoom     *bool            `json:"isWaitingRoom"`
+	MaxCapacity       *int             `json:"maxCapacity"`
+	LifetimeHours     *int             `json:"lifetimeHours"`
+	MaxExtensionHours *int             `json:"maxExtensionHours"`
+	AutoDelete        *bool            `json:"autoDelete"`
+	Schedule          *scheduleRequest `json:"schedule"`
+}
+
+// options returns the room options of the request
+func (r *createRoomRequest) options() usecase.RoomOptionsInput {
+	return usecase.RoomOptionsInput{
+		MaxCapacity:   r.MaxCapacity,
+		Lifetime:      hours(r.LifetimeHours),
+		MaxExtension:  hours(r.MaxExtensionHours),
+		IsWaitingRoom: r.IsWaitingRoom,
+		AutoDelete:    r.AutoDelete,
+	}
+}
+
+// hours converts an optional number of hours to a duration
+func hours(n *int) *time.Duration {
+	if n == nil {
+		return nil
+	}
+	d := time.Duration(*n) * time.Hour
+	return &d
+}
+
+// scheduleRequest plans a meeting; earlyJoinMinutes defaults to model.DefaultEarlyJoinWindow
+type scheduleRequest struct {
+	StartsAt         time.Time `json:"startsAt" binding:"required"`
+	EndsAt           time.Time `json:"endsAt" binding:"required"`
+	EarlyJoinMinutes *int      `json:"earlyJoinMinutes"`
+}
+
+// earlyJoin returns the early join window of the request
+func (r *scheduleRequest) earlyJoin() time.Duration {
+	if r.EarlyJoinMinutes == nil {
+		return model.DefaultEarlyJoinWindow
+	}
+	return time.Duration(*r.EarlyJoinMinutes) * time.Minute
+}
+
+type updateRoomRequest struct {
+	Name          string `json:"name"`
+	IsWaitingRoom *bool  `json:"isWaitingRoom" binding:"required"`
+}
+
+type extendRoomRequest struct {
+	Hours int `json:"hours" binding:"required,min=1"`
+}
+
+type transferHostRequest struct {
+	UserID uuid.UUID `json:"userId" binding:"required"`
+}
+
+type setRoleRequest struct {
+	Role model.Role `json:"role" binding:"required"`
+}
+
+type joinRoomRequest struct {
+	Passcode string `json:"passcode"`
+}
+
+type setPasscodeRequest struct {
+	Passcode string `json:"passcode" binding:"required"`
+}
+
+type kickParticipantRequest struct {
+	Ban    bool   `json:"ban"`
+	Reason string `json:"reason"`
+}
+
+type denyUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RoomsResponse is the body returned when listing rooms
+type RoomsResponse struct {
+	Rooms []*model.Room `json:"rooms"`
+}
+
+// JoinResponse is the body returned when joining a room
+type JoinResponse struct {
+	Status usecase.JoinStatus `json:"status"`
+}
+
+// WaitingUsersResponse is the body returned when listing the waiting room
+type WaitingUsersResponse struct {
+	UserIDs []uuid.UUID `json:"userIds"`
+}
+
+// ConnectionTokenResponse is the body returned when issuing a WebSocket connection token
+type ConnectionTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Create creates a room hosted by the acting user
+func (h *RoomHandler) Create(c *gin.Context) {
+	var req createRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	var room *model.Room
+	var err error
+	if req.Schedule != nil {
+		room, err = h.room.ScheduleRoom(c.Request.Context(), currentUser(c), req.Name, req.options(),
+			req.Schedule.StartsAt, req.Schedule.EndsAt, req.Schedule.earlyJoin())
+	} else {
+		room, err = h.room.CreateRoom(c.Request.Context(), currentUser(c), req.Name, req.options())
+	}
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, room)
+}
+
+// Reschedule moves a scheduled room to a new time (host and co-hosts)
+func (h *RoomHandler) Reschedule(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	var req scheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	room, err := h.room.RescheduleRoom(c.Request.Context(), currentUser(c), roomID, req.StartsAt, req.EndsAt, req.earlyJoin())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// Get returns a room
+func (h *RoomHandler) Get(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	room, err := h.room.GetRoom(c.Request.Context(), roomID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// Update updates room settings
+func (h *RoomHandler) Update(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	var req updateRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	room, err := h.room.UpdateRoom(c.Request.Context(), currentUser(c), roomID, req.Name, *req.IsWaitingRoom)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// Delete deletes a room
+func (h *RoomHandler) Delete(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	if err := h.room.DeleteRoom(c.Request.Context(), currentUser(c), roomID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Join adds the acting user to a room, checking the passcode if the room has one
+// Returns 202 when the user has to wait for the host to admit them
+func (h *RoomHandler) Join(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	// パスコードのないルームは空ボディで参加できる
+	var req joinRoomRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeBadRequest(c, err.Error())
+			return
+		}
+	}
+
+	status, err := h.room.JoinRoom(c.Request.Context(), currentUser(c), roomID, req.Passcode)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if status == usecase.JoinStatusWaiting {
+		c.JSON(http.StatusAccepted, JoinResponse{Status: status})
+		return
+	}
+	c.JSON(http.StatusOK, JoinResponse{Status: status})
+}
+
+// Waiting lists the users in the waiting room (host and co-hosts)
+func (h *RoomHandler) Waiting(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	userIDs, err := h.room.GetWaitingUsers(c.Request.Context(), currentUser(c), roomID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, WaitingUsersResponse{UserIDs: userIDs})
+}
+
+// ConnectionToken issues a token for the acting user to connect to the room's WebSocket
+func (h *RoomHandler) ConnectionToken(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	token, expiresAt, err := h.room.ConnectionToken(c.Request.Context(), currentUser(c), roomID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ConnectionTokenResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+// Admit admits a waiting user (host and co-hosts)
+func (h *RoomHandler) Admit(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+	userID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.room.AdmitUser(c.Request.Context(), currentUser(c), roomID, userID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Deny turns away a waiting user with an optional reason (host and co-hosts)
+func (h *RoomHandler) Deny(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+	userID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	// 理由は任意なので空ボディも受け付ける
+	var req denyUserRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeBadRequest(c, err.Error())
+			return
+		}
+	}
+
+	if err := h.room.DenyUser(c.Request.Context(), currentUser(c), roomID, userID, req.Reason); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Lock stops new users from joining (host and co-hosts)
+func (h *RoomHandler) Lock(c *gin.Context) {
+	h.setLocked(c, true)
+}
+
+// Unlock lets users join again (host and co-hosts)
+func (h *RoomHandler) Unlock(c *gin.Context) {
+	h.setLocked(c, false)
+}
+
+func (h *RoomHandler) setLocked(c *gin.Context, locked bool) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	room, err := h.room.LockRoom(c.Request.Context(), currentUser(c), roomID, locked)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// SetPasscode sets the passcode required to join (host and co-hosts)
+func (h *RoomHandler) SetPasscode(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	var req setPasscodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.room.SetPasscode(c.Request.Context(), currentUser(c), roomID, req.Passcode); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemovePasscode lets users join without a passcode (host and co-hosts)
+func (h *RoomHandler) RemovePasscode(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	if err := h.room.SetPasscode(c.Request.Context(), currentUser(c), roomID, ""); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Leave removes the acting user from a room
+func (h *RoomHandler) Leave(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	if err := h.room.LeaveRoom(c.Request.Context(), currentUser(c), roomID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Extend extends the room expiry
+func (h *RoomHandler) Extend(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	var req extendRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.room.ExtendRoomExpiry(c.Request.Context(), currentUser(c), roomID, req.Hours); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	room, err := h.room.GetRoom(c.Request.Context(), roomID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// Mute mutes a participant (host and co-hosts)
+func (h *RoomHandler) Mute(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+	targetID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.room.MuteParticipant(c.Request.Context(), currentUser(c), roomID, targetID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Kick removes a participant and optionally bans them (host and co-hosts)
+func (h *RoomHandler) Kick(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+	targetID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	// ボディは任意なので空ボディも受け付ける
+	var req kickParticipantRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeBadRequest(c, err.Error())
+			return
+		}
+	}
+
+	if err := h.room.KickParticipant(c.Request.Context(), currentUser(c), roomID, targetID, req.Ban, req.Reason); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TransferHost hands the host role to another participant (host only)
+func (h *RoomHandler) TransferHost(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	var req transferHostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.room.TransferHost(c.Request.Context(), currentUser(c), roomID, req.UserID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	room, err := h.room.GetRoom(c.Request.Context(), roomID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// SetRole promotes or demotes a participant (host and co-hosts)
+func (h *RoomHandler) SetRole(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+	targetID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	var req setRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.room.SetParticipantRole(c.Request.Context(), currentUser(c), roomID, targetID, req.Role); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Unmute unmutes the acting user
+func (h *RoomHandler) Unmute(c *gin.Context) {
+	roomID, ok := uuidParam(c, "roomId")
+	if !ok {
+		return
+	}
+	targetID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	// 参加者は自分自身のミュートのみ解除できる
+	if targetID != currentUser(c) {
+		writeError(c, fmt.Errorf("%w: participants can only unmute themselves", usecase.ErrPermissionDenied))
+		return
+	}
+
+	if err := h.room.UnmuteParticipant(c.Request.Context(), targetID, roomID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,912 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func TestRoomHandler_CreateAndGet(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+
+	var room model.Room
+	rec := api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Standup", "isWaitingRoom": true}, &room)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if room.Name != "Standup" || room.HostID != host.ID || !room.IsWaitingRoom {
+		t.Errorf("Unexpected room %+v", room)
+	}
+
+	var got model.Room
+	rec = api.do(t, http.MethodGet, "/api/v1/rooms/"+room.ID.String(), uuid.Nil, nil, &got)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if got.ID != room.ID || len(got.Participants) != 1 {
+		t.Errorf("Unexpected room %+v", got)
+	}
+}
+
+func TestRoomHandler_CreateWithOptions(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+
+	var room model.Room
+	rec := api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{
+		"name": "Office hours", "maxCapacity": 3, "lifetimeHours": 2, "maxExtensionHours": 1, "autoDelete": false,
+	}, &room)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if room.MaxCapacity != 3 || room.AutoDelete || room.MaxExtensionMinutes != 60 || room.IsWaitingRoom {
+		t.Errorf("Expected requested options, got %+v", room)
+	}
+	if lifetime := room.ExpiresAt.Sub(room.CreatedAt); lifetime != 2*time.Hour {
+		t.Errorf("Expected 2 hour lifetime, got %v", lifetime)
+	}
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	// 延長の合計は上限まで
+	rec = api.do(t, http.MethodPost, roomPath+"/extend", host.ID, map[string]interface{}{"hours": 1}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = api.do(t, http.MethodPost, roomPath+"/extend", host.ID, map[string]interface{}{"hours": 1}, nil)
+	expectError(t, rec, http.StatusConflict, "extension_limit_reached")
+
+	// 自動削除しないルームは最後の参加者が退出しても残る
+	rec = api.do(t, http.MethodPost, roomPath+"/leave", host.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	got, err := api.rooms.GetByID(context.Background(), room.ID)
+	if err != nil || got.GetParticipantCount() != 0 {
+		t.Errorf("Expected the empty room to be kept, got %+v (%v)", got, err)
+	}
+
+	for _, body := range []map[string]interface{}{
+		{"name": "Too big", "maxCapacity": model.DefaultMaxCapacity + 1},
+		{"name": "Too long", "lifetimeHours": 25},
+		{"name": "Too extensible", "maxExtensionHours": 48},
+		{"name": "Too long meeting", "schedule": map[string]interface{}{
+			"startsAt": time.Now().Add(time.Hour), "endsAt": time.Now().Add(26 * time.Hour),
+		}},
+	} {
+		rec = api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, body, nil)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d: %s", body["name"], rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestRoomHandler_Errors(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		actor  uuid.UUID
+		body   interface{}
+		status int
+		code   string
+	}{
+		{"invalid room id", http.MethodGet, "/api/v1/rooms/invalid", uuid.Nil, nil, http.StatusBadRequest, "invalid_input"},
+		{"missing name", http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{}, http.StatusBadRequest, "invalid_input"},
+		{"unknown host", http.MethodPost, "/api/v1/rooms", uuid.New(), map[string]interface{}{"name": "Room"}, http.StatusNotFound, "user_not_found"},
+		{"unknown room", http.MethodGet, "/api/v1/rooms/" + uuid.New().String(), uuid.Nil, nil, http.StatusNotFound, "room_not_found"},
+		{"host joins again", http.MethodPost, roomPath + "/join", host.ID, nil, http.StatusConflict, "already_in_room"},
+		{"guest extends", http.MethodPost, roomPath + "/extend", guest.ID, map[string]interface{}{"hours": 1}, http.StatusForbidden, "permission_denied"},
+		{"invalid hours", http.MethodPost, roomPath + "/extend", host.ID, map[string]interface{}{"hours": 0}, http.StatusBadRequest, "invalid_input"},
+		{"guest mutes host", http.MethodPost, roomPath + "/participants/" + host.ID.String() + "/mute", guest.ID, nil, http.StatusForbidden, "permission_denied"},
+		{"mute non-participant", http.MethodPost, roomPath + "/participants/" + guest.ID.String() + "/mute", host.ID, nil, http.StatusNotFound, "participant_not_found"},
+		{"guest leaves without joining", http.MethodPost, roomPath + "/leave", guest.ID, nil, http.StatusForbidden, "not_participant"},
+		{"unmute someone else", http.MethodPost, roomPath + "/participants/" + host.ID.String() + "/unmute", guest.ID, nil, http.StatusForbidden, "permission_denied"},
+		{"guest deletes room", http.MethodDelete, roomPath, guest.ID, nil, http.StatusForbidden, "permission_denied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := api.do(t, tt.method, tt.path, tt.actor, tt.body, nil)
+			expectError(t, rec, tt.status, tt.code)
+		})
+	}
+}
+
+func TestRoomHandler_JoinFullRoom(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+
+	for i := 1; i < room.MaxCapacity; i++ {
+		guest := api.createUser(t, "Guest")
+		rec := api.do(t, http.MethodPost, "/api/v1/rooms/"+room.ID.String()+"/join", guest.ID, nil, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	late := api.createUser(t, "Late")
+	rec := api.do(t, http.MethodPost, "/api/v1/rooms/"+room.ID.String()+"/join", late.ID, nil, nil)
+	expectError(t, rec, http.StatusConflict, "room_full")
+}
+
+func TestRoomHandler_ConcurrentJoins(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	joinPath := "/api/v1/rooms/" + room.ID.String() + "/join"
+
+	guests := make([]*model.User, 100)
+	for i := range guests {
+		guests[i] = api.createUser(t, "Guest")
+	}
+
+	// 全員同時に参加して、更新が失われないことを確認する
+	recs := make([]*httptest.ResponseRecorder, len(guests))
+	var wg sync.WaitGroup
+	for i, guest := range guests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recs[i] = api.do(t, http.MethodPost, joinPath, guest.ID, nil, nil)
+		}()
+	}
+	wg.Wait()
+
+	joined := 0
+	for _, rec := range recs {
+		if rec.Code == http.StatusOK {
+			joined++
+			continue
+		}
+		var resp ErrorResponse
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		if code := resp.Error.Code; code != "room_full" && code != "concurrent_update" {
+			t.Errorf("Expected room_full or concurrent_update, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+	if want := room.MaxCapacity - 1; joined != want {
+		t.Errorf("Expected %d joins, got %d", want, joined)
+	}
+
+	stored, err := api.rooms.GetByID(context.Background(), room.ID)
+	if err != nil {
+		t.Fatalf("Expected room, got %v", err)
+	}
+	if len(stored.Participants) != room.MaxCapacity {
+		t.Errorf("Expected %d participants, got %d", room.MaxCapacity, len(stored.Participants))
+	}
+}
+
+func TestRoomHandler_JoinNotificationRetried(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+	ctx := context.Background()
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+
+	// 通知に失敗しても参加は成功する
+	api.notifier.fail = errors.New("redis unavailable")
+	rec := api.do(t, http.MethodPost, "/api/v1/rooms/"+room.ID.String()+"/join", guest.ID, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// makeDue skips the grace period left to the joining request
+	makeDue := func() {
+		t.Helper()
+		events, _ := api.outbox.GetDue(ctx, time.Now().Add(time.Hour), 10)
+		if len(events) != 1 || events[0].Type != model.EventTypeParticipantJoined {
+			t.Fatalf("Expected the joined event in the outbox, got %+v", events)
+		}
+		events[0].NextAttemptAt = time.Now()
+		api.outbox.Reschedule(ctx, events[0])
+	}
+
+	// 失敗した配信は間隔をあけて再試行する
+	makeDue()
+	if err := api.events.DispatchEvents(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if due, _ := api.outbox.GetDue(ctx, time.Now(), 10); len(due) != 0 {
+		t.Errorf("Expected the event to be rescheduled, got %+v", due)
+	}
+
+	api.notifier.fail = nil
+	makeDue()
+	if err := api.events.DispatchEvents(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(api.notifier.joined) != 1 || api.notifier.joined[0] != guest.ID {
+		t.Errorf("Expected the join to be delivered, got %v", api.notifier.joined)
+	}
+	if events, _ := api.outbox.GetDue(ctx, time.Now().Add(time.Hour), 10); len(events) != 0 {
+		t.Errorf("Expected the delivered event to be removed, got %+v", events)
+	}
+}
+
+func TestRoomHandler_ExpiredRoom(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	room := model.NewRoom("Room", host.ID, false)
+	room.ExpiresAt = time.Now().Add(-time.Minute)
+	api.rooms.Create(context.Background(), room)
+
+	rec := api.do(t, http.MethodGet, "/api/v1/rooms/"+room.ID.String(), uuid.Nil, nil, nil)
+	expectError(t, rec, http.StatusGone, "room_expired")
+
+	rec = api.do(t, http.MethodPost, "/api/v1/rooms/"+room.ID.String()+"/join", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusGone, "room_expired")
+}
+
+func TestRoomHandler_ParticipantFlow(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	steps := []struct {
+		name   string
+		method string
+		path   string
+		actor  uuid.UUID
+		body   interface{}
+		status int
+	}{
+		{"join", http.MethodPost, roomPath + "/join", guest.ID, nil, http.StatusOK},
+		{"mute", http.MethodPost, roomPath + "/participants/" + guest.ID.String() + "/mute", host.ID, nil, http.StatusNoContent},
+		{"unmute", http.MethodPost, roomPath + "/participants/" + guest.ID.String() + "/unmute", guest.ID, nil, http.StatusNoContent},
+		{"extend", http.MethodPost, roomPath + "/extend", host.ID, map[string]interface{}{"hours": 2}, http.StatusOK},
+		{"update", http.MethodPatch, roomPath, host.ID, map[string]interface{}{"name": "Renamed", "isWaitingRoom": true}, http.StatusOK},
+		{"leave", http.MethodPost, roomPath + "/leave", guest.ID, nil, http.StatusNoContent},
+	}
+
+	for _, step := range steps {
+		rec := api.do(t, step.method, step.path, step.actor, step.body, nil)
+		if rec.Code != step.status {
+			t.Fatalf("%s: expected status %d, got %d: %s", step.name, step.status, rec.Code, rec.Body.String())
+		}
+	}
+
+	got, _ := api.rooms.GetByID(context.Background(), room.ID)
+	if got.Name != "Renamed" || !got.IsWaitingRoom {
+		t.Errorf("Expected updated settings, got %+v", got)
+	}
+	if !got.ExpiresAt.After(room.ExpiresAt.Add(time.Hour)) {
+		t.Errorf("Expected expiry to be extended, got %v", got.ExpiresAt)
+	}
+	if got.IsParticipant(guest.ID) {
+		t.Error("Expected guest to have left")
+	}
+
+	rec := api.do(t, http.MethodDelete, roomPath, host.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", rec.Code)
+	}
+	rec = api.do(t, http.MethodGet, roomPath, uuid.Nil, nil, nil)
+	expectError(t, rec, http.StatusNotFound, "room_not_found")
+}
+
+func TestRoomHandler_DeleteClosesRoom(t *testing.T) {
+	api := newTestAPI(t)
+	ctx := context.Background()
+	host := api.createUser(t, "Host")
+	other := api.createUser(t, "Other")
+	guest := api.createUser(t, "Guest")
+	waiter := api.createUser(t, "Waiter")
+
+	var room, next model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room", "isWaitingRoom": true}, &room)
+	api.do(t, http.MethodPost, "/api/v1/rooms", other.ID, map[string]interface{}{"name": "Next"}, &next)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+	api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	api.do(t, http.MethodPost, roomPath+"/waiting/"+guest.ID.String()+"/admit", host.ID, nil, nil)
+	api.do(t, http.MethodPost, roomPath+"/join", waiter.ID, nil, nil)
+
+	// ゲストは別のルームに移った
+	api.do(t, http.MethodPost, "/api/v1/rooms/"+next.ID.String()+"/join", guest.ID, nil, nil)
+
+	rec := api.do(t, http.MethodDelete, roomPath, host.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// 残っていた参加者にルームの終了を伝えて切断し、待機中のユーザーは入室を拒否する
+	if len(api.notifier.closed) != 1 || api.notifier.closed[0] != room.ID {
+		t.Errorf("Expected room %s to be closed, got %v", room.ID, api.notifier.closed)
+	}
+	denied := api.notifier.lastDirect(waiter.ID)
+	if denied == nil || denied.Payload.(model.ControlPayload).Action != "deny" {
+		t.Errorf("Expected the waiting user to be denied, got %+v", denied)
+	}
+	if due, _ := api.outbox.GetDue(ctx, time.Now().Add(time.Hour), 10); len(due) != 0 {
+		t.Errorf("Expected the events to be delivered, got %d events left", len(due))
+	}
+
+	// 他のルームのセッションは消さない
+	session, err := api.sessions.GetSession(ctx, guest.ID)
+	if err != nil || session.RoomID != next.ID {
+		t.Errorf("Expected the guest to keep the session of room %s, got %+v (%v)", next.ID, session, err)
+	}
+}
+
+// unavailableRooms is a room repository whose reads fail as if the database were down
+type unavailableRooms struct {
+	*memory.Room
+}
+
+func (unavailableRooms) GetByID(ctx context.Context, id uuid.UUID) (*model.Room, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestRoomHandler_StorageFailureIsNotNotFound(t *testing.T) {
+	api := newTestAPIWithRooms(t, unavailableRooms{memory.NewRoom()})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/rooms/"+uuid.New().String(), nil)
+	req.Header.Set(RequestIDHeader, "req-500")
+	rec := httptest.NewRecorder()
+	api.router.ServeHTTP(rec, req)
+	expectError(t, rec, http.StatusInternalServerError, "internal_error")
+
+	// 内部エラーの詳細はレスポンスに含めずリクエストIDと一緒に記録する
+	var entry struct {
+		Level     string `json:"level"`
+		Error     string `json:"error"`
+		Status    int    `json:"status"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(api.logs.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON log entry, got %q", api.logs.String())
+	}
+	if entry.Level != "ERROR" || entry.Status != http.StatusInternalServerError || entry.RequestID != "req-500" || !strings.Contains(entry.Error, "connection refused") {
+		t.Errorf("Expected the internal error to be logged with its request, got %+v", entry)
+	}
+}
+
+func TestRoomHandler_WaitingRoom(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+	other := api.createUser(t, "Other")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Lobby", "isWaitingRoom": true}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	// 待機室のあるルームへの参加は保留される
+	var joined JoinResponse
+	rec := api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, &joined)
+	if rec.Code != http.StatusAccepted || joined.Status != usecase.JoinStatusWaiting {
+		t.Fatalf("Expected 202 waiting, got %d %s", rec.Code, rec.Body.String())
+	}
+	api.do(t, http.MethodPost, roomPath+"/join", other.ID, nil, nil)
+
+	notice := api.notifier.lastDirect(host.ID)
+	if notice == nil || notice.Type != model.MessageTypeUserWaiting {
+		t.Fatalf("Expected host to be notified, got %+v", notice)
+	}
+
+	var waiting WaitingUsersResponse
+	api.do(t, http.MethodGet, roomPath+"/waiting", host.ID, nil, &waiting)
+	if len(waiting.UserIDs) != 2 {
+		t.Errorf("Expected 2 waiting users, got %v", waiting.UserIDs)
+	}
+
+	rec = api.do(t, http.MethodGet, roomPath+"/waiting", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	// 入室許可
+	rec = api.do(t, http.MethodPost, roomPath+"/waiting/"+guest.ID.String()+"/admit", host.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if admitted := api.notifier.lastDirect(guest.ID); admitted.Payload.(model.ControlPayload).Action != "admit" {
+		t.Errorf("Expected admit notification, got %+v", admitted.Payload)
+	}
+
+	got, _ := api.rooms.GetByID(context.Background(), room.ID)
+	if !got.IsParticipant(guest.ID) {
+		t.Error("Expected admitted guest to be a participant")
+	}
+
+	// 入室拒否
+	rec = api.do(t, http.MethodPost, roomPath+"/waiting/"+other.ID.String()+"/deny", host.ID, map[string]interface{}{"reason": "private meeting"}, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	denied := api.notifier.lastDirect(other.ID).Payload.(model.ControlPayload)
+	if denied.Action != "deny" || denied.Reason != "private meeting" {
+		t.Errorf("Expected deny notification with reason, got %+v", denied)
+	}
+
+	rec = api.do(t, http.MethodPost, roomPath+"/waiting/"+other.ID.String()+"/admit", host.ID, nil, nil)
+	expectError(t, rec, http.StatusNotFound, "not_waiting")
+
+	got, _ = api.rooms.GetByID(context.Background(), room.ID)
+	if got.IsParticipant(other.ID) {
+		t.Error("Expected denied user not to be a participant")
+	}
+}
+
+func TestRoomHandler_ConnectionToken(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+	other := api.createUser(t, "Other")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Lobby", "isWaitingRoom": true}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+	api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+
+	// 参加者と待機室のユーザーは自分とルームを表すトークンを受け取る
+	for _, user := range []*model.User{host, guest} {
+		var resp ConnectionTokenResponse
+		rec := api.do(t, http.MethodPost, roomPath+"/connection-token", user.ID, nil, &resp)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		claims, err := api.signer.VerifyConnection(resp.Token)
+		if err != nil {
+			t.Fatalf("Expected a valid connection token, got %v", err)
+		}
+		if claims.UserID != user.ID || claims.RoomID != room.ID || !claims.ExpiresAt.Equal(resp.ExpiresAt) {
+			t.Errorf("Expected claims for %s in %s, got %+v", user.Name, room.ID, claims)
+		}
+	}
+
+	rec := api.do(t, http.MethodPost, roomPath+"/connection-token", other.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "not_participant")
+
+	rec = api.do(t, http.MethodPost, "/api/v1/rooms/"+uuid.NewString()+"/connection-token", host.ID, nil, nil)
+	expectError(t, rec, http.StatusNotFound, "room_not_found")
+}
+
+func TestRoomHandler_WaitingRoomCancelAndDisable(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+	other := api.createUser(t, "Other")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Lobby", "isWaitingRoom": true}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	api.do(t, http.MethodPost, roomPath+"/join", other.ID, nil, nil)
+
+	// 待機中のユーザーは退出で待機をやめられる
+	rec := api.do(t, http.MethodPost, roomPath+"/leave", guest.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// 待機室を無効にすると待機中のユーザーは入室する
+	rec = api.do(t, http.MethodPatch, roomPath, host.ID, map[string]interface{}{"isWaitingRoom": false}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	got, _ := api.rooms.GetByID(context.Background(), room.ID)
+	if !got.IsParticipant(other.ID) {
+		t.Error("Expected waiting user to be admitted when the waiting room is disabled")
+	}
+	if got.IsParticipant(guest.ID) {
+		t.Error("Expected cancelled user not to be admitted")
+	}
+}
+
+func TestRoomHandler_HostSuccession(t *testing.T) {
+	tests := []struct {
+		name       string
+		coHost     bool
+		wantSecond bool
+	}{
+		{"longest-tenured participant", false, false},
+		{"designated co-host", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t)
+			host := api.createUser(t, "Host")
+			first := api.createUser(t, "First")
+			second := api.createUser(t, "Second")
+
+			var room model.Room
+			api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+			roomPath := "/api/v1/rooms/" + room.ID.String()
+			api.do(t, http.MethodPost, roomPath+"/join", first.ID, nil, nil)
+			api.do(t, http.MethodPost, roomPath+"/join", second.ID, nil, nil)
+
+			if tt.coHost {
+				rec := api.do(t, http.MethodPut, roomPath+"/participants/"+second.ID.String()+"/role", host.ID, map[string]interface{}{"role": "co_host"}, nil)
+				if rec.Code != http.StatusNoContent {
+					t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+				}
+			}
+
+			rec := api.do(t, http.MethodPost, roomPath+"/leave", host.ID, nil, nil)
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+			}
+
+			want := first.ID
+			if tt.wantSecond {
+				want = second.ID
+			}
+
+			got, _ := api.rooms.GetByID(context.Background(), room.ID)
+			if got.HostID != want {
+				t.Errorf("Expected HostID %s, got %s", want, got.HostID)
+			}
+			for _, p := range got.Participants {
+				if p.IsHost != (p.UserID == want) {
+					t.Errorf("Expected IsHost %v for %s, got %v", p.UserID == want, p.UserID, p.IsHost)
+				}
+			}
+
+			if len(api.notifier.hostChanges) != 1 {
+				t.Fatalf("Expected 1 host change notification, got %d", len(api.notifier.hostChanges))
+			}
+			payload := api.notifier.hostChanges[0].Payload.(model.HostChangedPayload)
+			if payload.PreviousHostID != host.ID || payload.NewHostID != want || payload.Reason != model.HostChangeReasonLeft {
+				t.Errorf("Unexpected host change payload %+v", payload)
+			}
+		})
+	}
+}
+
+func TestRoomHandler_TransferHost(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+	outsider := api.createUser(t, "Outsider")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+	api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+
+	rec := api.do(t, http.MethodPost, roomPath+"/host", guest.ID, map[string]interface{}{"userId": guest.ID}, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	rec = api.do(t, http.MethodPost, roomPath+"/host", host.ID, map[string]interface{}{"userId": outsider.ID}, nil)
+	expectError(t, rec, http.StatusNotFound, "participant_not_found")
+
+	rec = api.do(t, http.MethodPost, roomPath+"/host", host.ID, map[string]interface{}{"userId": host.ID}, nil)
+	expectError(t, rec, http.StatusBadRequest, "invalid_input")
+
+	var got model.Room
+	rec = api.do(t, http.MethodPost, roomPath+"/host", host.ID, map[string]interface{}{"userId": guest.ID}, &got)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got.HostID != guest.ID || !got.IsHost(guest.ID) || got.IsHost(host.ID) {
+		t.Errorf("Expected guest to be the new host, got %+v", got.Participants)
+	}
+
+	// 元のホストはホスト権限を失う
+	rec = api.do(t, http.MethodPost, roomPath+"/participants/"+guest.ID.String()+"/mute", host.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	payload := api.notifier.hostChanges[0].Payload.(model.HostChangedPayload)
+	if payload.Reason != model.HostChangeReasonTransferred {
+		t.Errorf("Expected Reason %s, got %s", model.HostChangeReasonTransferred, payload.Reason)
+	}
+}
+
+func TestRoomHandler_Roles(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	coHost := api.createUser(t, "CoHost")
+	viewer := api.createUser(t, "Viewer")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+	api.do(t, http.MethodPost, roomPath+"/join", coHost.ID, nil, nil)
+	api.do(t, http.MethodPost, roomPath+"/join", viewer.ID, nil, nil)
+
+	rolePath := func(userID uuid.UUID) string {
+		return roomPath + "/participants/" + userID.String() + "/role"
+	}
+
+	steps := []struct {
+		name   string
+		method string
+		path   string
+		actor  uuid.UUID
+		body   interface{}
+		status int
+		code   string
+	}{
+		{"attendee cannot promote", http.MethodPut, rolePath(coHost.ID), viewer.ID, map[string]interface{}{"role": "co_host"}, http.StatusForbidden, "permission_denied"},
+		{"unknown role", http.MethodPut, rolePath(coHost.ID), host.ID, map[string]interface{}{"role": "owner"}, http.StatusBadRequest, "invalid_role"},
+		{"host promotes co-host", http.MethodPut, rolePath(coHost.ID), host.ID, map[string]interface{}{"role": "co_host"}, http.StatusNoContent, ""},
+		{"co-host demotes to viewer", http.MethodPut, rolePath(viewer.ID), coHost.ID, map[string]interface{}{"role": "viewer"}, http.StatusNoContent, ""},
+		{"co-host cannot demote host", http.MethodPut, rolePath(host.ID), coHost.ID, map[string]interface{}{"role": "attendee"}, http.StatusForbidden, "permission_denied"},
+		{"co-host mutes", http.MethodPost, roomPath + "/participants/" + viewer.ID.String() + "/mute", coHost.ID, nil, http.StatusNoContent, ""},
+		{"co-host extends", http.MethodPost, roomPath + "/extend", coHost.ID, map[string]interface{}{"hours": 1}, http.StatusOK, ""},
+		{"co-host cannot delete room", http.MethodDelete, roomPath, coHost.ID, nil, http.StatusForbidden, "permission_denied"},
+		{"co-host cannot transfer host", http.MethodPost, roomPath + "/host", coHost.ID, map[string]interface{}{"userId": coHost.ID}, http.StatusForbidden, "permission_denied"},
+		{"viewer cannot unmute", http.MethodPost, roomPath + "/participants/" + viewer.ID.String() + "/unmute", viewer.ID, nil, http.StatusForbidden, "permission_denied"},
+		{"viewer cannot chat", http.MethodPost, roomPath + "/messages", viewer.ID, map[string]interface{}{"message": "Hello"}, http.StatusForbidden, "permission_denied"},
+		{"co-host deletes history", http.MethodDelete, roomPath + "/messages", coHost.ID, nil, http.StatusNoContent, ""},
+	}
+
+	for _, step := range steps {
+		rec := api.do(t, step.method, step.path, step.actor, step.body, nil)
+		if step.code != "" {
+			expectError(t, rec, step.status, step.code)
+			continue
+		}
+		if rec.Code != step.status {
+			t.Fatalf("%s: expected status %d, got %d: %s", step.name, step.status, rec.Code, rec.Body.String())
+		}
+	}
+
+	got, _ := api.rooms.GetByID(context.Background(), room.ID)
+	if got.RoleOf(coHost.ID) != model.RoleCoHost || got.RoleOf(viewer.ID) != model.RoleViewer {
+		t.Errorf("Expected co_host and viewer roles, got %+v", got.Participants)
+	}
+}
+
+func TestRoomHandler_Kick(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+	other := api.createUser(t, "Other")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+	api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	api.do(t, http.MethodPost, roomPath+"/join", other.ID, nil, nil)
+
+	rec := api.do(t, http.MethodPost, roomPath+"/participants/"+guest.ID.String()+"/kick", other.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	// 退出のみ（ボディなし）
+	rec = api.do(t, http.MethodPost, roomPath+"/participants/"+other.ID.String()+"/kick", host.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if kicked := api.notifier.lastDirect(other.ID).Payload.(model.ControlPayload); kicked.Action != "kick" {
+		t.Errorf("Expected kick notification, got %+v", kicked)
+	}
+	rec = api.do(t, http.MethodPost, roomPath+"/join", other.ID, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected kicked user to rejoin, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// 退出と再入室禁止
+	rec = api.do(t, http.MethodPost, roomPath+"/participants/"+guest.ID.String()+"/kick", host.ID, map[string]interface{}{"ban": true, "reason": "spam"}, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	banned := api.notifier.lastDirect(guest.ID).Payload.(model.ControlPayload)
+	if banned.Action != "ban" || banned.Reason != "spam" {
+		t.Errorf("Expected ban notification with reason, got %+v", banned)
+	}
+
+	rec = api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "user_banned")
+
+	// 待機室経由でも再入室できない
+	api.do(t, http.MethodPatch, roomPath, host.ID, map[string]interface{}{"isWaitingRoom": true}, nil)
+	rec = api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "user_banned")
+
+	got, _ := api.rooms.GetByID(context.Background(), room.ID)
+	if got.IsParticipant(guest.ID) {
+		t.Error("Expected banned user not to be a participant")
+	}
+}
+
+func TestRoomHandler_Lock(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	rec := api.do(t, http.MethodPost, roomPath+"/lock", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	var locked model.Room
+	rec = api.do(t, http.MethodPost, roomPath+"/lock", host.ID, nil, &locked)
+	if rec.Code != http.StatusOK || !locked.IsLocked {
+		t.Fatalf("Expected locked room, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusLocked, "room_locked")
+
+	api.do(t, http.MethodPost, roomPath+"/unlock", host.ID, nil, nil)
+	rec = api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected guest to join an unlocked room, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRoomHandler_Passcode(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+	other := api.createUser(t, "Other")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	rec := api.do(t, http.MethodPut, roomPath+"/passcode", host.ID, map[string]interface{}{"passcode": "12"}, nil)
+	expectError(t, rec, http.StatusBadRequest, "invalid_input")
+
+	rec = api.do(t, http.MethodPut, roomPath+"/passcode", host.ID, map[string]interface{}{"passcode": "2468"}, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// ハッシュはレスポンスに含めない
+	rec = api.do(t, http.MethodGet, roomPath, uuid.Nil, nil, nil)
+	if stored, _ := api.rooms.GetByID(context.Background(), room.ID); strings.Contains(rec.Body.String(), stored.PasscodeHash) {
+		t.Error("Expected passcode hash not to be exposed")
+	}
+
+	rec = api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "invalid_passcode")
+
+	rec = api.do(t, http.MethodPost, roomPath+"/join", guest.ID, map[string]interface{}{"passcode": "2468"}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected guest to join with the passcode, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// 試行回数を超えると正しいパスコードでも拒否される
+	for i := 0; i < usecase.MaxPasscodeAttempts; i++ {
+		rec = api.do(t, http.MethodPost, roomPath+"/join", other.ID, map[string]interface{}{"passcode": "0000"}, nil)
+		expectError(t, rec, http.StatusForbidden, "invalid_passcode")
+	}
+	rec = api.do(t, http.MethodPost, roomPath+"/join", other.ID, map[string]interface{}{"passcode": "2468"}, nil)
+	expectError(t, rec, http.StatusTooManyRequests, "too_many_attempts")
+
+	rec = api.do(t, http.MethodDelete, roomPath+"/passcode", host.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = api.do(t, http.MethodPost, roomPath+"/join", other.ID, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected join without passcode once removed, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRoomHandler_ScheduledRoom(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	startsAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	endsAt := startsAt.Add(30 * time.Minute)
+
+	var room model.Room
+	rec := api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{
+		"name":     "Standup",
+		"schedule": map[string]interface{}{"startsAt": startsAt, "endsAt": endsAt, "earlyJoinMinutes": 5},
+	}, &room)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if room.Schedule == nil || room.Schedule.EarlyJoinMinutes != 5 {
+		t.Fatalf("Expected schedule with 5 minute early join, got %+v", room.Schedule)
+	}
+	if !room.ExpiresAt.Equal(endsAt.Add(model.ScheduleGracePeriod)) {
+		t.Errorf("Expected ExpiresAt %v, got %v", endsAt.Add(model.ScheduleGracePeriod), room.ExpiresAt)
+	}
+	if len(room.Participants) != 0 {
+		t.Errorf("Expected no participants before the meeting, got %d", len(room.Participants))
+	}
+	roomPath := "/api/v1/rooms/" + room.ID.String()
+
+	// 開始前は参加できない
+	rec = api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	expectError(t, rec, http.StatusConflict, "room_not_started")
+
+	var meetings RoomsResponse
+	api.do(t, http.MethodGet, "/api/v1/users/"+host.ID.String()+"/meetings", host.ID, nil, &meetings)
+	if len(meetings.Rooms) != 1 || meetings.Rooms[0].ID != room.ID {
+		t.Errorf("Expected the scheduled room in upcoming meetings, got %+v", meetings.Rooms)
+	}
+
+	// 早期入室の時間帯に前倒しすると参加できる
+	var rescheduled model.Room
+	rec = api.do(t, http.MethodPut, roomPath+"/schedule", host.ID, map[string]interface{}{
+		"startsAt": time.Now().Add(5 * time.Minute), "endsAt": time.Now().Add(time.Hour),
+	}, &rescheduled)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rescheduled.Schedule.EarlyJoinMinutes != int(model.DefaultEarlyJoinWindow/time.Minute) {
+		t.Errorf("Expected default early join window, got %d minutes", rescheduled.Schedule.EarlyJoinMinutes)
+	}
+
+	rec = api.do(t, http.MethodPost, roomPath+"/join", guest.ID, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRoomHandler_ScheduleErrors(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	var adHoc model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Ad hoc"}, &adHoc)
+
+	startsAt := time.Now().Add(time.Hour)
+	var scheduled model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{
+		"name": "Planned", "schedule": map[string]interface{}{"startsAt": startsAt, "endsAt": startsAt.Add(time.Hour)},
+	}, &scheduled)
+	valid := map[string]interface{}{"startsAt": startsAt, "endsAt": startsAt.Add(time.Hour)}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		actor  *model.User
+		body   interface{}
+		status int
+		code   string
+	}{
+		{"end before start", http.MethodPost, "/api/v1/rooms", host, map[string]interface{}{
+			"name": "Bad", "schedule": map[string]interface{}{"startsAt": startsAt, "endsAt": startsAt.Add(-time.Minute)},
+		}, http.StatusBadRequest, "invalid_schedule"},
+		{"too long", http.MethodPost, "/api/v1/rooms", host, map[string]interface{}{
+			"name": "Bad", "schedule": map[string]interface{}{"startsAt": startsAt, "endsAt": startsAt.Add(48 * time.Hour)},
+		}, http.StatusBadRequest, "invalid_schedule"},
+		{"missing end", http.MethodPut, "/api/v1/rooms/" + scheduled.ID.String() + "/schedule", host, map[string]interface{}{"startsAt": startsAt}, http.StatusBadRequest, "invalid_input"},
+		{"not scheduled", http.MethodPut, "/api/v1/rooms/" + adHoc.ID.String() + "/schedule", host, valid, http.StatusBadRequest, "invalid_schedule"},
+		{"not host", http.MethodPut, "/api/v1/rooms/" + scheduled.ID.String() + "/schedule", guest, valid, http.StatusForbidden, "permission_denied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := api.do(t, tt.method, tt.path, tt.actor.ID, tt.body, nil)
+			expectError(t, rec, tt.status, tt.code)
+		})
+	}
+}
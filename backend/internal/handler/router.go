@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"log/slog"
+
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// NewRouter creates the HTTP API router over the usecase layer
+// Requests that fail with an unknown error are logged to logger
+func NewRouter(roomUsecase *usecase.Room, userUsecase *usecase.User, messageUsecase *usecase.Message, inviteUsecase *usecase.Invite, seriesUsecase *usecase.Series, calendarUsecase *usecase.Calendar, logger *slog.Logger) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery(), requestID, logErrors(logger))
+
+	rooms := NewRoomHandler(roomUsecase)
+	users := NewUserHandler(userUsecase, roomUsecase)
+	messages := NewMessageHandler(messageUsecase)
+	invites := NewInviteHandler(inviteUsecase)
+	series := NewSeriesHandler(seriesUsecase)
+	calendars := NewCalendarHandler(calendarUsecase)
+
+	api := router.Group("/api/v1")
+	api.GET("/openapi.json", serveOpenAPI)
+
+	// ユーザー
+	api.POST("/users", users.Create)
+	api.GET("/users/:userId", users.Get)
+	api.PATCH("/users/:userId", requireUser, users.UpdateProfile)
+	api.GET("/users/:userId/rooms", users.ListRooms)
+	api.GET("/users/:userId/meetings", users.ListMeetings)
+
+	// ルーム
+	api.POST("/rooms", requireUser, rooms.Create)
+	api.GET("/rooms/:roomId", rooms.Get)
+	api.PATCH("/rooms/:roomId", requireUser, rooms.Update)
+	api.DELETE("/rooms/:roomId", requireUser, rooms.Delete)
+	api.POST("/rooms/:roomId/join", requireUser, rooms.Join)
+	api.POST("/rooms/:roomId/leave", requireUser, rooms.Leave)
+	api.POST("/rooms/:roomId/extend", requireUser, rooms.Extend)
+	api.PUT("/rooms/:roomId/schedule", requireUser, rooms.Reschedule)
+	api.POST("/rooms/:roomId/lock", requireUser, rooms.Lock)
+	api.POST("/rooms/:roomId/unlock", requireUser, rooms.Unlock)
+	api.PUT("/rooms/:roomId/passcode", requireUser, rooms.SetPasscode)
+	api.DELETE("/rooms/:roomId/passcode", requireUser, rooms.RemovePasscode)
+	api.POST("/rooms/:roomId/participants/:userId/mute", requireUser, rooms.Mute)
+	api.POST("/rooms/:roomId/participants/:userId/unmute", requireUser, rooms.Unmute)
+	api.PUT("/rooms/:roomId/participants/:userId/role", requireUser, rooms.SetRole)
+	api.POST("/rooms/:roomId/participants/:userId/kick", requireUser, rooms.Kick)
+	api.POST("/rooms/:roomId/host", requireUser, rooms.TransferHost)
+
+	// 定期的な会議
+	api.POST("/series", requireUser, series.Create)
+	api.GET("/series/:seriesId", series.Get)
+	api.DELETE("/series/:seriesId", requireUser, series.Delete)
+	api.GET("/series/:seriesId/occurrences", series.Occurrences)
+	api.POST("/series/:seriesId/occurrences", requireUser, series.Materialize)
+	api.POST("/series/:seriesId/occurrences/cancel", requireUser, series.Cancel)
+
+	// カレンダー (.ics)
+	api.GET("/rooms/:roomId/calendar.ics", requireUser, calendars.Room)
+	api.GET("/rooms/:roomId/calendar/cancellation.ics", requireUser, calendars.RoomCancellation)
+	api.GET("/series/:seriesId/calendar.ics", requireUser, calendars.Series)
+	api.GET("/series/:seriesId/occurrences/cancellation.ics", requireUser, calendars.OccurrenceCancellation)
+
+	// 待機室
+	api.GET("/rooms/:roomId/waiting", requireUser, rooms.Waiting)
+	api.POST("/rooms/:roomId/waiting/:userId/admit", requireUser, rooms.Admit)
+	api.POST("/rooms/:roomId/waiting/:userId/deny", requireUser, rooms.Deny)
+
+	// WebSocket接続
+	api.POST("/rooms/:roomId/connection-token", requireUser, rooms.ConnectionToken)
+
+	// 招待
+	api.POST("/rooms/:roomId/invites", requireUser, invites.Create)
+	api.GET("/rooms/:roomId/invites", requireUser, invites.List)
+	api.DELETE("/rooms/:roomId/invites/:inviteId", requireUser, invites.Revoke)
+	api.POST("/invites/redeem", requireUser, invites.Redeem)
+
+	// チャット
+	api.GET("/rooms/:roomId/messages", requireUser, messages.History)
+	api.POST("/rooms/:roomId/messages", requireUser, messages.Send)
+	api.DELETE("/rooms/:roomId/messages", requireUser, messages.DeleteHistory)
+
+	return router
+}
@@ -0,0 +1,379 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/infrastructure/ical"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/cline-meet/backend/internal/infrastructure/token"
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// recordingNotifier is a service.RealtimeNotifier that records direct messages, host changes, joins and closed rooms
+// While fail is set, joins are not delivered
+type recordingNotifier struct {
+	direct      []*model.Message
+	hostChanges []*model.Message
+	joined      []uuid.UUID
+	closed      []uuid.UUID
+	fail        error
+	mutex       sync.Mutex
+}
+
+func (n *recordingNotifier) NotifyRoomJoined(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.fail != nil {
+		return n.fail
+	}
+	n.joined = append(n.joined, userID)
+	return nil
+}
+
+func (n *recordingNotifier) NotifyRoomLeft(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	return nil
+}
+
+func (n *recordingNotifier) NotifyUserMuted(ctx context.Context, roomID, actorID, userID uuid.UUID, isMuted bool) error {
+	return nil
+}
+
+func (n *recordingNotifier) BroadcastChatMessage(ctx context.Context, message *model.Message) error {
+	return nil
+}
+
+func (n *recordingNotifier) SendDirectMessage(ctx context.Context, message *model.Message) error {
+	n.direct = append(n.direct, message)
+	return nil
+}
+
+func (n *recordingNotifier) NotifyRoomUpdate(ctx context.Context, room *model.Room) error {
+	return nil
+}
+
+func (n *recordingNotifier) NotifyHostChanged(ctx context.Context, roomID, previousHostID, newHostID uuid.UUID, reason string) error {
+	n.hostChanges = append(n.hostChanges, model.NewHostChangedMessage(roomID, previousHostID, newHostID, reason))
+	return nil
+}
+
+func (n *recordingNotifier) NotifyRoomClosed(ctx context.Context, roomID uuid.UUID, reason string) error {
+	n.closed = append(n.closed, roomID)
+	return nil
+}
+
+// lastDirect returns the most recent direct message sent to userID
+func (n *recordingNotifier) lastDirect(userID uuid.UUID) *model.Message {
+	for i := len(n.direct) - 1; i >= 0; i-- {
+		if n.direct[i].TargetUserID == userID {
+			return n.direct[i]
+		}
+	}
+	return nil
+}
+
+// recordingFailures is a service.FailureCounter that records the failed steps
+type recordingFailures struct {
+	steps map[string]int
+	mutex sync.Mutex
+}
+
+func (f *recordingFailures) CountFailure(step string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.steps[step]++
+}
+
+func (f *recordingFailures) count(step string) int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.steps[step]
+}
+
+// testPublicURL is the web app URL join links in calendar entries point to
+const testPublicURL = "https://meet.example.com"
+
+type testAPI struct {
+	router   *gin.Engine
+	rooms    repository.Room
+	users    *memory.User
+	notifier *recordingNotifier
+	sessions *memory.SessionManager
+	events   *usecase.Events
+	outbox   *memory.Outbox
+	failures *recordingFailures
+	signer   *token.HMACSigner
+	logs     *bytes.Buffer
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	return newTestAPIWithRooms(t, memory.NewRoom())
+}
+
+// newTestAPIWithRooms builds the API over the given room repository
+func newTestAPIWithRooms(t *testing.T, rooms repository.Room) *testAPI {
+	t.Helper()
+
+	users := memory.NewUser()
+	messages := memory.NewMessage()
+	notifier := &recordingNotifier{}
+	sessions := memory.NewSessionManager("test-pod")
+
+	signer, err := token.NewHMACSigner([]byte("test-invite-secret-0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("NewHMACSigner failed: %v", err)
+	}
+
+	invites := memory.NewInvite()
+	series := memory.NewSeries()
+	transactor := memory.NewTransactor()
+	outbox := memory.NewOutbox()
+	failures := &recordingFailures{steps: make(map[string]int)}
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(logs, nil))
+	events := usecase.NewEvents(outbox, transactor, notifier, failures, logger)
+	roomUsecase := usecase.NewRoom(rooms, users, memory.NewWaitingRoom(), transactor, events, notifier, sessions, signer, memory.NewRateLimiter(usecase.MaxPasscodeAttempts, usecase.PasscodeAttemptWindow), model.DefaultRoomPolicy(), failures, logger)
+	router := NewRouter(
+		roomUsecase,
+		usecase.NewUser(users, notifier, sessions, failures, logger),
+		usecase.NewMessage(messages, rooms, users, transactor, events),
+		usecase.NewInvite(invites, rooms, users, signer, transactor, roomUsecase),
+		usecase.NewSeries(series, rooms, users, transactor, roomUsecase),
+		usecase.NewCalendar(rooms, series, users, invites, signer, ical.NewEncoder(), testPublicURL),
+		logger,
+	)
+	return &testAPI{router: router, rooms: rooms, users: users, notifier: notifier, sessions: sessions, events: events, outbox: outbox, failures: failures, signer: signer, logs: logs}
+}
+
+// do sends a request as actor (uuid.Nil for anonymous) and decodes the JSON response into out
+func (a *testAPI) do(t *testing.T, method, path string, actor uuid.UUID, body interface{}, out interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if actor != uuid.Nil {
+		req.Header.Set(UserIDHeader, actor.String())
+	}
+
+	rec := httptest.NewRecorder()
+	a.router.ServeHTTP(rec, req)
+
+	if out != nil && rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("Expected JSON response, got %s", rec.Body.String())
+		}
+	}
+	return rec
+}
+
+func (a *testAPI) createUser(t *testing.T, name string) *model.User {
+	t.Helper()
+
+	user := model.NewUser(uuid.NewString(), strings.ToLower(name)+"@example.com", name, "")
+	if err := a.users.Create(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error creating user, got %v", err)
+	}
+	return user
+}
+
+// expectError checks the status and error code of a JSON error response
+func expectError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+
+	if rec.Code != status {
+		t.Errorf("Expected status %d, got %d: %s", status, rec.Code, rec.Body.String())
+	}
+
+	var resp ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Expected JSON error body, got %s", rec.Body.String())
+	}
+	if resp.Error.Code != code {
+		t.Errorf("Expected error code %s, got %s", code, resp.Error.Code)
+	}
+	if resp.Error.Message == "" {
+		t.Error("Expected error message")
+	}
+}
+
+func TestRouter_OpenAPIMatchesRoutes(t *testing.T) {
+	api := newTestAPI(t)
+
+	var spec struct {
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPISpec, &spec); err != nil {
+		t.Fatalf("Expected valid OpenAPI JSON, got %v", err)
+	}
+	base := spec.Servers[0].URL
+
+	documented := map[string]bool{}
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			documented[strings.ToUpper(method)+" "+base+path] = true
+		}
+	}
+
+	// gin の :param を OpenAPI の {param} に変換する
+	param := regexp.MustCompile(`:(\w+)`)
+	registered := map[string]bool{}
+	for _, route := range api.router.Routes() {
+		registered[route.Method+" "+param.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+
+	var missing, stale []string
+	for route := range registered {
+		if !documented[route] {
+			missing = append(missing, route)
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			stale = append(stale, route)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+
+	if len(missing) > 0 {
+		t.Errorf("Routes missing from openapi.json: %v", missing)
+	}
+	if len(stale) > 0 {
+		t.Errorf("Documented operations without a route: %v", stale)
+	}
+}
+
+func TestRouter_OpenAPIErrorCodes(t *testing.T) {
+	var spec struct {
+		Components struct {
+			Schemas struct {
+				Error struct {
+					Properties struct {
+						Error struct {
+							Properties struct {
+								Code struct {
+									Enum []string `json:"enum"`
+								} `json:"code"`
+							} `json:"properties"`
+						} `json:"error"`
+					} `json:"properties"`
+				} `json:"Error"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	json.Unmarshal(openAPISpec, &spec)
+
+	codes := map[string]bool{}
+	for _, code := range spec.Components.Schemas.Error.Properties.Error.Properties.Code.Enum {
+		codes[code] = true
+	}
+
+	for _, m := range errorMappings {
+		if !codes[m.code] {
+			t.Errorf("Error code %s is not documented", m.code)
+		}
+	}
+	for _, code := range []string{"invalid_input", "unauthorized", "forbidden", "internal_error"} {
+		if !codes[code] {
+			t.Errorf("Error code %s is not documented", code)
+		}
+	}
+}
+
+func TestRouter_ServesOpenAPI(t *testing.T) {
+	api := newTestAPI(t)
+
+	rec := api.do(t, http.MethodGet, "/api/v1/openapi.json", uuid.Nil, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+	if !bytes.Equal(rec.Body.Bytes(), openAPISpec) {
+		t.Error("Expected embedded OpenAPI document")
+	}
+}
+
+func TestRouter_RequiresUserHeader(t *testing.T) {
+	api := newTestAPI(t)
+
+	rec := api.do(t, http.MethodPost, "/api/v1/rooms", uuid.Nil, map[string]interface{}{"name": "Room"}, nil)
+	expectError(t, rec, http.StatusUnauthorized, "unauthorized")
+}
+
+func TestRouter_RequestID(t *testing.T) {
+	api := newTestAPI(t)
+
+	// IDがなければ生成して返す
+	rec := api.do(t, http.MethodGet, "/api/v1/openapi.json", uuid.Nil, nil, nil)
+	if _, err := uuid.Parse(rec.Header().Get(RequestIDHeader)); err != nil {
+		t.Errorf("Expected a generated request ID, got %q", rec.Header().Get(RequestIDHeader))
+	}
+
+	host := api.createUser(t, "Host")
+	guest := api.createUser(t, "Guest")
+
+	var room model.Room
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Room"}, &room)
+
+	// 失敗した通知はリクエストIDと一緒に記録され、ステップごとに数えられる
+	api.notifier.fail = errors.New("redis unavailable")
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/rooms/"+room.ID.String()+"/join", nil)
+	req.Header.Set(UserIDHeader, guest.ID.String())
+	req.Header.Set(RequestIDHeader, "req-123")
+	rec = httptest.NewRecorder()
+	api.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get(RequestIDHeader); got != "req-123" {
+		t.Errorf("Expected request ID req-123 to be echoed, got %q", got)
+	}
+
+	var entry struct {
+		Step      string `json:"step"`
+		RequestID string `json:"request_id"`
+		RoomID    string `json:"room_id"`
+	}
+	if err := json.Unmarshal(api.logs.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON log entry, got %q", api.logs.String())
+	}
+	if entry.Step != "publish_event" || entry.RequestID != "req-123" || entry.RoomID != room.ID.String() {
+		t.Errorf("Expected the failed publish to be logged with its request, got %+v", entry)
+	}
+	if got := api.failures.count("publish_event"); got != 1 {
+		t.Errorf("Expected 1 publish_event failure to be counted, got %d", got)
+	}
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DefaultOccurrenceCount is the number of occurrences listed when count is not given
+const DefaultOccurrenceCount = 10
+
+// SeriesHandler exposes usecase.Series over HTTP
+type SeriesHandler struct {
+	series *usecase.Series
+}
+
+// NewSeriesHandler creates a new SeriesHandler
+func NewSeriesHandler(series *usecase.Series) *SeriesHandler {
+	return &SeriesHandler{series: series}
+}
+
+// createSeriesRequest describes a recurring meeting; earlyJoinMinutes defaults to model.DefaultEarlyJoinWindow
+type createSeriesRequest struct {
+	Name             string      `json:"name"`
+	IsWaitingRoom    bool        `json:"isWaitingRoom"`
+	MaxCapacity      int         `json:"maxCapacity" binding:"min=0"`
+	CoHostIDs        []uuid.UUID `json:"coHostIds"`
+	StartsAt         time.Time   `json:"startsAt" binding:"required"`
+	DurationMinutes  int         `json:"durationMinutes" binding:"required,min=1"`
+	EarlyJoinMinutes *int        `json:"earlyJoinMinutes"`
+	RRule            string      `json:"rrule" binding:"required"`
+	TimeZone         string      `json:"timeZone"`
+
+	// 各回のルームの設定（省略時はルームポリシーの既定値）
+	MaxExtensionHours *int  `json:"maxExtensionHours"`
+	AutoDelete        *bool `json:"autoDelete"`
+}
+
+type occurrenceRequest struct {
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+}
+
+// OccurrencesResponse is the body returned when listing occurrences
+type OccurrencesResponse struct {
+	Occurrences []model.Occurrence `json:"occurrences"`
+}
+
+// Create creates a meeting series hosted by the acting user
+func (h *SeriesHandler) Create(c *gin.Context) {
+	var req createSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	earlyJoin := model.DefaultEarlyJoinWindow
+	if req.EarlyJoinMinutes != nil {
+		earlyJoin = time.Duration(*req.EarlyJoinMinutes) * time.Minute
+	}
+
+	series, err := h.series.CreateSeries(c.Request.Context(), currentUser(c), usecase.SeriesInput{
+		Name:          req.Name,
+		IsWaitingRoom: req.IsWaitingRoom,
+		MaxCapacity:   req.MaxCapacity,
+		MaxExtension:  hours(req.MaxExtensionHours),
+		AutoDelete:    req.AutoDelete,
+		CoHostIDs:     req.CoHostIDs,
+		StartsAt:      req.StartsAt,
+		Duration:      time.Duration(req.DurationMinutes) * time.Minute,
+		EarlyJoin:     earlyJoin,
+		RRule:         req.RRule,
+		TimeZone:      req.TimeZone,
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+// Get returns a series
+func (h *SeriesHandler) Get(c *gin.Context) {
+	seriesID, ok := uuidParam(c, "seriesId")
+	if !ok {
+		return
+	}
+
+	series, err := h.series.GetSeries(c.Request.Context(), seriesID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// Delete deletes a series (host only)
+func (h *SeriesHandler) Delete(c *gin.Context) {
+	seriesID, ok := uuidParam(c, "seriesId")
+	if !ok {
+		return
+	}
+
+	if err := h.series.DeleteSeries(c.Request.Context(), currentUser(c), seriesID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Occurrences lists the series' next occurrences
+func (h *SeriesHandler) Occurrences(c *gin.Context) {
+	seriesID, ok := uuidParam(c, "seriesId")
+	if !ok {
+		return
+	}
+
+	count := DefaultOccurrenceCount
+	if value := c.Query("count"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			writeBadRequest(c, "invalid count")
+			return
+		}
+		count = parsed
+	}
+
+	occurrences, err := h.series.ListOccurrences(c.Request.Context(), seriesID, count)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, OccurrencesResponse{Occurrences: occurrences})
+}
+
+// Materialize returns the room for an occurrence, creating it on first use
+func (h *SeriesHandler) Materialize(c *gin.Context) {
+	seriesID, ok := uuidParam(c, "seriesId")
+	if !ok {
+		return
+	}
+
+	var req occurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	room, err := h.series.MaterializeOccurrence(c.Request.Context(), currentUser(c), seriesID, req.StartsAt)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// Cancel cancels an occurrence (host and co-hosts)
+func (h *SeriesHandler) Cancel(c *gin.Context) {
+	seriesID, ok := uuidParam(c, "seriesId")
+	if !ok {
+		return
+	}
+
+	var req occurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.series.CancelOccurrence(c.Request.Context(), currentUser(c), seriesID, req.StartsAt); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
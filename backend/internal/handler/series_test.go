@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+func TestSeriesHandler_CreateListAndMaterialize(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	coHost := api.createUser(t, "Co-host")
+
+	// 毎日の朝会（最初の回は参加受付中）
+	var series model.Series
+	rec := api.do(t, http.MethodPost, "/api/v1/series", host.ID, map[string]interface{}{
+		"name": "Standup", "isWaitingRoom": true, "maxCapacity": 5, "coHostIds": []uuid.UUID{coHost.ID},
+		"startsAt": time.Now().Add(5 * time.Minute), "durationMinutes": 15, "rrule": "FREQ=DAILY",
+		"maxExtensionHours": 2, "autoDelete": false,
+	}, &series)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if series.EarlyJoinMinutes != 10 || series.TimeZone != "UTC" || !series.IsCoHost(coHost.ID) {
+		t.Errorf("Expected defaults and co-host, got %+v", series)
+	}
+	seriesPath := "/api/v1/series/" + series.ID.String()
+
+	var list OccurrencesResponse
+	rec = api.do(t, http.MethodGet, seriesPath+"/occurrences?count=3", uuid.Nil, nil, &list)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(list.Occurrences) != 3 || list.Occurrences[1].StartsAt.Sub(list.Occurrences[0].StartsAt) != 24*time.Hour {
+		t.Fatalf("Expected 3 daily occurrences, got %+v", list.Occurrences)
+	}
+	first := list.Occurrences[0]
+
+	var room model.Room
+	rec = api.do(t, http.MethodPost, seriesPath+"/occurrences", coHost.ID, map[string]interface{}{"startsAt": first.StartsAt}, &room)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if room.HostID != host.ID || room.MaxCapacity != 5 || !room.IsWaitingRoom || room.Occurrence == nil ||
+		room.MaxExtensionMinutes != 120 || room.AutoDelete {
+		t.Errorf("Expected room with the series' settings, got %+v", room)
+	}
+
+	// 2回目の呼び出しでは同じルームを返す
+	var again model.Room
+	api.do(t, http.MethodPost, seriesPath+"/occurrences", host.ID, map[string]interface{}{"startsAt": first.StartsAt}, &again)
+	if again.ID != room.ID {
+		t.Errorf("Expected the same room %s, got %s", room.ID, again.ID)
+	}
+
+	// 共同ホストは待機室を経由せずに共同ホストとして参加する
+	var joined JoinResponse
+	api.do(t, http.MethodPost, "/api/v1/rooms/"+room.ID.String()+"/join", coHost.ID, nil, &joined)
+	if joined.Status != "joined" {
+		t.Fatalf("Expected co-host to join directly, got %q", joined.Status)
+	}
+	stored, _ := api.rooms.GetByID(context.Background(), room.ID)
+	if stored.RoleOf(coHost.ID) != model.RoleCoHost {
+		t.Errorf("Expected co-host role, got %q", stored.RoleOf(coHost.ID))
+	}
+
+	api.do(t, http.MethodGet, seriesPath+"/occurrences?count=1", uuid.Nil, nil, &list)
+	if len(list.Occurrences) != 1 || list.Occurrences[0].RoomID == nil || *list.Occurrences[0].RoomID != room.ID {
+		t.Errorf("Expected occurrence to reference room %s, got %+v", room.ID, list.Occurrences)
+	}
+}
+
+func TestSeriesHandler_CancelAndDelete(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	coHost := api.createUser(t, "Co-host")
+	guest := api.createUser(t, "Guest")
+
+	var series model.Series
+	api.do(t, http.MethodPost, "/api/v1/series", host.ID, map[string]interface{}{
+		"name": "Weekly", "coHostIds": []uuid.UUID{coHost.ID}, "startsAt": time.Now().Add(24 * time.Hour),
+		"durationMinutes": 60, "rrule": "FREQ=WEEKLY;COUNT=4", "timeZone": "Asia/Tokyo",
+	}, &series)
+	seriesPath := "/api/v1/series/" + series.ID.String()
+
+	var list OccurrencesResponse
+	api.do(t, http.MethodGet, seriesPath+"/occurrences", uuid.Nil, nil, &list)
+	if len(list.Occurrences) != 4 {
+		t.Fatalf("Expected 4 occurrences, got %d", len(list.Occurrences))
+	}
+	first := map[string]interface{}{"startsAt": list.Occurrences[0].StartsAt}
+
+	var room model.Room
+	api.do(t, http.MethodPost, seriesPath+"/occurrences", guest.ID, first, &room)
+
+	rec := api.do(t, http.MethodPost, seriesPath+"/occurrences/cancel", guest.ID, first, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+
+	rec = api.do(t, http.MethodPost, seriesPath+"/occurrences/cancel", coHost.ID, first, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, err := api.rooms.GetByID(context.Background(), room.ID); err == nil {
+		t.Error("Expected the cancelled occurrence's room to be deleted")
+	}
+
+	api.do(t, http.MethodGet, seriesPath+"/occurrences", uuid.Nil, nil, &list)
+	if len(list.Occurrences) != 3 {
+		t.Errorf("Expected 3 occurrences after cancelling, got %d", len(list.Occurrences))
+	}
+
+	rec = api.do(t, http.MethodPost, seriesPath+"/occurrences", guest.ID, first, nil)
+	expectError(t, rec, http.StatusNotFound, "occurrence_not_found")
+
+	rec = api.do(t, http.MethodDelete, seriesPath, coHost.ID, nil, nil)
+	expectError(t, rec, http.StatusForbidden, "permission_denied")
+	rec = api.do(t, http.MethodDelete, seriesPath, host.ID, nil, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = api.do(t, http.MethodGet, seriesPath, uuid.Nil, nil, nil)
+	expectError(t, rec, http.StatusNotFound, "series_not_found")
+}
+
+func TestSeriesHandler_InvalidInput(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+	start := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		body   map[string]interface{}
+		status int
+		code   string
+	}{
+		{"missing rrule", map[string]interface{}{"startsAt": start, "durationMinutes": 30}, http.StatusBadRequest, "invalid_input"},
+		{"invalid rrule", map[string]interface{}{"startsAt": start, "durationMinutes": 30, "rrule": "FREQ=SOMETIMES"}, http.StatusBadRequest, "invalid_recurrence"},
+		{"too frequent", map[string]interface{}{"startsAt": start, "durationMinutes": 30, "rrule": "FREQ=HOURLY"}, http.StatusBadRequest, "invalid_recurrence"},
+		{"unknown time zone", map[string]interface{}{"startsAt": start, "durationMinutes": 30, "rrule": "FREQ=DAILY", "timeZone": "Mars/Olympus"}, http.StatusBadRequest, "invalid_recurrence"},
+		{"too long", map[string]interface{}{"startsAt": start, "durationMinutes": 25 * 60, "rrule": "FREQ=DAILY"}, http.StatusBadRequest, "invalid_schedule"},
+		{"over capacity", map[string]interface{}{"startsAt": start, "durationMinutes": 30, "rrule": "FREQ=DAILY", "maxCapacity": 11}, http.StatusBadRequest, "invalid_input"},
+		{"over extension", map[string]interface{}{"startsAt": start, "durationMinutes": 30, "rrule": "FREQ=DAILY", "maxExtensionHours": 25}, http.StatusBadRequest, "invalid_room_options"},
+		{"unknown co-host", map[string]interface{}{"startsAt": start, "durationMinutes": 30, "rrule": "FREQ=DAILY", "coHostIds": []uuid.UUID{uuid.New()}}, http.StatusNotFound, "user_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := api.do(t, http.MethodPost, "/api/v1/series", host.ID, tt.body, nil)
+			expectError(t, rec, tt.status, tt.code)
+		})
+	}
+
+	rec := api.do(t, http.MethodGet, "/api/v1/series/"+uuid.NewString()+"/occurrences?count=0", uuid.Nil, nil, nil)
+	expectError(t, rec, http.StatusBadRequest, "invalid_input")
+}
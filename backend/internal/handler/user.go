@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/cline-meet/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler exposes usecase.User over HTTP
+type UserHandler struct {
+	user *usecase.User
+	room *usecase.Room
+}
+
+// NewUserHandler creates a new UserHandler
+func NewUserHandler(user *usecase.User, room *usecase.Room) *UserHandler {
+	return &UserHandler{user: user, room: room}
+}
+
+type createUserRequest struct {
+	GoogleID  string `json:"googleId"`
+	Email     string `json:"email" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+	AvatarURL string `json:"avatarUrl"`
+}
+
+type updateProfileRequest struct {
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatarUrl"`
+}
+
+// Create creates a user, returning the existing one for a known Google ID
+func (h *UserHandler) Create(c *gin.Context) {
+	var req createUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	user, err := h.user.CreateUser(c.Request.Context(), req.GoogleID, req.Email, req.Name, req.AvatarURL)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// Get returns a user
+func (h *UserHandler) Get(c *gin.Context) {
+	userID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	user, err := h.user.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateProfile updates the acting user's profile
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	userID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+	if userID != currentUser(c) {
+		abort(c, http.StatusForbidden, "forbidden", "cannot update another user's profile")
+		return
+	}
+
+	var req updateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.user.UpdateProfile(c.Request.Context(), userID, req.Name, req.AvatarURL); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	user, err := h.user.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ListRooms returns the active rooms hosted by a user
+func (h *UserHandler) ListRooms(c *gin.Context) {
+	userID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	rooms, err := h.room.GetUserRooms(c.Request.Context(), userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RoomsResponse{Rooms: rooms})
+}
+
+// ListMeetings returns the upcoming scheduled meetings hosted by a user, soonest first
+func (h *UserHandler) ListMeetings(c *gin.Context) {
+	userID, ok := uuidParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	rooms, err := h.room.GetUpcomingMeetings(c.Request.Context(), userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RoomsResponse{Rooms: rooms})
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+func TestUserHandler_CreateAndGet(t *testing.T) {
+	api := newTestAPI(t)
+	body := map[string]interface{}{"googleId": "google123", "email": "alice@example.com", "name": "Alice"}
+
+	var user model.User
+	rec := api.do(t, http.MethodPost, "/api/v1/users", uuid.Nil, body, &user)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if user.Email != "alice@example.com" {
+		t.Errorf("Expected Email alice@example.com, got %s", user.Email)
+	}
+
+	var got model.User
+	rec = api.do(t, http.MethodGet, "/api/v1/users/"+user.ID.String(), uuid.Nil, nil, &got)
+	if rec.Code != http.StatusOK || got.ID != user.ID {
+		t.Errorf("Expected user %s, got %d %+v", user.ID, rec.Code, got)
+	}
+
+	rec = api.do(t, http.MethodGet, "/api/v1/users/"+uuid.New().String(), uuid.Nil, nil, nil)
+	expectError(t, rec, http.StatusNotFound, "user_not_found")
+
+	rec = api.do(t, http.MethodPost, "/api/v1/users", uuid.Nil, map[string]interface{}{"email": "bob@example.com"}, nil)
+	expectError(t, rec, http.StatusBadRequest, "invalid_input")
+}
+
+func TestUserHandler_UpdateProfile(t *testing.T) {
+	api := newTestAPI(t)
+	user := api.createUser(t, "Alice")
+	other := api.createUser(t, "Bob")
+	path := "/api/v1/users/" + user.ID.String()
+
+	var updated model.User
+	rec := api.do(t, http.MethodPatch, path, user.ID, map[string]interface{}{"name": "Alice Smith"}, &updated)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if updated.Name != "Alice Smith" {
+		t.Errorf("Expected Name Alice Smith, got %s", updated.Name)
+	}
+
+	rec = api.do(t, http.MethodPatch, path, other.ID, map[string]interface{}{"name": "Hacked"}, nil)
+	expectError(t, rec, http.StatusForbidden, "forbidden")
+}
+
+func TestUserHandler_ListRooms(t *testing.T) {
+	api := newTestAPI(t)
+	host := api.createUser(t, "Host")
+
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "First"}, nil)
+	api.do(t, http.MethodPost, "/api/v1/rooms", host.ID, map[string]interface{}{"name": "Second"}, nil)
+
+	var resp RoomsResponse
+	rec := api.do(t, http.MethodGet, "/api/v1/users/"+host.ID.String()+"/rooms", uuid.Nil, nil, &resp)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if len(resp.Rooms) != 2 {
+		t.Errorf("Expected 2 rooms, got %d", len(resp.Rooms))
+	}
+}
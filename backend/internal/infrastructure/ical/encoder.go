@@ -0,0 +1,203 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+// ContentType is the media type of iCalendar objects (RFC 5545)
+const ContentType = "text/calendar; charset=utf-8"
+
+// ProdID identifies cline-meet as the product that created a calendar
+const ProdID = "-//cline-meet//cline-meet//EN"
+
+// maxLineOctets is the line length after which content lines are folded
+const maxLineOctets = 75
+
+const (
+	utcLayout   = "20060102T150405Z"
+	localLayout = "20060102T150405"
+	dateLayout  = "20060102"
+)
+
+// Encoder is an RFC 5545 implementation of service.CalendarEncoder
+// Events with a time zone reference the IANA name in TZID, defined by a VTIMEZONE component
+// built from the Go time zone database for the period the events cover
+type Encoder struct{}
+
+var _ service.CalendarEncoder = (*Encoder)(nil)
+
+// NewEncoder creates a new iCalendar Encoder
+func NewEncoder() *Encoder {
+	return &Encoder{}
+}
+
+// ContentType returns the iCalendar media type
+func (e *Encoder) ContentType() string {
+	return ContentType
+}
+
+// Encode writes the calendar as a VCALENDAR with one VEVENT per event
+func (e *Encoder) Encode(calendar *model.Calendar) ([]byte, error) {
+	w := &writer{}
+	w.line("BEGIN", nil, "VCALENDAR")
+	w.line("PRODID", nil, ProdID)
+	w.line("VERSION", nil, "2.0")
+	w.line("CALSCALE", nil, "GREGORIAN")
+	if calendar.Method != "" {
+		w.line("METHOD", nil, string(calendar.Method))
+	}
+
+	zones, err := timeZones(calendar.Events)
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range zones {
+		writeTimeZone(w, zone)
+	}
+
+	for _, event := range calendar.Events {
+		if err := writeEvent(w, event); err != nil {
+			return nil, err
+		}
+	}
+
+	w.line("END", nil, "VCALENDAR")
+	return []byte(w.String()), nil
+}
+
+func writeEvent(w *writer, event *model.CalendarEvent) error {
+	if event.UID == "" {
+		return fmt.Errorf("%w: event has no UID", service.ErrInvalidCalendar)
+	}
+	if !event.End.After(event.Start) {
+		return fmt.Errorf("%w: event %s must end after it starts", service.ErrInvalidCalendar, event.UID)
+	}
+
+	loc := time.UTC
+	if event.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(event.TimeZone); err != nil {
+			return fmt.Errorf("%w: unknown time zone %q", service.ErrInvalidCalendar, event.TimeZone)
+		}
+	}
+
+	w.line("BEGIN", nil, "VEVENT")
+	w.line("UID", nil, event.UID)
+	w.line("SEQUENCE", nil, strconv.Itoa(event.Sequence))
+	w.line("DTSTAMP", nil, event.Stamp.UTC().Format(utcLayout))
+	w.dateTime("DTSTART", event.Start, event.TimeZone, loc)
+	w.dateTime("DTEND", event.End, event.TimeZone, loc)
+	if event.RecurrenceID != nil {
+		w.dateTime("RECURRENCE-ID", *event.RecurrenceID, event.TimeZone, loc)
+	}
+	if event.RRule != "" {
+		w.line("RRULE", nil, event.RRule)
+	}
+	for _, exdate := range event.ExceptionDates {
+		w.dateTime("EXDATE", exdate, event.TimeZone, loc)
+	}
+	if event.Status != "" {
+		w.line("STATUS", nil, string(event.Status))
+	}
+	w.line("SUMMARY", nil, escapeText(event.Summary))
+	if event.Description != "" {
+		w.line("DESCRIPTION", nil, escapeText(event.Description))
+	}
+	if event.URL != "" {
+		w.line("URL", nil, event.URL)
+	}
+	if event.Organizer.Email != "" {
+		w.line("ORGANIZER", addressParams(event.Organizer), "mailto:"+event.Organizer.Email)
+	}
+	for _, attendee := range event.Attendees {
+		params := append(addressParams(attendee), param{"PARTSTAT", "NEEDS-ACTION"}, param{"RSVP", "TRUE"})
+		w.line("ATTENDEE", params, "mailto:"+attendee.Email)
+	}
+	w.line("END", nil, "VEVENT")
+	return nil
+}
+
+func addressParams(address model.CalendarAddress) []param {
+	var params []param
+	if address.Name != "" {
+		params = append(params, param{"CN", address.Name})
+	}
+	if address.Role != "" {
+		params = append(params, param{"ROLE", string(address.Role)})
+	}
+	return params
+}
+
+// param is a property parameter such as CN="Alice"
+type param struct {
+	name  string
+	value string
+}
+
+// writer builds folded CRLF-terminated content lines
+type writer struct {
+	strings.Builder
+}
+
+// dateTime writes a UTC DATE-TIME, or a local one with TZID when the event has a time zone
+func (w *writer) dateTime(name string, t time.Time, tzid string, loc *time.Location) {
+	if tzid == "" {
+		w.line(name, nil, t.UTC().Format(utcLayout))
+		return
+	}
+	w.line(name, []param{{"TZID", tzid}}, t.In(loc).Format(localLayout))
+}
+
+func (w *writer) line(name string, params []param, value string) {
+	var b strings.Builder
+	b.WriteString(name)
+	for _, p := range params {
+		b.WriteString(";" + p.name + "=" + paramValue(p.value))
+	}
+	b.WriteString(":" + value)
+	w.fold(b.String())
+}
+
+// fold splits a content line into lines of at most maxLineOctets, continued with a leading space
+// Lines are only split between UTF-8 characters
+func (w *writer) fold(line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// 継続行は先頭の空白も75オクテットに含める
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(line + "\r\n")
+}
+
+// escapeText escapes a TEXT value
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// paramValue quotes a parameter value containing separators
+// Double quotes cannot be escaped in parameter values and are dropped
+func paramValue(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '"' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, s)
+	if strings.ContainsAny(s, ":;,") {
+		return `"` + s + `"`
+	}
+	return s
+}
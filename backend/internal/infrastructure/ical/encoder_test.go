@@ -0,0 +1,305 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+func newTestEvent() *model.CalendarEvent {
+	start := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	return &model.CalendarEvent{
+		UID:         "room-1@" + model.CalendarUIDDomain,
+		Status:      model.EventStatusConfirmed,
+		Summary:     "Planning; Q4, roadmap",
+		Description: "Join: https://meet.example.com/rooms/room-1\nBring notes \\ ideas",
+		URL:         "https://meet.example.com/rooms/room-1",
+		Start:       start,
+		End:         start.Add(30 * time.Minute),
+		Organizer:   model.CalendarAddress{Email: "host@example.com", Name: "Host, The"},
+		Attendees: []model.CalendarAddress{
+			{Email: "alice@example.com", Name: "Alice", Role: model.AttendeeRoleRequired},
+			{Email: "bob@example.com", Role: model.AttendeeRoleOptional},
+		},
+		Stamp: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func roundTrip(t *testing.T, calendar *model.Calendar) (*model.Calendar, string) {
+	t.Helper()
+
+	data, err := NewEncoder().Encode(calendar)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v\n%s", err, data)
+	}
+	return parsed, string(data)
+}
+
+func assertEventEqual(t *testing.T, want, got *model.CalendarEvent) {
+	t.Helper()
+
+	if got.UID != want.UID || got.Sequence != want.Sequence || got.Status != want.Status {
+		t.Errorf("Expected UID %s sequence %d %s, got %s %d %s", want.UID, want.Sequence, want.Status, got.UID, got.Sequence, got.Status)
+	}
+	if got.Summary != want.Summary || got.Description != want.Description || got.URL != want.URL {
+		t.Errorf("Expected text %q %q %q, got %q %q %q", want.Summary, want.Description, want.URL, got.Summary, got.Description, got.URL)
+	}
+	if !got.Start.Equal(want.Start) || !got.End.Equal(want.End) || !got.Stamp.Equal(want.Stamp) {
+		t.Errorf("Expected %v-%v stamped %v, got %v-%v stamped %v", want.Start, want.End, want.Stamp, got.Start, got.End, got.Stamp)
+	}
+	if got.TimeZone != want.TimeZone || got.RRule != want.RRule {
+		t.Errorf("Expected %q %q, got %q %q", want.TimeZone, want.RRule, got.TimeZone, got.RRule)
+	}
+	if got.Organizer != want.Organizer {
+		t.Errorf("Expected organizer %+v, got %+v", want.Organizer, got.Organizer)
+	}
+	if len(got.Attendees) != len(want.Attendees) {
+		t.Fatalf("Expected attendees %+v, got %+v", want.Attendees, got.Attendees)
+	}
+	for i := range want.Attendees {
+		if got.Attendees[i] != want.Attendees[i] {
+			t.Errorf("Expected attendee %+v, got %+v", want.Attendees[i], got.Attendees[i])
+		}
+	}
+	if len(got.ExceptionDates) != len(want.ExceptionDates) {
+		t.Fatalf("Expected exception dates %v, got %v", want.ExceptionDates, got.ExceptionDates)
+	}
+	for i := range want.ExceptionDates {
+		if !got.ExceptionDates[i].Equal(want.ExceptionDates[i]) {
+			t.Errorf("Expected exception date %v, got %v", want.ExceptionDates[i], got.ExceptionDates[i])
+		}
+	}
+	if (got.RecurrenceID == nil) != (want.RecurrenceID == nil) ||
+		(want.RecurrenceID != nil && !got.RecurrenceID.Equal(*want.RecurrenceID)) {
+		t.Errorf("Expected RecurrenceID %v, got %v", want.RecurrenceID, got.RecurrenceID)
+	}
+}
+
+func TestEncoder_RoundTripRoom(t *testing.T) {
+	event := newTestEvent()
+	calendar := model.NewCalendar(model.CalendarMethodRequest, event)
+
+	parsed, data := roundTrip(t, calendar)
+	if parsed.Method != model.CalendarMethodRequest || len(parsed.Events) != 1 {
+		t.Fatalf("Expected 1 REQUEST event, got %s with %d events", parsed.Method, len(parsed.Events))
+	}
+	assertEventEqual(t, event, parsed.Events[0])
+
+	unfolded := strings.ReplaceAll(data, "\r\n ", "")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"VERSION:2.0\r\n",
+		"DTSTART:20261102T090000Z\r\n",
+		`SUMMARY:Planning\; Q4\, roadmap` + "\r\n",
+		`ORGANIZER;CN="Host, The":mailto:host@example.com` + "\r\n",
+		"ATTENDEE;CN=Alice;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:alice@example.com\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("Expected output to contain %q, got\n%s", want, data)
+		}
+	}
+}
+
+func TestEncoder_RoundTripSeries(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	event := newTestEvent()
+	event.Start = time.Date(2026, 11, 2, 9, 30, 0, 0, tokyo)
+	event.End = event.Start.Add(15 * time.Minute)
+	event.TimeZone = "Asia/Tokyo"
+	event.RRule = "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+	event.ExceptionDates = []time.Time{event.Start.AddDate(0, 0, 1), event.Start.AddDate(0, 0, 3)}
+	event.Sequence = 2
+
+	parsed, data := roundTrip(t, model.NewCalendar(model.CalendarMethodPublish, event))
+	assertEventEqual(t, event, parsed.Events[0])
+
+	for _, want := range []string{
+		"DTSTART;TZID=Asia/Tokyo:20261102T093000\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR\r\n",
+		"EXDATE;TZID=Asia/Tokyo:20261103T093000\r\n",
+		"SEQUENCE:2\r\n",
+		// 夏時間のないタイムゾーンは1つの定義だけで表せる
+		"BEGIN:VTIMEZONE\r\nTZID:Asia/Tokyo\r\nBEGIN:STANDARD\r\nDTSTART:20261102T093000\r\n" +
+			"TZOFFSETFROM:+0900\r\nTZOFFSETTO:+0900\r\nTZNAME:JST\r\nEND:STANDARD\r\nEND:VTIMEZONE\r\n",
+	} {
+		if !strings.Contains(data, want) {
+			t.Errorf("Expected output to contain %q, got\n%s", want, data)
+		}
+	}
+	if strings.Index(data, "BEGIN:VTIMEZONE") > strings.Index(data, "BEGIN:VEVENT") {
+		t.Errorf("Expected the VTIMEZONE before the events, got\n%s", data)
+	}
+}
+
+func TestEncoder_TimeZoneTransitions(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	event := newTestEvent()
+	event.Start = time.Date(2026, 11, 2, 9, 0, 0, 0, newYork)
+	event.End = event.Start.Add(time.Hour)
+	event.TimeZone = "America/New_York"
+	event.RRule = "FREQ=WEEKLY"
+	second := newTestEvent()
+	second.UID = "room-2@" + model.CalendarUIDDomain
+	second.Start = event.Start.AddDate(0, 1, 0)
+	second.End = second.Start.Add(time.Hour)
+	second.TimeZone = "America/New_York"
+
+	parsed, data := roundTrip(t, model.NewCalendar(model.CalendarMethodPublish, event, second))
+	assertEventEqual(t, event, parsed.Events[0])
+
+	for _, want := range []string{
+		"BEGIN:STANDARD\r\nDTSTART:20261102T090000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0500\r\nTZNAME:EST\r\nEND:STANDARD\r\n",
+		// 切り替えの時刻は切り替え前の時差で書く
+		"BEGIN:DAYLIGHT\r\nDTSTART:20270314T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\nTZNAME:EDT\r\nEND:DAYLIGHT\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20271107T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\nTZNAME:EST\r\nEND:STANDARD\r\n",
+	} {
+		if !strings.Contains(data, want) {
+			t.Errorf("Expected output to contain %q, got\n%s", want, data)
+		}
+	}
+
+	// 同じタイムゾーンは1回だけ定義し、繰り返しの予定は先の年まで含める
+	if n := strings.Count(data, "BEGIN:VTIMEZONE"); n != 1 {
+		t.Errorf("Expected 1 VTIMEZONE, got %d", n)
+	}
+	if !strings.Contains(data, "DTSTART:20361102T020000\r\n") {
+		t.Errorf("Expected transitions until %s, got\n%s", event.Start.AddDate(recurringYears, 0, 0).Format(time.DateOnly), data)
+	}
+}
+
+func TestEncoder_RoundTripCancelledOccurrence(t *testing.T) {
+	event := newTestEvent()
+	recurrenceID := event.Start
+	event.RecurrenceID = &recurrenceID
+	event.Attendees = []model.CalendarAddress{}
+	event.Cancel()
+
+	parsed, data := roundTrip(t, model.NewCalendar(model.CalendarMethodCancel, event))
+	if parsed.Method != model.CalendarMethodCancel {
+		t.Errorf("Expected CANCEL, got %s", parsed.Method)
+	}
+	assertEventEqual(t, event, parsed.Events[0])
+	if !strings.Contains(data, "STATUS:CANCELLED\r\n") || !strings.Contains(data, "SEQUENCE:1\r\n") {
+		t.Errorf("Expected cancelled event at sequence 1, got\n%s", data)
+	}
+}
+
+func TestEncoder_FoldsLongLines(t *testing.T) {
+	event := newTestEvent()
+	event.Summary = strings.Repeat("週次の定例会議 ", 20)
+	event.Description = strings.Repeat("a", 300)
+
+	parsed, data := roundTrip(t, model.NewCalendar(model.CalendarMethodPublish, event))
+	for _, line := range strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Expected lines of at most %d octets, got %d: %q", maxLineOctets, len(line), line)
+		}
+	}
+	assertEventEqual(t, event, parsed.Events[0])
+}
+
+func TestEncoder_InvalidEvent(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*model.CalendarEvent)
+	}{
+		{"missing UID", func(e *model.CalendarEvent) { e.UID = "" }},
+		{"ends before start", func(e *model.CalendarEvent) { e.End = e.Start.Add(-time.Minute) }},
+		{"unknown time zone", func(e *model.CalendarEvent) { e.TimeZone = "Mars/Olympus" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := newTestEvent()
+			tt.modify(event)
+			if _, err := NewEncoder().Encode(model.NewCalendar(model.CalendarMethodPublish, event)); !errors.Is(err, service.ErrInvalidCalendar) {
+				t.Errorf("Expected ErrInvalidCalendar, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParse_ClientCalendar(t *testing.T) {
+	// 他のクライアントが書き出した形式（LF改行、タブでの折り返し、VTIMEZONE、VALARM、複数のEXDATE）
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Example//Calendar//EN",
+		"BEGIN:VTIMEZONE",
+		"TZID:Asia/Tokyo",
+		"BEGIN:STANDARD",
+		"DTSTART:19700101T000000",
+		"TZOFFSETFROM:+0900",
+		"TZOFFSETTO:+0900",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:abc@example.com",
+		"DTSTAMP:20261016T120000Z",
+		"DTSTART;TZID=Asia/Tokyo:20261102T093000",
+		"DTEND;TZID=Asia/Tokyo:20261102T094500",
+		"RRULE:FREQ=DAILY",
+		"EXDATE;TZID=Asia/Tokyo:20261103T093000,20261104T093000",
+		"SUMMARY:Daily",
+		"\tstandup",
+		"organizer;cn=Host:MAILTO:host@example.com",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:Reminder",
+		"TRIGGER:-PT10M",
+		"END:VALARM",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\n")
+
+	calendar, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(calendar.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(calendar.Events))
+	}
+	event := calendar.Events[0]
+	if event.Summary != "Dailystandup" || event.Description != "" {
+		t.Errorf("Expected unfolded summary and no alarm description, got %q %q", event.Summary, event.Description)
+	}
+	if event.Start.UTC() != time.Date(2026, 11, 2, 0, 30, 0, 0, time.UTC) || event.TimeZone != "Asia/Tokyo" {
+		t.Errorf("Expected 00:30 UTC start in Asia/Tokyo, got %v %q", event.Start.UTC(), event.TimeZone)
+	}
+	if len(event.ExceptionDates) != 2 {
+		t.Errorf("Expected 2 exception dates, got %v", event.ExceptionDates)
+	}
+	if event.Organizer.Email != "host@example.com" || event.Organizer.Name != "Host" {
+		t.Errorf("Expected organizer host@example.com, got %+v", event.Organizer)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not a calendar":     "BEGIN:VEVENT\r\nEND:VEVENT\r\n",
+		"unterminated":       "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\n",
+		"mismatched end":     "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\nEND:VCALENDAR\r\n",
+		"missing uid":        "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:a\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"malformed line":     "BEGIN:VCALENDAR\r\nno colon\r\nEND:VCALENDAR\r\n",
+		"unterminated quote": "BEGIN:VCALENDAR\r\nATTENDEE;CN=\"Alice:mailto:a@example.com\r\nEND:VCALENDAR\r\n",
+		"invalid date":       "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\nDTSTART:tomorrow\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"invalid sequence":   "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\nSEQUENCE:x\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"unknown time zone":  "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\nDTSTART;TZID=Mars/Olympus:20261102T093000\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(data)); !errors.Is(err, service.ErrInvalidCalendar) {
+				t.Errorf("Expected ErrInvalidCalendar, got %v", err)
+			}
+		})
+	}
+}
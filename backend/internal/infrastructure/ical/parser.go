@@ -0,0 +1,249 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+// property is an unfolded content line
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse reads a VCALENDAR and its VEVENTs
+// Other components such as VTIMEZONE and VALARM and unknown properties are skipped
+func Parse(data []byte) (*model.Calendar, error) {
+	props, err := parseLines(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if len(props) == 0 || props[0].name != "BEGIN" || !strings.EqualFold(props[0].value, "VCALENDAR") {
+		return nil, fmt.Errorf("%w: missing BEGIN:VCALENDAR", service.ErrInvalidCalendar)
+	}
+
+	calendar := &model.Calendar{Events: []*model.CalendarEvent{}}
+	var event *model.CalendarEvent
+	var components []string
+
+	for _, p := range props {
+		switch p.name {
+		case "BEGIN":
+			component := strings.ToUpper(p.value)
+			components = append(components, component)
+			if component == "VEVENT" && len(components) == 2 {
+				event = &model.CalendarEvent{Attendees: []model.CalendarAddress{}}
+			}
+			continue
+		case "END":
+			component := strings.ToUpper(p.value)
+			if len(components) == 0 || components[len(components)-1] != component {
+				return nil, fmt.Errorf("%w: unexpected END:%s", service.ErrInvalidCalendar, p.value)
+			}
+			components = components[:len(components)-1]
+			if component == "VEVENT" && event != nil && len(components) == 1 {
+				if event.UID == "" {
+					return nil, fmt.Errorf("%w: event has no UID", service.ErrInvalidCalendar)
+				}
+				calendar.Events = append(calendar.Events, event)
+				event = nil
+			}
+			continue
+		}
+
+		switch {
+		case len(components) == 1 && p.name == "METHOD":
+			calendar.Method = model.CalendarMethod(strings.ToUpper(p.value))
+		case len(components) == 2 && event != nil:
+			if err := setEventProperty(event, p); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(components) != 0 {
+		return nil, fmt.Errorf("%w: missing END:%s", service.ErrInvalidCalendar, components[len(components)-1])
+	}
+	return calendar, nil
+}
+
+func setEventProperty(event *model.CalendarEvent, p property) error {
+	var err error
+	switch p.name {
+	case "UID":
+		event.UID = p.value
+	case "SEQUENCE":
+		if event.Sequence, err = strconv.Atoi(p.value); err != nil {
+			return fmt.Errorf("%w: invalid SEQUENCE %q", service.ErrInvalidCalendar, p.value)
+		}
+	case "DTSTAMP":
+		event.Stamp, err = parseDateTime(p)
+	case "DTSTART":
+		event.Start, err = parseDateTime(p)
+		event.TimeZone = p.params["TZID"]
+	case "DTEND":
+		event.End, err = parseDateTime(p)
+	case "RECURRENCE-ID":
+		var recurrenceID time.Time
+		recurrenceID, err = parseDateTime(p)
+		event.RecurrenceID = &recurrenceID
+	case "RRULE":
+		event.RRule = p.value
+	case "EXDATE":
+		// 1行に複数の日時をカンマ区切りで書ける
+		for _, value := range strings.Split(p.value, ",") {
+			var exdate time.Time
+			if exdate, err = parseDateTime(property{name: p.name, params: p.params, value: value}); err != nil {
+				break
+			}
+			event.ExceptionDates = append(event.ExceptionDates, exdate)
+		}
+	case "STATUS":
+		event.Status = model.EventStatus(strings.ToUpper(p.value))
+	case "SUMMARY":
+		event.Summary = unescapeText(p.value)
+	case "DESCRIPTION":
+		event.Description = unescapeText(p.value)
+	case "URL":
+		event.URL = p.value
+	case "ORGANIZER":
+		event.Organizer = parseAddress(p)
+	case "ATTENDEE":
+		event.Attendees = append(event.Attendees, parseAddress(p))
+	}
+	return err
+}
+
+// parseDateTime reads a UTC, TZID-local or floating DATE-TIME, or a DATE
+// Floating times have no time zone and are read as UTC
+func parseDateTime(p property) (time.Time, error) {
+	loc := time.UTC
+	if tzid := p.params["TZID"]; tzid != "" {
+		var err error
+		if loc, err = time.LoadLocation(tzid); err != nil {
+			return time.Time{}, fmt.Errorf("%w: unknown time zone %q", service.ErrInvalidCalendar, tzid)
+		}
+	}
+
+	var t time.Time
+	var err error
+	switch {
+	case strings.EqualFold(p.params["VALUE"], "DATE"):
+		t, err = time.ParseInLocation(dateLayout, p.value, loc)
+	case strings.HasSuffix(p.value, "Z"):
+		t, err = time.Parse(utcLayout, p.value)
+	default:
+		t, err = time.ParseInLocation(localLayout, p.value, loc)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid %s %q", service.ErrInvalidCalendar, p.name, p.value)
+	}
+	return t, nil
+}
+
+func parseAddress(p property) model.CalendarAddress {
+	email := p.value
+	if len(email) >= len("mailto:") && strings.EqualFold(email[:len("mailto:")], "mailto:") {
+		email = email[len("mailto:"):]
+	}
+	return model.CalendarAddress{
+		Email: email,
+		Name:  p.params["CN"],
+		Role:  model.AttendeeRole(strings.ToUpper(p.params["ROLE"])),
+	}
+}
+
+// parseLines unfolds the content lines and splits them into properties
+func parseLines(data string) ([]property, error) {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\n ", "")
+	data = strings.ReplaceAll(data, "\n\t", "")
+
+	var props []property
+	for _, line := range strings.Split(data, "\n") {
+		if line == "" {
+			continue
+		}
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, p)
+	}
+	return props, nil
+}
+
+// parseLine splits name;param=value;...:value, honouring quoted parameter values
+func parseLine(line string) (property, error) {
+	p := property{params: map[string]string{}}
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return p, fmt.Errorf("%w: malformed line %q", service.ErrInvalidCalendar, line)
+	}
+	p.name = strings.ToUpper(line[:i])
+
+	for line[i] == ';' {
+		rest := line[i+1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return p, fmt.Errorf("%w: malformed parameter in %q", service.ErrInvalidCalendar, line)
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		var end int
+		if strings.HasPrefix(rest, `"`) {
+			closing := strings.IndexByte(rest[1:], '"')
+			if closing < 0 {
+				return p, fmt.Errorf("%w: unterminated quote in %q", service.ErrInvalidCalendar, line)
+			}
+			value = rest[1 : closing+1]
+			end = closing + 2
+		} else {
+			end = strings.IndexAny(rest, ";:")
+			if end < 0 {
+				end = len(rest)
+			}
+			value = rest[:end]
+		}
+		p.params[name] = value
+
+		i = len(line) - len(rest) + end
+		if i >= len(line) {
+			return p, fmt.Errorf("%w: missing value in %q", service.ErrInvalidCalendar, line)
+		}
+	}
+
+	if line[i] != ':' {
+		return p, fmt.Errorf("%w: malformed line %q", service.ErrInvalidCalendar, line)
+	}
+	p.value = line[i+1:]
+	return p, nil
+}
+
+// unescapeText reverses escapeText
+func unescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,138 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+// recurringYears is how many years past its start a recurring event's time zone is described
+// Clients keep applying the last observance to occurrences after it
+const recurringYears = 10
+
+// transitionStep is the interval at which UTC offset changes are looked for
+// Time zones don't change their offset twice within it
+const transitionStep = 24 * time.Hour
+
+// timeZone is a time zone referenced by TZID and the period its VTIMEZONE has to cover
+type timeZone struct {
+	tzid     string
+	location *time.Location
+	from     time.Time
+	to       time.Time
+}
+
+// timeZones collects the time zones of the events in order of first use
+func timeZones(events []*model.CalendarEvent) ([]*timeZone, error) {
+	var zones []*timeZone
+	byID := make(map[string]*timeZone)
+	for _, event := range events {
+		if event.TimeZone == "" {
+			continue
+		}
+
+		zone, ok := byID[event.TimeZone]
+		if !ok {
+			location, err := time.LoadLocation(event.TimeZone)
+			if err != nil {
+				return nil, fmt.Errorf("%w: unknown time zone %q", service.ErrInvalidCalendar, event.TimeZone)
+			}
+			zone = &timeZone{tzid: event.TimeZone, location: location, from: event.Start, to: event.End}
+			byID[event.TimeZone] = zone
+			zones = append(zones, zone)
+		}
+
+		dates := append([]time.Time{event.Start, event.End}, event.ExceptionDates...)
+		if event.RecurrenceID != nil {
+			dates = append(dates, *event.RecurrenceID)
+		}
+		if event.RRule != "" {
+			dates = append(dates, event.Start.AddDate(recurringYears, 0, 0))
+		}
+		for _, t := range dates {
+			if t.Before(zone.from) {
+				zone.from = t
+			}
+			if t.After(zone.to) {
+				zone.to = t
+			}
+		}
+	}
+	return zones, nil
+}
+
+// writeTimeZone writes a VTIMEZONE with the observance in effect at zone.from and one observance
+// per UTC offset change until zone.to
+// Listing the changes instead of yearly rules also describes zones whose rules changed over the period
+func writeTimeZone(w *writer, zone *timeZone) {
+	w.line("BEGIN", nil, "VTIMEZONE")
+	w.line("TZID", nil, zone.tzid)
+
+	at := zone.from.Truncate(time.Second)
+	name, offset := at.In(zone.location).Zone()
+	writeObservance(w, at.In(zone.location), offset)
+
+	for at.Before(zone.to) {
+		next := at.Add(transitionStep)
+		nextName, nextOffset := next.In(zone.location).Zone()
+		if nextName == name && nextOffset == offset {
+			at = next
+			continue
+		}
+
+		// 変更の時刻を秒単位で求め、そこから探索を続ける
+		at = findTransition(zone.location, at, next)
+		writeObservance(w, at.In(zone.location), offset)
+		name, offset = at.In(zone.location).Zone()
+	}
+
+	w.line("END", nil, "VTIMEZONE")
+}
+
+// writeObservance writes the STANDARD or DAYLIGHT observance starting at t
+// Its onset is written in the local time of the previous offset, as RFC 5545 requires
+func writeObservance(w *writer, t time.Time, offsetFrom int) {
+	name, offset := t.Zone()
+	component := "STANDARD"
+	if t.IsDST() {
+		component = "DAYLIGHT"
+	}
+
+	w.line("BEGIN", nil, component)
+	w.line("DTSTART", nil, t.UTC().Add(time.Duration(offsetFrom)*time.Second).Format(localLayout))
+	w.line("TZOFFSETFROM", nil, utcOffset(offsetFrom))
+	w.line("TZOFFSETTO", nil, utcOffset(offset))
+	w.line("TZNAME", nil, escapeText(name))
+	w.line("END", nil, component)
+}
+
+// findTransition returns the first second after before at which loc's zone differs from before's
+// after must already be in the new zone
+func findTransition(loc *time.Location, before, after time.Time) time.Time {
+	name, offset := before.In(loc).Zone()
+	for after.Sub(before) > time.Second {
+		mid := before.Add((after.Sub(before) / 2).Truncate(time.Second))
+		if midName, midOffset := mid.In(loc).Zone(); midName == name && midOffset == offset {
+			before = mid
+		} else {
+			after = mid
+		}
+	}
+	return after
+}
+
+// utcOffset formats a UTC offset in seconds as a UTC-OFFSET value such as +0900
+func utcOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	value := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		value += fmt.Sprintf("%02d", seconds%60)
+	}
+	return value
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInviteNotFound is returned when an invite does not exist in the store
+	ErrInviteNotFound = fmt.Errorf("invite %w", repository.ErrNotFound)
+
+	// ErrInviteAlreadyExists is returned when creating an invite whose ID is already stored
+	ErrInviteAlreadyExists = errors.New("invite already exists")
+
+	// ErrInviteUnavailable is returned when incrementing the uses of a revoked or used up invite
+	ErrInviteUnavailable = fmt.Errorf("invite %w", repository.ErrConflict)
+)
+
+// Invite is an in-memory implementation of repository.Invite
+type Invite struct {
+	invites map[uuid.UUID]*model.Invite
+	mutex   sync.RWMutex
+}
+
+var _ repository.Invite = (*Invite)(nil)
+
+// NewInvite creates a new in-memory Invite repository
+func NewInvite() *Invite {
+	return &Invite{
+		invites: make(map[uuid.UUID]*model.Invite),
+	}
+}
+
+// Create creates a new invite
+func (r *Invite) Create(ctx context.Context, invite *model.Invite) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.invites[invite.ID]; ok {
+		return ErrInviteAlreadyExists
+	}
+
+	r.invites[invite.ID] = copyInvite(invite)
+	return nil
+}
+
+// GetByID retrieves an invite by ID
+func (r *Invite) GetByID(ctx context.Context, id uuid.UUID) (*model.Invite, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	invite, ok := r.invites[id]
+	if !ok {
+		return nil, ErrInviteNotFound
+	}
+
+	return copyInvite(invite), nil
+}
+
+// GetByRoomID retrieves the invites of a room, oldest first
+func (r *Invite) GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*model.Invite, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	invites := []*model.Invite{}
+	for _, invite := range r.invites {
+		if invite.RoomID == roomID {
+			invites = append(invites, copyInvite(invite))
+		}
+	}
+
+	sort.Slice(invites, func(i, j int) bool {
+		return invites[i].CreatedAt.Before(invites[j].CreatedAt)
+	})
+
+	return invites, nil
+}
+
+// Update updates an existing invite, keeping the stored uses
+func (r *Invite) Update(ctx context.Context, invite *model.Invite) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, ok := r.invites[invite.ID]
+	if !ok {
+		return ErrInviteNotFound
+	}
+
+	updated := copyInvite(invite)
+	updated.Uses = stored.Uses
+	r.invites[invite.ID] = updated
+	return nil
+}
+
+// IncrementUses records a use of an invite that is not revoked and has uses left
+func (r *Invite) IncrementUses(ctx context.Context, id uuid.UUID) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, ok := r.invites[id]
+	if !ok {
+		return ErrInviteNotFound
+	}
+	if stored.IsRevoked() || (stored.MaxUses > 0 && stored.Uses >= stored.MaxUses) {
+		return ErrInviteUnavailable
+	}
+
+	stored.Uses++
+	// 他の利用を巻き戻さないよう、取り消しでは1回分だけ戻す
+	recordUndo(ctx, func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		if invite, ok := r.invites[id]; ok {
+			invite.Uses--
+		}
+	})
+	return nil
+}
+
+// copyInvite returns a deep copy of an invite
+func copyInvite(invite *model.Invite) *model.Invite {
+	copied := *invite
+	if invite.RevokedAt != nil {
+		revokedAt := *invite.RevokedAt
+		copied.RevokedAt = &revokedAt
+	}
+	return &copied
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+func TestInvite_CreateAndGetByID(t *testing.T) {
+	repo := NewInvite()
+	ctx := context.Background()
+	invite := model.NewInvite(uuid.New(), uuid.New(), "alice@example.com", model.RoleAttendee, time.Hour, 1)
+
+	if err := repo.Create(ctx, invite); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, invite.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Email != invite.Email || got.MaxUses != 1 {
+		t.Errorf("Expected %+v, got %+v", invite, got)
+	}
+
+	// 重複作成
+	if err := repo.Create(ctx, invite); !errors.Is(err, ErrInviteAlreadyExists) {
+		t.Errorf("Expected ErrInviteAlreadyExists, got %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+}
+
+func TestInvite_Update(t *testing.T) {
+	repo := NewInvite()
+	ctx := context.Background()
+	invite := model.NewInvite(uuid.New(), uuid.New(), "", model.RoleAttendee, time.Hour, 0)
+	repo.Create(ctx, invite)
+
+	got, _ := repo.GetByID(ctx, invite.ID)
+	got.Revoke()
+
+	// 更新前はストアに反映されない
+	stored, _ := repo.GetByID(ctx, invite.ID)
+	if stored.IsRevoked() {
+		t.Error("Expected stored invite not to change before Update")
+	}
+
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	stored, _ = repo.GetByID(ctx, invite.ID)
+	if !stored.IsRevoked() {
+		t.Error("Expected invite to be revoked after Update")
+	}
+
+	missing := model.NewInvite(uuid.New(), uuid.New(), "", model.RoleAttendee, time.Hour, 0)
+	if err := repo.Update(ctx, missing); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("Expected ErrInviteNotFound, got %v", err)
+	}
+}
+
+func TestInvite_IncrementUses(t *testing.T) {
+	repo := NewInvite()
+	ctx := context.Background()
+	invite := model.NewInvite(uuid.New(), uuid.New(), "", model.RoleAttendee, time.Hour, 1)
+	repo.Create(ctx, invite)
+
+	if err := repo.IncrementUses(ctx, invite.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.IncrementUses(ctx, invite.ID); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("Expected repository.ErrConflict for a used up invite, got %v", err)
+	}
+
+	// 古いコピーで更新しても利用回数は戻らない
+	invite.Revoke()
+	repo.Update(ctx, invite)
+	stored, _ := repo.GetByID(ctx, invite.ID)
+	if stored.Uses != 1 || !stored.IsRevoked() {
+		t.Errorf("Expected a revoked invite with 1 use, got %+v", stored)
+	}
+
+	if err := repo.IncrementUses(ctx, uuid.New()); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("Expected ErrInviteNotFound, got %v", err)
+	}
+}
+
+func TestInvite_IncrementUses_RevokedInvite(t *testing.T) {
+	repo := NewInvite()
+	ctx := context.Background()
+	invite := model.NewInvite(uuid.New(), uuid.New(), "", model.RoleAttendee, time.Hour, 0)
+	invite.Revoke()
+	repo.Create(ctx, invite)
+
+	if err := repo.IncrementUses(ctx, invite.ID); !errors.Is(err, ErrInviteUnavailable) {
+		t.Errorf("Expected ErrInviteUnavailable, got %v", err)
+	}
+}
+
+func TestInvite_IncrementUses_Rollback(t *testing.T) {
+	repo := NewInvite()
+	ctx := context.Background()
+	invite := model.NewInvite(uuid.New(), uuid.New(), "", model.RoleAttendee, time.Hour, 0)
+	repo.Create(ctx, invite)
+
+	errJoin := errors.New("join failed")
+	err := NewTransactor().WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := repo.IncrementUses(ctx, invite.ID); err != nil {
+			return err
+		}
+		// トランザクションの外での利用は取り消されない
+		if err := repo.IncrementUses(context.Background(), invite.ID); err != nil {
+			return err
+		}
+		return errJoin
+	})
+	if !errors.Is(err, errJoin) {
+		t.Fatalf("Expected errJoin, got %v", err)
+	}
+
+	stored, _ := repo.GetByID(ctx, invite.ID)
+	if stored.Uses != 1 {
+		t.Errorf("Expected only the use outside the transaction to remain, got %d", stored.Uses)
+	}
+}
+
+func TestInvite_GetByRoomID(t *testing.T) {
+	repo := NewInvite()
+	ctx := context.Background()
+	roomID := uuid.New()
+
+	first := model.NewInvite(roomID, uuid.New(), "", model.RoleAttendee, time.Hour, 0)
+	second := model.NewInvite(roomID, uuid.New(), "", model.RoleViewer, time.Hour, 0)
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	other := model.NewInvite(uuid.New(), uuid.New(), "", model.RoleAttendee, time.Hour, 0)
+	repo.Create(ctx, second)
+	repo.Create(ctx, first)
+	repo.Create(ctx, other)
+
+	invites, err := repo.GetByRoomID(ctx, roomID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(invites) != 2 {
+		t.Fatalf("Expected 2 invites, got %d", len(invites))
+	}
+	if invites[0].ID != first.ID || invites[1].ID != second.ID {
+		t.Error("Expected invites ordered by creation time")
+	}
+}
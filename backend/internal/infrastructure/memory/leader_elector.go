@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+// LeaderElector is an in-memory implementation of service.LeaderElector
+// Without a shared store there is no one to compete with, so this pod is always the leader
+type LeaderElector struct{}
+
+var _ service.LeaderElector = (*LeaderElector)(nil)
+
+// NewLeaderElector creates a new in-memory LeaderElector
+func NewLeaderElector() *LeaderElector {
+	return &LeaderElector{}
+}
+
+// Acquire always succeeds
+func (e *LeaderElector) Acquire(ctx context.Context, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+// Release does nothing
+func (e *LeaderElector) Release(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MaxChatHistory is the number of chat messages kept per room (README: 最新100件)
+const MaxChatHistory = 100
+
+// Message is an in-memory implementation of repository.Message
+// Only the most recent MaxChatHistory messages are kept per room
+type Message struct {
+	history map[uuid.UUID][]*model.Message
+	mutex   sync.RWMutex
+}
+
+var _ repository.Message = (*Message)(nil)
+
+// NewMessage creates a new in-memory Message repository
+func NewMessage() *Message {
+	return &Message{
+		history: make(map[uuid.UUID][]*model.Message),
+	}
+}
+
+// SaveChatMessage appends a chat message to the room's history
+func (m *Message) SaveChatMessage(ctx context.Context, message *model.Message) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	copied := *message
+	messages := append(m.history[message.RoomID], &copied)
+	if len(messages) > MaxChatHistory {
+		messages = messages[len(messages)-MaxChatHistory:]
+	}
+	m.history[message.RoomID] = messages
+
+	return nil
+}
+
+// GetChatHistory returns the most recent messages (up to limit) in chronological order
+// A non-positive limit returns the whole stored history
+func (m *Message) GetChatHistory(ctx context.Context, roomID uuid.UUID, limit int) ([]*model.Message, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	messages := m.history[roomID]
+	if limit > 0 && len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+
+	result := make([]*model.Message, len(messages))
+	for i, message := range messages {
+		copied := *message
+		result[i] = &copied
+	}
+
+	return result, nil
+}
+
+// DeleteChatHistory deletes all chat history for a room
+func (m *Message) DeleteChatHistory(ctx context.Context, roomID uuid.UUID) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.history, roomID)
+	return nil
+}
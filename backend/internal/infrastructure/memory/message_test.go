@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+func TestMessage_GetChatHistory(t *testing.T) {
+	repo := NewMessage()
+	ctx := context.Background()
+	roomID := uuid.New()
+	senderID := uuid.New()
+
+	for i := 0; i < 5; i++ {
+		repo.SaveChatMessage(ctx, model.NewChatMessage(senderID, roomID, fmt.Sprintf("message %d", i), "Alice"))
+	}
+	repo.SaveChatMessage(ctx, model.NewChatMessage(senderID, uuid.New(), "other room", "Alice"))
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{"limit smaller than history", 3, []string{"message 2", "message 3", "message 4"}},
+		{"limit larger than history", 10, []string{"message 0", "message 1", "message 2", "message 3", "message 4"}},
+		{"non-positive limit", 0, []string{"message 0", "message 1", "message 2", "message 3", "message 4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, err := repo.GetChatHistory(ctx, roomID, tt.limit)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(messages) != len(tt.want) {
+				t.Fatalf("Expected %d messages, got %d", len(tt.want), len(messages))
+			}
+			for i, message := range messages {
+				payload := message.Payload.(model.ChatPayload)
+				if payload.Message != tt.want[i] {
+					t.Errorf("Expected message %d to be %s, got %s", i, tt.want[i], payload.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestMessage_CapsHistory(t *testing.T) {
+	repo := NewMessage()
+	ctx := context.Background()
+	roomID := uuid.New()
+
+	for i := 0; i < MaxChatHistory+20; i++ {
+		repo.SaveChatMessage(ctx, model.NewChatMessage(uuid.New(), roomID, fmt.Sprintf("message %d", i), "Alice"))
+	}
+
+	messages, _ := repo.GetChatHistory(ctx, roomID, 0)
+	if len(messages) != MaxChatHistory {
+		t.Fatalf("Expected %d messages, got %d", MaxChatHistory, len(messages))
+	}
+
+	// 古いメッセージから破棄される
+	first := messages[0].Payload.(model.ChatPayload)
+	if first.Message != "message 20" {
+		t.Errorf("Expected oldest kept message to be message 20, got %s", first.Message)
+	}
+}
+
+func TestMessage_DeleteChatHistory(t *testing.T) {
+	repo := NewMessage()
+	ctx := context.Background()
+	roomID := uuid.New()
+	repo.SaveChatMessage(ctx, model.NewChatMessage(uuid.New(), roomID, "Hello", "Alice"))
+
+	if err := repo.DeleteChatHistory(ctx, roomID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	messages, _ := repo.GetChatHistory(ctx, roomID, 10)
+	if len(messages) != 0 {
+		t.Errorf("Expected empty history, got %d messages", len(messages))
+	}
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ErrEventNotFound is returned when an event is not in the outbox
+var ErrEventNotFound = fmt.Errorf("event %w", repository.ErrNotFound)
+
+// Outbox is an in-memory implementation of repository.Outbox
+// It takes part in Transactor transactions like Room
+type Outbox struct {
+	events map[uuid.UUID]*model.Event
+	mutex  sync.RWMutex
+}
+
+var _ repository.Outbox = (*Outbox)(nil)
+
+// NewOutbox creates a new in-memory Outbox
+func NewOutbox() *Outbox {
+	return &Outbox{
+		events: make(map[uuid.UUID]*model.Event),
+	}
+}
+
+// Add stores an event to be delivered
+func (o *Outbox) Add(ctx context.Context, event *model.Event) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	copied := *event
+	o.events[event.ID] = &copied
+	recordUndo(ctx, func() { o.restore(copied.ID, &copied, nil) })
+	return nil
+}
+
+// GetDue returns up to limit events whose NextAttemptAt is not after now, oldest first
+func (o *Outbox) GetDue(ctx context.Context, now time.Time, limit int) ([]*model.Event, error) {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	due := []*model.Event{}
+	for _, event := range o.events {
+		if !event.NextAttemptAt.After(now) {
+			copied := *event
+			due = append(due, &copied)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].OccurredAt.Before(due[j].OccurredAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+// Reschedule stores the event's Attempts and NextAttemptAt after a failed delivery
+func (o *Outbox) Reschedule(ctx context.Context, event *model.Event) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	stored, ok := o.events[event.ID]
+	if !ok {
+		return ErrEventNotFound
+	}
+
+	updated := *stored
+	updated.Attempts = event.Attempts
+	updated.NextAttemptAt = event.NextAttemptAt
+	o.events[event.ID] = &updated
+	recordUndo(ctx, func() { o.restore(stored.ID, &updated, stored) })
+	return nil
+}
+
+// Delete removes a delivered event
+func (o *Outbox) Delete(ctx context.Context, id uuid.UUID) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	stored, ok := o.events[id]
+	if !ok {
+		return nil
+	}
+
+	delete(o.events, id)
+	recordUndo(ctx, func() { o.restore(id, nil, stored) })
+	return nil
+}
+
+// restore puts back the event replaced by written within a rolled back transaction; nil removes it
+func (o *Outbox) restore(id uuid.UUID, written, event *model.Event) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	restoreEntry(o.events, id, written, event)
+}
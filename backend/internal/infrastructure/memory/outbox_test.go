@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+func TestOutbox_GetDueRescheduleDelete(t *testing.T) {
+	outbox := NewOutbox()
+	ctx := context.Background()
+	roomID := uuid.New()
+
+	first := model.NewParticipantJoinedEvent(roomID, uuid.New(), "First")
+	second := model.NewParticipantLeftEvent(roomID, uuid.New(), "Second")
+	second.OccurredAt = first.OccurredAt.Add(time.Millisecond)
+	later := model.NewParticipantMutedEvent(roomID, uuid.New(), uuid.New(), true)
+	later.NextAttemptAt = time.Now().Add(time.Hour)
+	for _, event := range []*model.Event{second, later, first} {
+		outbox.Add(ctx, event)
+	}
+
+	due, _ := outbox.GetDue(ctx, time.Now(), 10)
+	if len(due) != 2 || due[0].ID != first.ID || due[1].ID != second.ID {
+		t.Fatalf("Expected the due events oldest first, got %+v", due)
+	}
+	if due, _ := outbox.GetDue(ctx, time.Now(), 1); len(due) != 1 {
+		t.Errorf("Expected limit to apply, got %d events", len(due))
+	}
+
+	// 失敗した配信は後で再試行する
+	first.Attempts = 1
+	first.NextAttemptAt = time.Now().Add(time.Minute)
+	if err := outbox.Reschedule(ctx, first); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	outbox.Delete(ctx, second.ID)
+	if due, _ := outbox.GetDue(ctx, time.Now(), 10); len(due) != 0 {
+		t.Errorf("Expected no due events, got %+v", due)
+	}
+	if due, _ := outbox.GetDue(ctx, time.Now().Add(time.Minute), 10); len(due) != 1 || due[0].Attempts != 1 {
+		t.Errorf("Expected the rescheduled event, got %+v", due)
+	}
+
+	if err := outbox.Delete(ctx, second.ID); err != nil {
+		t.Errorf("Expected deleting twice to be a no-op, got %v", err)
+	}
+	if err := outbox.Reschedule(ctx, second); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("Expected ErrEventNotFound, got %v", err)
+	}
+}
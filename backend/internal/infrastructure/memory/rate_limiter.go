@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+// RateLimiter is an in-memory fixed-window implementation of service.RateLimiter
+// Attempts are only counted on this pod
+type RateLimiter struct {
+	limit   int
+	window  time.Duration
+	windows map[string]*attemptWindow
+	mutex   sync.Mutex
+}
+
+type attemptWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+var _ service.RateLimiter = (*RateLimiter)(nil)
+
+// NewRateLimiter creates a new in-memory RateLimiter allowing limit attempts per window
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]*attemptWindow),
+	}
+}
+
+// Allow records an attempt for key and reports whether it is within the limit
+func (l *RateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		// 期限切れのウィンドウはまとめて掃除する
+		for k, expired := range l.windows {
+			if !now.Before(expired.resetAt) {
+				delete(l.windows, k)
+			}
+		}
+		w = &attemptWindow{resetAt: now.Add(l.window)}
+		l.windows[key] = w
+	}
+
+	w.count++
+	return w.count <= l.limit, nil
+}
+
+// Reset forgets the attempts recorded for key
+func (l *RateLimiter) Reset(ctx context.Context, key string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.windows, key)
+	return nil
+}
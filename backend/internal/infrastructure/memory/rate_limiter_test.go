@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	limiter := NewRateLimiter(2, time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.Allow(ctx, "alice"); !ok {
+			t.Fatalf("Expected attempt %d to be allowed", i+1)
+		}
+	}
+	if ok, _ := limiter.Allow(ctx, "alice"); ok {
+		t.Error("Expected attempt over the limit to be rejected")
+	}
+
+	// キーごとに数える
+	if ok, _ := limiter.Allow(ctx, "bob"); !ok {
+		t.Error("Expected other key to be allowed")
+	}
+
+	limiter.Reset(ctx, "alice")
+	if ok, _ := limiter.Allow(ctx, "alice"); !ok {
+		t.Error("Expected attempt after reset to be allowed")
+	}
+}
+
+func TestRateLimiter_WindowExpires(t *testing.T) {
+	limiter := NewRateLimiter(1, 10*time.Millisecond)
+	ctx := context.Background()
+
+	limiter.Allow(ctx, "alice")
+	if ok, _ := limiter.Allow(ctx, "alice"); ok {
+		t.Error("Expected second attempt to be rejected")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if ok, _ := limiter.Allow(ctx, "alice"); !ok {
+		t.Error("Expected attempt in a new window to be allowed")
+	}
+}
@@ -0,0 +1,231 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrRoomNotFound is returned when a room does not exist in the store
+	ErrRoomNotFound = fmt.Errorf("room %w", repository.ErrNotFound)
+
+	// ErrRoomAlreadyExists is returned when creating a room whose ID is already stored
+	ErrRoomAlreadyExists = errors.New("room already exists")
+
+	// ErrRoomConflict is returned when updating a room that changed since it was read
+	ErrRoomConflict = fmt.Errorf("room %w", repository.ErrConflict)
+)
+
+// Room is an in-memory implementation of repository.Room
+// Rooms are deep-copied on every read and write so callers never share state with the store
+type Room struct {
+	rooms map[uuid.UUID]*model.Room
+	mutex sync.RWMutex
+}
+
+var _ repository.Room = (*Room)(nil)
+
+// NewRoom creates a new in-memory Room repository
+func NewRoom() *Room {
+	return &Room{
+		rooms: make(map[uuid.UUID]*model.Room),
+	}
+}
+
+// Create creates a new room
+func (r *Room) Create(ctx context.Context, room *model.Room) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.rooms[room.ID]; ok {
+		return ErrRoomAlreadyExists
+	}
+	// 定期的な会議の各回に作成できるルームは1つだけ
+	if room.Occurrence != nil {
+		for _, existing := range r.rooms {
+			if existing.Occurrence != nil && existing.Occurrence.SeriesID == room.Occurrence.SeriesID &&
+				existing.Occurrence.StartsAt.Equal(room.Occurrence.StartsAt) {
+				return ErrRoomAlreadyExists
+			}
+		}
+	}
+
+	created := copyRoom(room)
+	r.rooms[room.ID] = created
+	recordUndo(ctx, func() { r.restore(room.ID, created, nil) })
+	return nil
+}
+
+// GetByID retrieves a room by ID
+func (r *Room) GetByID(ctx context.Context, id uuid.UUID) (*model.Room, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	room, ok := r.rooms[id]
+	if !ok {
+		return nil, ErrRoomNotFound
+	}
+
+	return copyRoom(room), nil
+}
+
+// GetByHostID retrieves rooms by host ID, oldest first
+func (r *Room) GetByHostID(ctx context.Context, hostID uuid.UUID) ([]*model.Room, error) {
+	return r.filter(func(room *model.Room) bool {
+		return room.HostID == hostID
+	}), nil
+}
+
+// GetUpcomingByHostID retrieves scheduled rooms by host ID that end after from, ordered by start time
+func (r *Room) GetUpcomingByHostID(ctx context.Context, hostID uuid.UUID, from time.Time) ([]*model.Room, error) {
+	rooms := r.filter(func(room *model.Room) bool {
+		return room.HostID == hostID && room.Schedule != nil && room.Schedule.EndsAt.After(from)
+	})
+
+	sort.SliceStable(rooms, func(i, j int) bool {
+		return rooms[i].Schedule.StartsAt.Before(rooms[j].Schedule.StartsAt)
+	})
+
+	return rooms, nil
+}
+
+// GetBySeriesID retrieves the rooms materialized for a series, ordered by occurrence
+func (r *Room) GetBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*model.Room, error) {
+	rooms := r.filter(func(room *model.Room) bool {
+		return room.Occurrence != nil && room.Occurrence.SeriesID == seriesID
+	})
+
+	sort.SliceStable(rooms, func(i, j int) bool {
+		return rooms[i].Occurrence.StartsAt.Before(rooms[j].Occurrence.StartsAt)
+	})
+
+	return rooms, nil
+}
+
+// Update updates an existing room if it was not updated since it was read
+func (r *Room) Update(ctx context.Context, room *model.Room) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, ok := r.rooms[room.ID]
+	if !ok {
+		return ErrRoomNotFound
+	}
+	if stored.Version != room.Version {
+		return ErrRoomConflict
+	}
+
+	room.Version++
+	updated := copyRoom(room)
+	r.rooms[room.ID] = updated
+	recordUndo(ctx, func() { r.restore(room.ID, updated, stored) })
+	return nil
+}
+
+// Delete deletes a room
+func (r *Room) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, ok := r.rooms[id]
+	if !ok {
+		return ErrRoomNotFound
+	}
+
+	delete(r.rooms, id)
+	recordUndo(ctx, func() { r.restore(id, nil, stored) })
+	return nil
+}
+
+// GetActiveRooms retrieves all active (non-expired) rooms, oldest first
+func (r *Room) GetActiveRooms(ctx context.Context) ([]*model.Room, error) {
+	return r.filter(func(room *model.Room) bool {
+		return !room.IsExpired()
+	}), nil
+}
+
+// CountActiveRooms counts the active (non-expired) rooms and their participants
+func (r *Room) CountActiveRooms(ctx context.Context) (repository.RoomCounts, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	counts := repository.RoomCounts{BySize: make(map[int]int)}
+	for _, room := range r.rooms {
+		if !room.IsExpired() {
+			counts.Rooms++
+			counts.Participants += len(room.Participants)
+			counts.BySize[len(room.Participants)]++
+		}
+	}
+	return counts, nil
+}
+
+// CleanupExpiredRooms removes expired rooms and returns them
+func (r *Room) CleanupExpiredRooms(ctx context.Context) ([]*model.Room, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	removed := []*model.Room{}
+	for id, room := range r.rooms {
+		if room.IsExpired() {
+			delete(r.rooms, id)
+			removed = append(removed, room)
+			recordUndo(ctx, func() { r.restore(id, nil, room) })
+		}
+	}
+
+	return removed, nil
+}
+
+// restore puts back the room replaced by written within a rolled back transaction; nil removes it
+func (r *Room) restore(id uuid.UUID, written, room *model.Room) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	restoreEntry(r.rooms, id, written, room)
+}
+
+// filter returns copies of the rooms matching the predicate ordered by creation time
+func (r *Room) filter(match func(room *model.Room) bool) []*model.Room {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rooms := []*model.Room{}
+	for _, room := range r.rooms {
+		if match(room) {
+			rooms = append(rooms, copyRoom(room))
+		}
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].CreatedAt.Before(rooms[j].CreatedAt)
+	})
+
+	return rooms
+}
+
+// copyRoom returns a deep copy of a room
+func copyRoom(room *model.Room) *model.Room {
+	copied := *room
+	copied.Participants = make([]model.Participant, len(room.Participants))
+	copy(copied.Participants, room.Participants)
+	copied.BannedUserIDs = append([]uuid.UUID(nil), room.BannedUserIDs...)
+	if room.Schedule != nil {
+		schedule := *room.Schedule
+		copied.Schedule = &schedule
+	}
+	copied.CoHostIDs = append([]uuid.UUID(nil), room.CoHostIDs...)
+	if room.Occurrence != nil {
+		occurrence := *room.Occurrence
+		copied.Occurrence = &occurrence
+	}
+	return &copied
+}
@@ -0,0 +1,351 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+func TestRoom_CreateAndGetByID(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+	room := model.NewRoom("Test Room", uuid.New(), false)
+	room.AddParticipant(room.HostID)
+
+	if err := repo.Create(ctx, room); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, room.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Name != room.Name {
+		t.Errorf("Expected Name %s, got %s", room.Name, got.Name)
+	}
+	if len(got.Participants) != 1 {
+		t.Errorf("Expected 1 participant, got %d", len(got.Participants))
+	}
+
+	// 重複作成
+	if err := repo.Create(ctx, room); !errors.Is(err, ErrRoomAlreadyExists) {
+		t.Errorf("Expected ErrRoomAlreadyExists, got %v", err)
+	}
+}
+
+func TestRoom_GetByID_NotFound(t *testing.T) {
+	repo := NewRoom()
+
+	_, err := repo.GetByID(context.Background(), uuid.New())
+	if !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound, got %v", err)
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+}
+
+func TestRoom_DeepCopy(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+	room := model.NewRoom("Test Room", uuid.New(), false)
+	room.AddParticipant(room.HostID)
+	repo.Create(ctx, room)
+
+	// 作成後の変更はストアに反映されない
+	room.Name = "Changed"
+	room.Participants[0].IsMuted = true
+
+	got, _ := repo.GetByID(ctx, room.ID)
+	if got.Name != "Test Room" {
+		t.Errorf("Expected stored Name to be unchanged, got %s", got.Name)
+	}
+	if got.Participants[0].IsMuted {
+		t.Error("Expected stored participant to be unchanged")
+	}
+
+	// 取得したルームの変更もUpdateまで反映されない
+	got.AddParticipant(uuid.New())
+	got.Participants[0].IsMuted = true
+
+	again, _ := repo.GetByID(ctx, room.ID)
+	if len(again.Participants) != 1 {
+		t.Errorf("Expected 1 participant before Update, got %d", len(again.Participants))
+	}
+	if again.Participants[0].IsMuted {
+		t.Error("Expected participant to be unmuted before Update")
+	}
+
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	updated, _ := repo.GetByID(ctx, room.ID)
+	if len(updated.Participants) != 2 {
+		t.Errorf("Expected 2 participants after Update, got %d", len(updated.Participants))
+	}
+}
+
+func TestRoom_Update_NotFound(t *testing.T) {
+	repo := NewRoom()
+	room := model.NewRoom("Test Room", uuid.New(), false)
+
+	if err := repo.Update(context.Background(), room); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound, got %v", err)
+	}
+}
+
+func TestRoom_Update_Conflict(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+	room := model.NewRoom("Test Room", uuid.New(), false)
+	repo.Create(ctx, room)
+
+	first, _ := repo.GetByID(ctx, room.ID)
+	second, _ := repo.GetByID(ctx, room.ID)
+
+	first.IsLocked = true
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Expected version 2, got %d", first.Version)
+	}
+
+	// 古いバージョンからの更新は拒否される
+	second.Name = "Stale"
+	if err := repo.Update(ctx, second); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, room.ID)
+	if got.Name != "Test Room" || !got.IsLocked || got.Version != 2 {
+		t.Errorf("Expected the first update only, got %+v", got)
+	}
+}
+
+func TestRoom_Delete(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+	room := model.NewRoom("Test Room", uuid.New(), false)
+	repo.Create(ctx, room)
+
+	if err := repo.Delete(ctx, room.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, room.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, room.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestRoom_GetByHostID(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+	hostID := uuid.New()
+
+	first := model.NewRoom("First", hostID, false)
+	second := model.NewRoom("Second", hostID, false)
+	second.CreatedAt = first.CreatedAt.Add(time.Minute)
+	other := model.NewRoom("Other", uuid.New(), false)
+
+	repo.Create(ctx, second)
+	repo.Create(ctx, first)
+	repo.Create(ctx, other)
+
+	rooms, err := repo.GetByHostID(ctx, hostID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rooms) != 2 {
+		t.Fatalf("Expected 2 rooms, got %d", len(rooms))
+	}
+	if rooms[0].ID != first.ID || rooms[1].ID != second.ID {
+		t.Error("Expected rooms ordered by CreatedAt")
+	}
+
+	rooms, _ = repo.GetByHostID(ctx, uuid.New())
+	if len(rooms) != 0 {
+		t.Errorf("Expected no rooms for unknown host, got %d", len(rooms))
+	}
+}
+
+func TestRoom_GetActiveRoomsAndCleanup(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+
+	active := model.NewRoom("Active", uuid.New(), false)
+	expired := model.NewRoom("Expired", uuid.New(), false)
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+
+	repo.Create(ctx, active)
+	repo.Create(ctx, expired)
+
+	rooms, err := repo.GetActiveRooms(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rooms) != 1 || rooms[0].ID != active.ID {
+		t.Errorf("Expected only the active room, got %d rooms", len(rooms))
+	}
+
+	removed, err := repo.CleanupExpiredRooms(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(removed) != 1 || removed[0].ID != expired.ID {
+		t.Fatalf("Expected the expired room to be returned, got %d rooms", len(removed))
+	}
+	if _, err := repo.GetByID(ctx, expired.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected expired room to be removed, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, active.ID); err != nil {
+		t.Errorf("Expected active room to remain, got %v", err)
+	}
+}
+
+func TestRoom_CountActiveRooms(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+
+	active := model.NewRoom("Active", uuid.New(), false)
+	active.AddParticipant(active.HostID)
+	active.AddParticipant(uuid.New())
+	expired := model.NewRoom("Expired", uuid.New(), false)
+	expired.AddParticipant(expired.HostID)
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	repo.Create(ctx, active)
+	repo.Create(ctx, expired)
+	repo.Create(ctx, model.NewRoom("Empty", uuid.New(), false))
+
+	counts, err := repo.CountActiveRooms(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := repository.RoomCounts{Rooms: 2, Participants: 2, BySize: map[int]int{0: 1, 2: 1}}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("Expected %+v, got %+v", want, counts)
+	}
+}
+
+func TestRoom_ConcurrentAccess(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+	hostID := uuid.New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			room := model.NewRoom("Room", hostID, false)
+			repo.Create(ctx, room)
+			got, _ := repo.GetByID(ctx, room.ID)
+			got.AddParticipant(uuid.New())
+			repo.Update(ctx, got)
+			repo.GetActiveRooms(ctx)
+		}()
+	}
+	wg.Wait()
+
+	rooms, _ := repo.GetByHostID(ctx, hostID)
+	if len(rooms) != 50 {
+		t.Errorf("Expected 50 rooms, got %d", len(rooms))
+	}
+}
+
+func TestRoom_GetUpcomingByHostID(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+	hostID := uuid.New()
+	now := time.Now()
+
+	later, _ := model.NewSchedule(now.Add(3*time.Hour), now.Add(4*time.Hour), 0)
+	sooner, _ := model.NewSchedule(now.Add(time.Hour), now.Add(2*time.Hour), 0)
+	ended, _ := model.NewSchedule(now.Add(-2*time.Hour), now.Add(time.Minute), 0)
+	ended.EndsAt = now.Add(-time.Hour)
+
+	laterRoom := model.NewScheduledRoom("Later", hostID, false, later)
+	soonerRoom := model.NewScheduledRoom("Sooner", hostID, false, sooner)
+	repo.Create(ctx, laterRoom)
+	repo.Create(ctx, soonerRoom)
+	repo.Create(ctx, model.NewScheduledRoom("Ended", hostID, false, ended))
+	repo.Create(ctx, model.NewRoom("Ad hoc", hostID, false))
+	repo.Create(ctx, model.NewScheduledRoom("Other host", uuid.New(), false, sooner))
+
+	rooms, err := repo.GetUpcomingByHostID(ctx, hostID, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rooms) != 2 {
+		t.Fatalf("Expected 2 upcoming rooms, got %d", len(rooms))
+	}
+	if rooms[0].ID != soonerRoom.ID || rooms[1].ID != laterRoom.ID {
+		t.Errorf("Expected rooms ordered by start time, got %s, %s", rooms[0].Name, rooms[1].Name)
+	}
+
+	// スケジュールもコピーされる
+	rooms[0].Schedule.StartsAt = now
+	stored, _ := repo.GetByID(ctx, soonerRoom.ID)
+	if stored.Schedule.StartsAt.Equal(now) {
+		t.Error("Expected stored schedule not to change")
+	}
+}
+
+func TestRoom_GetBySeriesID(t *testing.T) {
+	repo := NewRoom()
+	ctx := context.Background()
+	hostID := uuid.New()
+	coHostID := uuid.New()
+
+	series, err := model.NewSeries("Standup", hostID, true, time.Now().Add(time.Hour), 15*time.Minute, 0, "FREQ=DAILY", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	series.SetCoHosts([]uuid.UUID{coHostID})
+	occurrences, _ := series.Occurrences(time.Now(), 2)
+
+	second, _ := series.NewOccurrenceRoom(&occurrences[1])
+	first, _ := series.NewOccurrenceRoom(&occurrences[0])
+	repo.Create(ctx, second)
+	repo.Create(ctx, first)
+	repo.Create(ctx, model.NewRoom("Ad hoc", hostID, false))
+
+	// 同じ回のルームは重複して作成できない
+	duplicate, _ := series.NewOccurrenceRoom(&occurrences[0])
+	if err := repo.Create(ctx, duplicate); !errors.Is(err, ErrRoomAlreadyExists) {
+		t.Errorf("Expected ErrRoomAlreadyExists, got %v", err)
+	}
+
+	// 別のタイムゾーンで表した同じ時刻も同じ回になる
+	duplicate, _ = series.NewOccurrenceRoom(&occurrences[0])
+	duplicate.Occurrence.StartsAt = duplicate.Occurrence.StartsAt.In(time.FixedZone("JST", 9*60*60))
+	if err := repo.Create(ctx, duplicate); !errors.Is(err, ErrRoomAlreadyExists) {
+		t.Errorf("Expected ErrRoomAlreadyExists for the same instant in another zone, got %v", err)
+	}
+
+	rooms, err := repo.GetBySeriesID(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rooms) != 2 || rooms[0].ID != first.ID || rooms[1].ID != second.ID {
+		t.Fatalf("Expected rooms ordered by occurrence, got %v", rooms)
+	}
+
+	// 共同ホストと回の情報もコピーされる
+	rooms[0].CoHostIDs[0] = uuid.New()
+	rooms[0].Occurrence.StartsAt = time.Now()
+	stored, _ := repo.GetByID(ctx, first.ID)
+	if stored.CoHostIDs[0] != coHostID || !stored.Occurrence.StartsAt.Equal(occurrences[0].StartsAt) {
+		t.Error("Expected stored co-hosts and occurrence not to change")
+	}
+}
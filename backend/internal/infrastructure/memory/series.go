@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSeriesNotFound is returned when a series does not exist in the store
+	ErrSeriesNotFound = fmt.Errorf("series %w", repository.ErrNotFound)
+
+	// ErrSeriesAlreadyExists is returned when creating a series whose ID is already stored
+	ErrSeriesAlreadyExists = errors.New("series already exists")
+
+	// ErrSeriesConflict is returned when updating a series whose version changed since it was read
+	ErrSeriesConflict = fmt.Errorf("series %w", repository.ErrConflict)
+)
+
+// Series is an in-memory implementation of repository.Series
+type Series struct {
+	series map[uuid.UUID]*model.Series
+	mutex  sync.RWMutex
+}
+
+var _ repository.Series = (*Series)(nil)
+
+// NewSeries creates a new in-memory Series repository
+func NewSeries() *Series {
+	return &Series{
+		series: make(map[uuid.UUID]*model.Series),
+	}
+}
+
+// Create creates a new series
+func (r *Series) Create(ctx context.Context, series *model.Series) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.series[series.ID]; ok {
+		return ErrSeriesAlreadyExists
+	}
+
+	created := copySeries(series)
+	r.series[series.ID] = created
+	recordUndo(ctx, func() { r.restore(series.ID, created, nil) })
+	return nil
+}
+
+// GetByID retrieves a series by ID
+func (r *Series) GetByID(ctx context.Context, id uuid.UUID) (*model.Series, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	series, ok := r.series[id]
+	if !ok {
+		return nil, ErrSeriesNotFound
+	}
+
+	return copySeries(series), nil
+}
+
+// GetByHostID retrieves the series of a host, oldest first
+func (r *Series) GetByHostID(ctx context.Context, hostID uuid.UUID) ([]*model.Series, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	list := []*model.Series{}
+	for _, series := range r.series {
+		if series.HostID == hostID {
+			list = append(list, copySeries(series))
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	return list, nil
+}
+
+// Update updates an existing series if series.Version is the stored version
+func (r *Series) Update(ctx context.Context, series *model.Series) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, ok := r.series[series.ID]
+	if !ok {
+		return ErrSeriesNotFound
+	}
+	if stored.Version != series.Version {
+		return ErrSeriesConflict
+	}
+
+	series.Version++
+	updated := copySeries(series)
+	r.series[series.ID] = updated
+	recordUndo(ctx, func() { r.restore(series.ID, updated, stored) })
+	return nil
+}
+
+// Delete deletes a series
+func (r *Series) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, ok := r.series[id]
+	if !ok {
+		return ErrSeriesNotFound
+	}
+
+	delete(r.series, id)
+	recordUndo(ctx, func() { r.restore(id, nil, stored) })
+	return nil
+}
+
+// restore puts back the series replaced by written within a rolled back transaction; nil removes it
+func (r *Series) restore(id uuid.UUID, written, series *model.Series) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	restoreEntry(r.series, id, written, series)
+}
+
+// copySeries returns a deep copy of a series
+func copySeries(series *model.Series) *model.Series {
+	copied := *series
+	copied.CoHostIDs = append([]uuid.UUID{}, series.CoHostIDs...)
+	copied.ExceptionDates = append([]time.Time{}, series.ExceptionDates...)
+	return &copied
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+func newTestSeries(t *testing.T, hostID uuid.UUID) *model.Series {
+	t.Helper()
+
+	series, err := model.NewSeries("Standup", hostID, false, time.Now().Add(time.Hour), 15*time.Minute, 0, "FREQ=DAILY", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return series
+}
+
+func TestSeries_CreateAndGetByID(t *testing.T) {
+	repo := NewSeries()
+	ctx := context.Background()
+	series := newTestSeries(t, uuid.New())
+
+	if err := repo.Create(ctx, series); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Name != series.Name || got.RRule != series.RRule {
+		t.Errorf("Expected %+v, got %+v", series, got)
+	}
+
+	// 重複作成
+	if err := repo.Create(ctx, series); !errors.Is(err, ErrSeriesAlreadyExists) {
+		t.Errorf("Expected ErrSeriesAlreadyExists, got %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+}
+
+func TestSeries_UpdateAndDelete(t *testing.T) {
+	repo := NewSeries()
+	ctx := context.Background()
+	series := newTestSeries(t, uuid.New())
+	repo.Create(ctx, series)
+
+	got, _ := repo.GetByID(ctx, series.ID)
+	occurrences, _ := got.Occurrences(time.Now(), 1)
+	got.CancelOccurrence(occurrences[0].StartsAt)
+
+	// 更新前はストアに反映されない
+	stored, _ := repo.GetByID(ctx, series.ID)
+	if len(stored.ExceptionDates) != 0 {
+		t.Error("Expected stored series not to change before Update")
+	}
+
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	stored, _ = repo.GetByID(ctx, series.ID)
+	if len(stored.ExceptionDates) != 1 {
+		t.Errorf("Expected 1 exception date, got %d", len(stored.ExceptionDates))
+	}
+
+	if err := repo.Delete(ctx, series.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.Delete(ctx, series.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+	if err := repo.Update(ctx, got); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+}
+
+func TestSeries_Update_Conflict(t *testing.T) {
+	repo := NewSeries()
+	ctx := context.Background()
+	series := newTestSeries(t, uuid.New())
+	repo.Create(ctx, series)
+
+	first, _ := repo.GetByID(ctx, series.ID)
+	second, _ := repo.GetByID(ctx, series.ID)
+	occurrences, _ := first.Occurrences(time.Now(), 2)
+
+	first.CancelOccurrence(occurrences[0].StartsAt)
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Expected version 2, got %d", first.Version)
+	}
+
+	// 古いバージョンからの更新は中止した回を消してしまうので拒否される
+	second.CancelOccurrence(occurrences[1].StartsAt)
+	if err := repo.Update(ctx, second); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, series.ID)
+	if len(got.ExceptionDates) != 1 || !got.ExceptionDates[0].Equal(occurrences[0].StartsAt) || got.Version != 2 {
+		t.Errorf("Expected the first update only, got %+v", got)
+	}
+}
+
+func TestSeries_GetByHostID(t *testing.T) {
+	repo := NewSeries()
+	ctx := context.Background()
+	hostID := uuid.New()
+
+	first := newTestSeries(t, hostID)
+	second := newTestSeries(t, hostID)
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	repo.Create(ctx, second)
+	repo.Create(ctx, first)
+	repo.Create(ctx, newTestSeries(t, uuid.New()))
+
+	list, err := repo.GetByHostID(ctx, hostID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(list) != 2 || list[0].ID != first.ID || list[1].ID != second.ID {
+		t.Errorf("Expected [%s %s], got %v", first.ID, second.ID, list)
+	}
+}
@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+// ErrSessionNotFound is returned when a user has no session
+var ErrSessionNotFound = service.ErrSessionNotFound
+
+// SessionManager is an in-memory implementation of service.SessionManager
+// It is meant for single-pod deployments and local development without Redis
+type SessionManager struct {
+	sessions  map[uuid.UUID]*service.UserSession
+	serverPod string
+	mutex     sync.RWMutex
+}
+
+var _ service.SessionManager = (*SessionManager)(nil)
+
+// NewSessionManager creates a new in-memory SessionManager
+// serverPod is recorded on sessions created here
+func NewSessionManager(serverPod string) *SessionManager {
+	return &SessionManager{
+		sessions:  make(map[uuid.UUID]*service.UserSession),
+		serverPod: serverPod,
+	}
+}
+
+// CreateSession creates a new user session bound to this pod
+// An existing room membership is kept so reconnecting users stay in their room
+func (s *SessionManager) CreateSession(ctx context.Context, userID uuid.UUID, connectionID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, ok := s.sessions[userID]
+	session := &service.UserSession{UserID: userID}
+	if ok {
+		copied := *stored
+		session = &copied
+	}
+	session.ConnectionID = connectionID
+	session.ServerPod = s.serverPod
+	session.LastSeen = time.Now().Unix()
+	s.sessions[userID] = session
+	recordUndo(ctx, func() { s.restore(userID, session, stored) })
+
+	return nil
+}
+
+// GetSession retrieves a user session
+func (s *SessionManager) GetSession(ctx context.Context, userID uuid.UUID) (*service.UserSession, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	session, ok := s.sessions[userID]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	copied := *session
+	return &copied, nil
+}
+
+// UpdateSession updates a user session
+// Empty ConnectionID and ServerPod keep the stored values
+func (s *SessionManager) UpdateSession(ctx context.Context, session *service.UserSession) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	updated := *session
+	stored, ok := s.sessions[session.UserID]
+	if ok {
+		if updated.ConnectionID == "" {
+			updated.ConnectionID = stored.ConnectionID
+		}
+		if updated.ServerPod == "" {
+			updated.ServerPod = stored.ServerPod
+		}
+	}
+	s.sessions[updated.UserID] = &updated
+	recordUndo(ctx, func() { s.restore(updated.UserID, &updated, stored) })
+
+	return nil
+}
+
+// DeleteSession deletes a user session
+func (s *SessionManager) DeleteSession(ctx context.Context, userID uuid.UUID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, ok := s.sessions[userID]
+	if !ok {
+		return nil
+	}
+
+	delete(s.sessions, userID)
+	recordUndo(ctx, func() { s.restore(userID, nil, stored) })
+	return nil
+}
+
+// GetActiveUsers returns all active users in a room
+func (s *SessionManager) GetActiveUsers(ctx context.Context, roomID uuid.UUID) ([]uuid.UUID, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var userIDs []uuid.UUID
+	for userID, session := range s.sessions {
+		if session.RoomID == roomID {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
+
+// TouchSession refreshes LastSeen while the user's connection is alive
+func (s *SessionManager) TouchSession(ctx context.Context, userID uuid.UUID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session, ok := s.sessions[userID]
+	if !ok {
+		return ErrSessionNotFound
+	}
+	session.LastSeen = time.Now().Unix()
+	return nil
+}
+
+// ReapStaleSessions deletes the sessions last seen before the given time and returns them
+func (s *SessionManager) ReapStaleSessions(ctx context.Context, before time.Time) ([]*service.UserSession, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	reaped := []*service.UserSession{}
+	for userID, session := range s.sessions {
+		if session.LastSeen < before.Unix() {
+			delete(s.sessions, userID)
+			reaped = append(reaped, session)
+		}
+	}
+	return reaped, nil
+}
+
+// restore puts back the session replaced by written within a rolled back transaction; nil removes it
+func (s *SessionManager) restore(userID uuid.UUID, written, session *service.UserSession) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	restoreEntry(s.sessions, userID, written, session)
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+func TestSessionManager_CreateAndGetSession(t *testing.T) {
+	manager := NewSessionManager("realtime-hub-1")
+	ctx := context.Background()
+	userID := uuid.New()
+
+	if err := manager.CreateSession(ctx, userID, "conn456"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	session, err := manager.GetSession(ctx, userID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if session.ConnectionID != "conn456" {
+		t.Errorf("Expected ConnectionID conn456, got %s", session.ConnectionID)
+	}
+	if session.ServerPod != "realtime-hub-1" {
+		t.Errorf("Expected ServerPod realtime-hub-1, got %s", session.ServerPod)
+	}
+
+	// 返されたセッションを変更してもストアには影響しない
+	session.IsMuted = true
+	stored, _ := manager.GetSession(ctx, userID)
+	if stored.IsMuted {
+		t.Error("Expected stored session to be unaffected")
+	}
+
+	if _, err := manager.GetSession(ctx, uuid.New()); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestSessionManager_UpdateSession(t *testing.T) {
+	manager := NewSessionManager("realtime-hub-1")
+	ctx := context.Background()
+	userID := uuid.New()
+	roomID := uuid.New()
+
+	manager.CreateSession(ctx, userID, "conn456")
+
+	err := manager.UpdateSession(ctx, &service.UserSession{UserID: userID, RoomID: roomID, IsHost: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	session, _ := manager.GetSession(ctx, userID)
+	if session.RoomID != roomID || !session.IsHost {
+		t.Errorf("Expected host session in room, got %+v", session)
+	}
+	if session.ConnectionID != "conn456" || session.ServerPod != "realtime-hub-1" {
+		t.Errorf("Expected connection info to be kept, got %+v", session)
+	}
+
+	// 再接続してもルームは維持される
+	manager.CreateSession(ctx, userID, "conn789")
+	session, _ = manager.GetSession(ctx, userID)
+	if session.RoomID != roomID || session.ConnectionID != "conn789" {
+		t.Errorf("Expected room kept on reconnect, got %+v", session)
+	}
+}
+
+func TestSessionManager_GetActiveUsersAndDelete(t *testing.T) {
+	manager := NewSessionManager("realtime-hub-1")
+	ctx := context.Background()
+	roomID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+
+	manager.UpdateSession(ctx, &service.UserSession{UserID: alice, RoomID: roomID})
+	manager.UpdateSession(ctx, &service.UserSession{UserID: bob, RoomID: roomID})
+	manager.UpdateSession(ctx, &service.UserSession{UserID: uuid.New(), RoomID: uuid.New()})
+
+	users, _ := manager.GetActiveUsers(ctx, roomID)
+	if len(users) != 2 {
+		t.Errorf("Expected 2 active users, got %d", len(users))
+	}
+
+	manager.DeleteSession(ctx, alice)
+	users, _ = manager.GetActiveUsers(ctx, roomID)
+	if len(users) != 1 || users[0] != bob {
+		t.Errorf("Expected only bob, got %v", users)
+	}
+}
+
+func TestSessionManager_ReapStaleSessions(t *testing.T) {
+	manager := NewSessionManager("realtime-hub-1")
+	ctx := context.Background()
+	staleID := uuid.New()
+	touchedID := uuid.New()
+
+	hourAgo := time.Now().Add(-time.Hour).Unix()
+	manager.UpdateSession(ctx, &service.UserSession{UserID: staleID, RoomID: uuid.New(), LastSeen: hourAgo})
+	manager.UpdateSession(ctx, &service.UserSession{UserID: touchedID, LastSeen: hourAgo})
+	manager.TouchSession(ctx, touchedID)
+
+	reaped, err := manager.ReapStaleSessions(ctx, time.Now().Add(-5*time.Minute))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reaped) != 1 || reaped[0].UserID != staleID {
+		t.Fatalf("Expected only the stale session, got %+v", reaped)
+	}
+	if _, err := manager.GetSession(ctx, staleID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+	if err := manager.TouchSession(ctx, staleID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// journalKey is the context key of the journal of the transaction in progress
+type journalKey struct{}
+
+// journal records how to undo the changes made within a transaction and what to run once it commits
+type journal struct {
+	undo        []func()
+	afterCommit []func(ctx context.Context)
+	mutex       sync.Mutex
+}
+
+// Transactor is an in-memory implementation of repository.Transactor
+// Room, Series, Invite, SessionManager and Outbox record how to undo their changes so a failed transaction leaves them untouched;
+// transactions are not isolated from each other, so a rollback keeps entries another request changed in the meantime
+type Transactor struct{}
+
+var _ repository.Transactor = (*Transactor)(nil)
+
+// NewTransactor creates a new in-memory Transactor
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+// WithinTransaction runs fn and undoes its changes if it returns an error
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 外側のトランザクションに参加する
+	if _, ok := ctx.Value(journalKey{}).(*journal); ok {
+		return fn(ctx)
+	}
+
+	j := &journal{}
+	if err := fn(context.WithValue(ctx, journalKey{}, j)); err != nil {
+		j.rollback()
+		return err
+	}
+	j.commit(ctx)
+	return nil
+}
+
+// AfterCommit runs fn once the transaction carried by ctx commits, or right away outside a transaction
+func (t *Transactor) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	j, ok := ctx.Value(journalKey{}).(*journal)
+	if !ok {
+		fn(ctx)
+		return
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.afterCommit = append(j.afterCommit, fn)
+}
+
+// commit runs the functions queued with AfterCommit in order, with ctx outside the transaction
+func (j *journal) commit(ctx context.Context) {
+	j.mutex.Lock()
+	fns := j.afterCommit
+	j.undo, j.afterCommit = nil, nil
+	j.mutex.Unlock()
+
+	for _, fn := range fns {
+		fn(ctx)
+	}
+}
+
+// rollback undoes the recorded changes, newest first
+func (j *journal) rollback() {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		j.undo[i]()
+	}
+	j.undo, j.afterCommit = nil, nil
+}
+
+// restoreEntry puts previous back in entries if the entry is still written, the value stored by the rolled back change
+// An entry changed since by another request is kept; a nil written or previous stands for a missing entry
+// The caller must hold the store's lock
+func restoreEntry[T any](entries map[uuid.UUID]*T, id uuid.UUID, written, previous *T) {
+	// 別のリクエストが上書きした値を古い値で戻さない
+	if entries[id] != written {
+		return
+	}
+
+	if previous == nil {
+		delete(entries, id)
+		return
+	}
+	entries[id] = previous
+}
+
+// recordUndo registers how to undo a change made within the transaction carried by ctx
+// Outside a transaction changes are final and nothing is recorded
+// undo runs without the store's lock held, so it must take the lock itself
+func recordUndo(ctx context.Context, undo func()) {
+	j, ok := ctx.Value(journalKey{}).(*journal)
+	if !ok {
+		return
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.undo = append(j.undo, undo)
+}
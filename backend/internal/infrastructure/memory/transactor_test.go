@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+func TestTransactor_CommitsOnSuccess(t *testing.T) {
+	rooms := NewRoom()
+	transactor := NewTransactor()
+	ctx := context.Background()
+
+	room := model.NewRoom("Test Room", uuid.New(), false)
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return rooms.Create(ctx, room)
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := rooms.GetByID(ctx, room.ID); err != nil {
+		t.Errorf("Expected committed room, got %v", err)
+	}
+}
+
+func TestTransactor_RollsBackOnError(t *testing.T) {
+	rooms := NewRoom()
+	sessions := NewSessionManager("pod-1")
+	transactor := NewTransactor()
+	ctx := context.Background()
+
+	updated := model.NewRoom("Updated", uuid.New(), false)
+	deleted := model.NewRoom("Deleted", uuid.New(), false)
+	rooms.Create(ctx, updated)
+	rooms.Create(ctx, deleted)
+
+	kept := uuid.New()
+	sessions.CreateSession(ctx, kept, "conn-1")
+	sessions.UpdateSession(ctx, &service.UserSession{UserID: kept, RoomID: updated.ID})
+
+	failure := errors.New("failure")
+	created := model.NewRoom("Created", uuid.New(), false)
+	added := uuid.New()
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		rooms.Create(ctx, created)
+
+		room, _ := rooms.GetByID(ctx, updated.ID)
+		room.IsLocked = true
+		rooms.Update(ctx, room)
+		rooms.Delete(ctx, deleted.ID)
+
+		sessions.CreateSession(ctx, added, "conn-2")
+		sessions.DeleteSession(ctx, kept)
+
+		// ネストした呼び出しは外側のトランザクションに参加する
+		return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return failure
+		})
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the error of fn, got %v", err)
+	}
+
+	if _, err := rooms.GetByID(ctx, created.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected the created room to be rolled back, got %v", err)
+	}
+	if room, _ := rooms.GetByID(ctx, updated.ID); room.IsLocked || room.Version != 1 {
+		t.Errorf("Expected the update to be rolled back, got %+v", room)
+	}
+	if _, err := rooms.GetByID(ctx, deleted.ID); err != nil {
+		t.Errorf("Expected the deleted room to be rolled back, got %v", err)
+	}
+
+	if _, err := sessions.GetSession(ctx, added); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected the created session to be rolled back, got %v", err)
+	}
+	if session, err := sessions.GetSession(ctx, kept); err != nil || session.RoomID != updated.ID {
+		t.Errorf("Expected the deleted session to be rolled back, got %+v (%v)", session, err)
+	}
+}
+
+func TestTransactor_RollbackKeepsConcurrentChanges(t *testing.T) {
+	rooms := NewRoom()
+	sessions := NewSessionManager("pod-1")
+	transactor := NewTransactor()
+	ctx := context.Background()
+
+	room := model.NewRoom("Test Room", uuid.New(), false)
+	rooms.Create(ctx, room)
+	userID := uuid.New()
+
+	failure := errors.New("failure")
+	err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		locked, _ := rooms.GetByID(txCtx, room.ID)
+		locked.IsLocked = true
+		rooms.Update(txCtx, locked)
+		sessions.CreateSession(txCtx, userID, "conn-1")
+
+		// トランザクションの外の書き込みが先に確定する
+		renamed, _ := rooms.GetByID(ctx, room.ID)
+		renamed.Name = "Renamed"
+		if err := rooms.Update(ctx, renamed); err != nil {
+			return err
+		}
+		sessions.UpdateSession(ctx, &service.UserSession{UserID: userID, RoomID: room.ID})
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the error of fn, got %v", err)
+	}
+
+	// 取り消しで後から確定した変更を消さない
+	if got, _ := rooms.GetByID(ctx, room.ID); got.Name != "Renamed" || got.Version != 3 {
+		t.Errorf("Expected the concurrent update to be kept, got %+v", got)
+	}
+	if session, err := sessions.GetSession(ctx, userID); err != nil || session.RoomID != room.ID {
+		t.Errorf("Expected the concurrent session update to be kept, got %+v (%v)", session, err)
+	}
+}
+
+func TestTransactor_AfterCommit(t *testing.T) {
+	transactor := NewTransactor()
+	ctx := context.Background()
+
+	// トランザクション外ではすぐに実行する
+	var ran []string
+	transactor.AfterCommit(ctx, func(ctx context.Context) { ran = append(ran, "outside") })
+	if len(ran) != 1 {
+		t.Fatalf("Expected the function to run right away, got %v", ran)
+	}
+
+	ran = nil
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		transactor.AfterCommit(ctx, func(ctx context.Context) { ran = append(ran, "outer") })
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			transactor.AfterCommit(ctx, func(ctx context.Context) { ran = append(ran, "nested") })
+			return nil
+		})
+
+		// ネストした呼び出しが終わっても外側がコミットするまでは実行しない
+		if len(ran) != 0 {
+			t.Errorf("Expected nothing to run before the outer commit, got %v", ran)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(ran) != 2 || ran[0] != "outer" || ran[1] != "nested" {
+		t.Errorf("Expected both functions to run in order after commit, got %v", ran)
+	}
+
+	ran = nil
+	failure := errors.New("failure")
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			transactor.AfterCommit(ctx, func(ctx context.Context) { ran = append(ran, "nested") })
+			return nil
+		})
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the error of fn, got %v", err)
+	}
+	if len(ran) != 0 {
+		t.Errorf("Expected nothing to run after a rollback, got %v", ran)
+	}
+}
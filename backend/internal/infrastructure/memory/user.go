@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrUserNotFound is returned when a user does not exist in the store
+	ErrUserNotFound = fmt.Errorf("user %w", repository.ErrNotFound)
+
+	// ErrUserAlreadyExists is returned when creating a user whose ID or Google ID is already stored
+	ErrUserAlreadyExists = errors.New("user already exists")
+)
+
+// User is an in-memory implementation of repository.User
+type User struct {
+	users map[uuid.UUID]*model.User
+	mutex sync.RWMutex
+}
+
+var _ repository.User = (*User)(nil)
+
+// NewUser creates a new in-memory User repository
+func NewUser() *User {
+	return &User{
+		users: make(map[uuid.UUID]*model.User),
+	}
+}
+
+// Create creates a new user
+func (u *User) Create(ctx context.Context, user *model.User) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if _, ok := u.users[user.ID]; ok {
+		return ErrUserAlreadyExists
+	}
+
+	// google_id はユニーク制約
+	if user.GoogleID != "" {
+		for _, existing := range u.users {
+			if existing.GoogleID == user.GoogleID {
+				return ErrUserAlreadyExists
+			}
+		}
+	}
+
+	copied := *user
+	u.users[user.ID] = &copied
+	return nil
+}
+
+// GetByID retrieves a user by ID
+func (u *User) GetByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	user, ok := u.users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	copied := *user
+	return &copied, nil
+}
+
+// GetByGoogleID retrieves a user by Google ID
+func (u *User) GetByGoogleID(ctx context.Context, googleID string) (*model.User, error) {
+	return u.find(func(user *model.User) bool {
+		return user.GoogleID == googleID
+	})
+}
+
+// GetByEmail retrieves a user by email
+func (u *User) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	return u.find(func(user *model.User) bool {
+		return user.Email == email
+	})
+}
+
+// Update updates an existing user
+func (u *User) Update(ctx context.Context, user *model.User) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if _, ok := u.users[user.ID]; !ok {
+		return ErrUserNotFound
+	}
+
+	copied := *user
+	u.users[user.ID] = &copied
+	return nil
+}
+
+// Delete deletes a user
+func (u *User) Delete(ctx context.Context, id uuid.UUID) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if _, ok := u.users[id]; !ok {
+		return ErrUserNotFound
+	}
+
+	delete(u.users, id)
+	return nil
+}
+
+// find returns a copy of the first user matching the predicate
+func (u *User) find(match func(user *model.User) bool) (*model.User, error) {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	for _, user := range u.users {
+		if match(user) {
+			copied := *user
+			return &copied, nil
+		}
+	}
+
+	return nil, ErrUserNotFound
+}
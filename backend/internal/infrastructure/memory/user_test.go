@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+func TestUser_CreateAndGet(t *testing.T) {
+	repo := NewUser()
+	ctx := context.Background()
+	user := model.NewUser("google123", "test@example.com", "Test User", "")
+
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	byID, err := repo.GetByID(ctx, user.ID)
+	if err != nil || byID.Email != user.Email {
+		t.Errorf("Expected user by ID, got %v (err %v)", byID, err)
+	}
+
+	byGoogleID, err := repo.GetByGoogleID(ctx, "google123")
+	if err != nil || byGoogleID.ID != user.ID {
+		t.Errorf("Expected user by Google ID, got %v (err %v)", byGoogleID, err)
+	}
+
+	byEmail, err := repo.GetByEmail(ctx, "test@example.com")
+	if err != nil || byEmail.ID != user.ID {
+		t.Errorf("Expected user by email, got %v (err %v)", byEmail, err)
+	}
+
+	// 取得結果の変更はストアに影響しない
+	byID.Name = "Changed"
+	again, _ := repo.GetByID(ctx, user.ID)
+	if again.Name != "Test User" {
+		t.Errorf("Expected stored Name to be unchanged, got %s", again.Name)
+	}
+}
+
+func TestUser_Create_Duplicate(t *testing.T) {
+	repo := NewUser()
+	ctx := context.Background()
+	user := model.NewUser("google123", "test@example.com", "Test User", "")
+	repo.Create(ctx, user)
+
+	if err := repo.Create(ctx, user); !errors.Is(err, ErrUserAlreadyExists) {
+		t.Errorf("Expected ErrUserAlreadyExists for same ID, got %v", err)
+	}
+
+	sameGoogleID := model.NewUser("google123", "other@example.com", "Other", "")
+	if err := repo.Create(ctx, sameGoogleID); !errors.Is(err, ErrUserAlreadyExists) {
+		t.Errorf("Expected ErrUserAlreadyExists for same Google ID, got %v", err)
+	}
+}
+
+func TestUser_NotFound(t *testing.T) {
+	repo := NewUser()
+	ctx := context.Background()
+
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+	if _, err := repo.GetByGoogleID(ctx, "missing"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if _, err := repo.GetByEmail(ctx, "missing@example.com"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if err := repo.Update(ctx, model.NewUser("g", "e", "n", "")); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, uuid.New()); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestUser_UpdateAndDelete(t *testing.T) {
+	repo := NewUser()
+	ctx := context.Background()
+	user := model.NewUser("google123", "test@example.com", "Test User", "")
+	repo.Create(ctx, user)
+
+	user.UpdateProfile("New Name", "https://example.com/a.png")
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, user.ID)
+	if got.Name != "New Name" {
+		t.Errorf("Expected Name New Name, got %s", got.Name)
+	}
+
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, user.ID); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound after delete, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ErrNotWaiting is returned when a user is not in a room's waiting set
+var ErrNotWaiting = fmt.Errorf("waiting user %w", repository.ErrNotFound)
+
+// WaitingRoom is an in-memory implementation of repository.WaitingRoom
+type WaitingRoom struct {
+	waiting map[uuid.UUID]map[uuid.UUID]struct{}
+	mutex   sync.RWMutex
+}
+
+var _ repository.WaitingRoom = (*WaitingRoom)(nil)
+
+// NewWaitingRoom creates a new in-memory WaitingRoom repository
+func NewWaitingRoom() *WaitingRoom {
+	return &WaitingRoom{
+		waiting: make(map[uuid.UUID]map[uuid.UUID]struct{}),
+	}
+}
+
+// AddWaitingUser places a user in the room's waiting set
+func (w *WaitingRoom) AddWaitingUser(ctx context.Context, roomID, userID uuid.UUID) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.waiting[roomID] == nil {
+		w.waiting[roomID] = make(map[uuid.UUID]struct{})
+	}
+	w.waiting[roomID][userID] = struct{}{}
+	return nil
+}
+
+// RemoveWaitingUser removes a user from the room's waiting set
+func (w *WaitingRoom) RemoveWaitingUser(ctx context.Context, roomID, userID uuid.UUID) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if _, ok := w.waiting[roomID][userID]; !ok {
+		return ErrNotWaiting
+	}
+
+	delete(w.waiting[roomID], userID)
+	if len(w.waiting[roomID]) == 0 {
+		delete(w.waiting, roomID)
+	}
+	return nil
+}
+
+// IsWaitingUser checks if a user is in the room's waiting set
+func (w *WaitingRoom) IsWaitingUser(ctx context.Context, roomID, userID uuid.UUID) (bool, error) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	_, ok := w.waiting[roomID][userID]
+	return ok, nil
+}
+
+// GetWaitingUsers returns all users waiting for the room
+func (w *WaitingRoom) GetWaitingUsers(ctx context.Context, roomID uuid.UUID) ([]uuid.UUID, error) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	userIDs := make([]uuid.UUID, 0, len(w.waiting[roomID]))
+	for userID := range w.waiting[roomID] {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
+
+// DeleteWaitingUsers removes the room's waiting set
+func (w *WaitingRoom) DeleteWaitingUsers(ctx context.Context, roomID uuid.UUID) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	delete(w.waiting, roomID)
+	return nil
+}
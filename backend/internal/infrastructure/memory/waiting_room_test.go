@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+func TestWaitingRoom_AddAndRemove(t *testing.T) {
+	repo := NewWaitingRoom()
+	ctx := context.Background()
+	roomID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+
+	repo.AddWaitingUser(ctx, roomID, alice)
+	repo.AddWaitingUser(ctx, roomID, alice)
+	repo.AddWaitingUser(ctx, roomID, bob)
+	repo.AddWaitingUser(ctx, uuid.New(), uuid.New())
+
+	users, _ := repo.GetWaitingUsers(ctx, roomID)
+	if len(users) != 2 {
+		t.Errorf("Expected 2 waiting users, got %d", len(users))
+	}
+
+	if waiting, _ := repo.IsWaitingUser(ctx, roomID, alice); !waiting {
+		t.Error("Expected alice to be waiting")
+	}
+
+	if err := repo.RemoveWaitingUser(ctx, roomID, alice); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if waiting, _ := repo.IsWaitingUser(ctx, roomID, alice); waiting {
+		t.Error("Expected alice to be removed")
+	}
+
+	err := repo.RemoveWaitingUser(ctx, roomID, alice)
+	if !errors.Is(err, ErrNotWaiting) || !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected ErrNotWaiting, got %v", err)
+	}
+}
+
+func TestWaitingRoom_DeleteWaitingUsers(t *testing.T) {
+	repo := NewWaitingRoom()
+	ctx := context.Background()
+	roomID := uuid.New()
+
+	repo.AddWaitingUser(ctx, roomID, uuid.New())
+	repo.AddWaitingUser(ctx, roomID, uuid.New())
+
+	if err := repo.DeleteWaitingUsers(ctx, roomID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	users, _ := repo.GetWaitingUsers(ctx, roomID)
+	if len(users) != 0 {
+		t.Errorf("Expected no waiting users, got %d", len(users))
+	}
+}
@@ -0,0 +1,201 @@
+// Package metrics exports the pod's metrics for Prometheus
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metric names used by the HPA and dashboards (README: Kubernetes構成)
+const (
+	WebSocketConnections = "websocket_connections"
+	ActiveRooms          = "rooms_active"
+	RoomParticipants     = "room_participants"
+	MessagesDelivered    = "realtime_messages_total"
+	FanoutDuration       = "realtime_fanout_duration_seconds"
+	NotifierErrors       = "realtime_notifier_errors_total"
+	SendBufferDrops      = "realtime_send_buffer_drops_total"
+	BestEffortFailures   = "usecase_best_effort_failures_total"
+)
+
+// roomCountsTTL bounds how often the room counts are read from the database
+const roomCountsTTL = 30 * time.Second
+
+var (
+	// participantBuckets fit rooms up to a few times model.DefaultMaxCapacity
+	participantBuckets = []float64{1, 2, 3, 5, 10, 25, 50, 100}
+	// fanoutBuckets range from a single local client to a large room across pods
+	fanoutBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+)
+
+// Metrics holds the metrics of a realtime-hub pod
+// It implements realtime.Metrics for the hub and service.FailureCounter for the usecases,
+// and serves all metrics on a scrape
+type Metrics struct {
+	registry *prometheus.Registry
+	handler  http.Handler
+	rooms    *roomCollector
+
+	messages       *prometheus.CounterVec
+	fanout         *prometheus.HistogramVec
+	notifierErrors *prometheus.CounterVec
+	drops          prometheus.Counter
+	failures       *prometheus.CounterVec
+}
+
+var _ service.FailureCounter = (*Metrics)(nil)
+
+// NewMetrics creates the metrics of a pod
+// connections reports the pod's WebSocket connections; the room metrics describe the whole
+// cluster, so they are only exported by the leader set with SetLeader
+func NewMetrics(connections func() int, rooms repository.Room) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		rooms:    newRoomCollector(rooms),
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MessagesDelivered,
+			Help: "Messages fanned out to at least one local client by message type.",
+		}, []string{"type"}),
+		fanout: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    FanoutDuration,
+			Help:    "Time to queue a message on the send buffers of its local recipients.",
+			Buckets: fanoutBuckets,
+		}, []string{"type"}),
+		notifierErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: NotifierErrors,
+			Help: "Failed realtime notifier calls by method.",
+		}, []string{"method"}),
+		drops: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: SendBufferDrops,
+			Help: "Clients disconnected because their send buffer was full.",
+		}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: BestEffortFailures,
+			Help: "Failed usecase steps that did not fail the request or job by step.",
+		}, []string{"step"}),
+	}
+	m.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: WebSocketConnections,
+			Help: "WebSocket connections served by this pod.",
+		}, func() float64 {
+			return float64(connections())
+		}),
+		m.rooms,
+		m.messages,
+		m.fanout,
+		m.notifierErrors,
+		m.drops,
+		m.failures,
+	)
+	// 一部のメトリクスを取得できなくても残りは出力する
+	m.handler = promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+	return m
+}
+
+// SetLeader makes the room metrics exported only while isLeader reports true
+// Call it before serving; until then the pod exports no room metrics
+func (m *Metrics) SetLeader(isLeader func() bool) {
+	m.rooms.mu.Lock()
+	defer m.rooms.mu.Unlock()
+	m.rooms.isLeader = isLeader
+}
+
+// ServeHTTP serves the metrics in the Prometheus exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.handler.ServeHTTP(w, r)
+}
+
+// MessageDelivered records a message fanned out to the local clients and how long it took
+func (m *Metrics) MessageDelivered(messageType model.MessageType, elapsed time.Duration) {
+	m.messages.WithLabelValues(string(messageType)).Inc()
+	m.fanout.WithLabelValues(string(messageType)).Observe(elapsed.Seconds())
+}
+
+// SendBufferDropped records a client dropped because its send buffer was full
+func (m *Metrics) SendBufferDropped() {
+	m.drops.Inc()
+}
+
+// CountFailure records a failed best-effort usecase step
+func (m *Metrics) CountFailure(step string) {
+	m.failures.WithLabelValues(step).Inc()
+}
+
+// roomCollector reports the active rooms and a histogram of their participants from the room repository
+// The counts are cached for roomCountsTTL so scrapes don't load the database
+type roomCollector struct {
+	rooms        repository.Room
+	activeRooms  *prometheus.Desc
+	participants *prometheus.Desc
+	now          func() time.Time
+
+	mu       sync.Mutex
+	isLeader func() bool
+	counts   repository.RoomCounts
+	loadedAt time.Time
+}
+
+func newRoomCollector(rooms repository.Room) *roomCollector {
+	return &roomCollector{
+		rooms:        rooms,
+		activeRooms:  prometheus.NewDesc(ActiveRooms, "Rooms that have not expired, exported by the leader.", nil, nil),
+		participants: prometheus.NewDesc(RoomParticipants, "Participants per active room, exported by the leader.", nil, nil),
+		now:          time.Now,
+		isLeader:     func() bool { return false },
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *roomCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.activeRooms
+	ch <- c.participants
+}
+
+// Collect implements prometheus.Collector
+func (c *roomCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// クラスタ全体の値なので、リーダー以外が出力すると Pod 間の合計が重複する
+	if !c.isLeader() {
+		return
+	}
+
+	if c.loadedAt.IsZero() || c.now().Sub(c.loadedAt) >= roomCountsTTL {
+		counts, err := c.rooms.CountActiveRooms(context.Background())
+		if err != nil {
+			err = fmt.Errorf("failed to count active rooms: %w", err)
+			ch <- prometheus.NewInvalidMetric(c.activeRooms, err)
+			ch <- prometheus.NewInvalidMetric(c.participants, err)
+			return
+		}
+		c.counts = counts
+		c.loadedAt = c.now()
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.activeRooms, prometheus.GaugeValue, float64(c.counts.Rooms))
+	ch <- prometheus.MustNewConstHistogram(c.participants, uint64(c.counts.Rooms), float64(c.counts.Participants), participantsPerRoom(c.counts))
+}
+
+// participantsPerRoom returns the cumulative bucket counts of the participants per room histogram
+func participantsPerRoom(counts repository.RoomCounts) map[float64]uint64 {
+	buckets := make(map[float64]uint64, len(participantBuckets))
+	for size, rooms := range counts.BySize {
+		for _, upper := range participantBuckets {
+			if float64(size) <= upper {
+				buckets[upper] += uint64(rooms)
+			}
+		}
+	}
+	return buckets
+}
@@ -0,0 +1,222 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// countingRooms is a repository.Room that counts the reads of the room counts
+type countingRooms struct {
+	repository.Room
+	reads int
+	err   error
+}
+
+func (r *countingRooms) CountActiveRooms(ctx context.Context) (repository.RoomCounts, error) {
+	r.reads++
+	if r.err != nil {
+		return repository.RoomCounts{}, r.err
+	}
+	return r.Room.CountActiveRooms(ctx)
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	return rec.Body.String()
+}
+
+func expectLines(t *testing.T, output string, lines ...string) {
+	t.Helper()
+
+	for _, line := range lines {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("Expected line %q in output:\n%s", line, output)
+		}
+	}
+}
+
+func TestMetrics_Scrape(t *testing.T) {
+	ctx := context.Background()
+	rooms := memory.NewRoom()
+
+	empty := model.NewRoom("Empty", uuid.New(), false)
+	full := model.NewRoom("Full", uuid.New(), false)
+	for range 3 {
+		full.AddParticipant(uuid.New())
+	}
+	for _, room := range []*model.Room{empty, full} {
+		if err := rooms.Create(ctx, room); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	m := NewMetrics(func() int { return 7 }, rooms)
+	m.SetLeader(func() bool { return true })
+	m.MessageDelivered(model.MessageTypeChatMessage, 2*time.Millisecond)
+	m.MessageDelivered(model.MessageTypeChatMessage, 20*time.Millisecond)
+	m.SendBufferDropped()
+	m.CountFailure("publish_event")
+
+	expectLines(t, scrape(t, m),
+		"# TYPE websocket_connections gauge",
+		"websocket_connections 7",
+		"# TYPE rooms_active gauge",
+		"rooms_active 2",
+		"# TYPE room_participants histogram",
+		`room_participants_bucket{le="1"} 1`,
+		`room_participants_bucket{le="2"} 1`,
+		`room_participants_bucket{le="3"} 2`,
+		`room_participants_bucket{le="+Inf"} 2`,
+		"room_participants_sum 3",
+		"room_participants_count 2",
+		`realtime_messages_total{type="chat_message"} 2`,
+		`realtime_fanout_duration_seconds_bucket{type="chat_message",le="0.005"} 1`,
+		`realtime_fanout_duration_seconds_count{type="chat_message"} 2`,
+		"realtime_send_buffer_drops_total 1",
+		`usecase_best_effort_failures_total{step="publish_event"} 1`,
+	)
+}
+
+func TestMetrics_Scrape_RoomsOnlyFromLeader(t *testing.T) {
+	rooms := &countingRooms{Room: memory.NewRoom()}
+	m := NewMetrics(func() int { return 1 }, rooms)
+
+	// リーダーが決まるまではどの Pod もルームのメトリクスを出力しない
+	output := scrape(t, m)
+	expectLines(t, output, "websocket_connections 1")
+	if strings.Contains(output, ActiveRooms) || rooms.reads != 0 {
+		t.Errorf("Expected no room metrics and no reads, got %d reads:\n%s", rooms.reads, output)
+	}
+
+	leader := false
+	m.SetLeader(func() bool { return leader })
+	if output := scrape(t, m); strings.Contains(output, ActiveRooms) {
+		t.Errorf("Expected no room metrics from a follower, got:\n%s", output)
+	}
+
+	leader = true
+	expectLines(t, scrape(t, m), "rooms_active 0", "room_participants_count 0")
+	if rooms.reads != 1 {
+		t.Errorf("Expected 1 read, got %d", rooms.reads)
+	}
+}
+
+func TestMetrics_Scrape_CachesRoomCounts(t *testing.T) {
+	ctx := context.Background()
+	rooms := &countingRooms{Room: memory.NewRoom()}
+	m := NewMetrics(func() int { return 0 }, rooms)
+	m.SetLeader(func() bool { return true })
+	now := time.Now()
+	m.rooms.now = func() time.Time { return now }
+
+	scrape(t, m)
+	rooms.Create(ctx, model.NewRoom("New", uuid.New(), false))
+
+	// TTL 内は前回の値を返す
+	expectLines(t, scrape(t, m), "rooms_active 0")
+	if rooms.reads != 1 {
+		t.Errorf("Expected 1 read within the TTL, got %d", rooms.reads)
+	}
+
+	now = now.Add(roomCountsTTL)
+	expectLines(t, scrape(t, m), "rooms_active 1")
+	if rooms.reads != 2 {
+		t.Errorf("Expected 2 reads after the TTL, got %d", rooms.reads)
+	}
+}
+
+func TestMetrics_Scrape_RoomsUnavailable(t *testing.T) {
+	rooms := &countingRooms{Room: memory.NewRoom(), err: errors.New("database unavailable")}
+	m := NewMetrics(func() int { return 1 }, rooms)
+	m.SetLeader(func() bool { return true })
+
+	// ルーム数を取得できなくても接続数は出力する
+	output := scrape(t, m)
+	expectLines(t, output, "websocket_connections 1")
+	if strings.Contains(output, ActiveRooms) {
+		t.Errorf("Expected no room metrics, got:\n%s", output)
+	}
+
+	// 失敗はキャッシュせず次のスクレイプで読み直す
+	rooms.err = nil
+	expectLines(t, scrape(t, m), "rooms_active 0")
+	if rooms.reads != 2 {
+		t.Errorf("Expected 2 reads, got %d", rooms.reads)
+	}
+}
+
+func TestMetrics_Notifier_CountsErrors(t *testing.T) {
+	ctx := context.Background()
+	m := NewMetrics(func() int { return 0 }, memory.NewRoom())
+	notifier := m.Notifier(&errNotifier{err: errors.New("redis unavailable")})
+
+	roomID := uuid.New()
+	notifier.NotifyRoomJoined(ctx, roomID, uuid.New(), "Alice")
+	notifier.NotifyRoomJoined(ctx, roomID, uuid.New(), "Bob")
+	if err := notifier.NotifyRoomLeft(ctx, roomID, uuid.New(), "Carol"); err == nil {
+		t.Error("Expected the notifier error to be returned")
+	}
+
+	if got := testutil.ToFloat64(m.notifierErrors.WithLabelValues("NotifyRoomJoined")); got != 2 {
+		t.Errorf("Expected 2 NotifyRoomJoined errors, got %v", got)
+	}
+	expectLines(t, scrape(t, m),
+		"# TYPE realtime_notifier_errors_total counter",
+		`realtime_notifier_errors_total{method="NotifyRoomJoined"} 2`,
+		`realtime_notifier_errors_total{method="NotifyRoomLeft"} 1`,
+	)
+}
+
+// errNotifier is a service.RealtimeNotifier whose calls all fail with err
+type errNotifier struct {
+	err error
+}
+
+func (n *errNotifier) NotifyRoomJoined(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	return n.err
+}
+
+func (n *errNotifier) NotifyRoomLeft(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	return n.err
+}
+
+func (n *errNotifier) NotifyUserMuted(ctx context.Context, roomID, actorID, userID uuid.UUID, isMuted bool) error {
+	return n.err
+}
+
+func (n *errNotifier) BroadcastChatMessage(ctx context.Context, message *model.Message) error {
+	return n.err
+}
+
+func (n *errNotifier) SendDirectMessage(ctx context.Context, message *model.Message) error {
+	return n.err
+}
+
+func (n *errNotifier) NotifyRoomUpdate(ctx context.Context, room *model.Room) error {
+	return n.err
+}
+
+func (n *errNotifier) NotifyHostChanged(ctx context.Context, roomID, previousHostID, newHostID uuid.UUID, reason string) error {
+	return n.err
+}
+
+func (n *errNotifier) NotifyRoomClosed(ctx context.Context, roomID uuid.UUID, reason string) error {
+	return n.err
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Notifier counts the failed calls of a service.RealtimeNotifier by method
+type Notifier struct {
+	next   service.RealtimeNotifier
+	errors *prometheus.CounterVec
+}
+
+var _ service.RealtimeNotifier = (*Notifier)(nil)
+
+// Notifier wraps next so its errors are counted in NotifierErrors
+func (m *Metrics) Notifier(next service.RealtimeNotifier) *Notifier {
+	return &Notifier{next: next, errors: m.notifierErrors}
+}
+
+// NotifyRoomJoined notifies all participants that a user joined the room
+func (n *Notifier) NotifyRoomJoined(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	return n.count("NotifyRoomJoined", n.next.NotifyRoomJoined(ctx, roomID, userID, userName))
+}
+
+// NotifyRoomLeft notifies all participants that a user left the room
+func (n *Notifier) NotifyRoomLeft(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	return n.count("NotifyRoomLeft", n.next.NotifyRoomLeft(ctx, roomID, userID, userName))
+}
+
+// NotifyUserMuted notifies all participants that the actor muted or unmuted a user
+func (n *Notifier) NotifyUserMuted(ctx context.Context, roomID, actorID, userID uuid.UUID, isMuted bool) error {
+	return n.count("NotifyUserMuted", n.next.NotifyUserMuted(ctx, roomID, actorID, userID, isMuted))
+}
+
+// BroadcastChatMessage broadcasts a chat message to all room participants
+func (n *Notifier) BroadcastChatMessage(ctx context.Context, message *model.Message) error {
+	return n.count("BroadcastChatMessage", n.next.BroadcastChatMessage(ctx, message))
+}
+
+// SendDirectMessage sends a direct message to a specific user
+func (n *Notifier) SendDirectMessage(ctx context.Context, message *model.Message) error {
+	return n.count("SendDirectMessage", n.next.SendDirectMessage(ctx, message))
+}
+
+// NotifyRoomUpdate notifies participants about room setting changes
+func (n *Notifier) NotifyRoomUpdate(ctx context.Context, room *model.Room) error {
+	return n.count("NotifyRoomUpdate", n.next.NotifyRoomUpdate(ctx, room))
+}
+
+// NotifyHostChanged notifies all participants that the host role moved to another user
+func (n *Notifier) NotifyHostChanged(ctx context.Context, roomID, previousHostID, newHostID uuid.UUID, reason string) error {
+	return n.count("NotifyHostChanged", n.next.NotifyHostChanged(ctx, roomID, previousHostID, newHostID, reason))
+}
+
+// NotifyRoomClosed notifies the remaining participants that the room ended and disconnects them
+func (n *Notifier) NotifyRoomClosed(ctx context.Context, roomID uuid.UUID, reason string) error {
+	return n.count("NotifyRoomClosed", n.next.NotifyRoomClosed(ctx, roomID, reason))
+}
+
+func (n *Notifier) count(method string, err error) error {
+	if err != nil {
+		n.errors.WithLabelValues(method).Inc()
+	}
+	return err
+}
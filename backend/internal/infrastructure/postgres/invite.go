@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInviteNotFound is returned when an invite row does not exist
+	ErrInviteNotFound = fmt.Errorf("invite %w", repository.ErrNotFound)
+
+	// ErrInviteUnavailable is returned when incrementing the uses of a revoked or used up invite
+	ErrInviteUnavailable = fmt.Errorf("invite %w", repository.ErrConflict)
+)
+
+const inviteColumns = `id, room_id, created_by, COALESCE(email, ''), role, max_uses, uses, created_at, expires_at, revoked_at`
+
+// Invite is a SQL implementation of repository.Invite backed by the invites table
+type Invite struct {
+	db *sql.DB
+}
+
+var _ repository.Invite = (*Invite)(nil)
+
+// NewInvite creates a new SQL Invite repository
+func NewInvite(db *sql.DB) *Invite {
+	return &Invite{db: db}
+}
+
+// Create creates a new invite
+func (i *Invite) Create(ctx context.Context, invite *model.Invite) error {
+	_, err := conn(ctx, i.db).ExecContext(ctx,
+		`INSERT INTO invites (id, room_id, created_by, email, role, max_uses, uses, created_at, expires_at, revoked_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10)`,
+		invite.ID, invite.RoomID, invite.CreatedBy, invite.Email, invite.Role, invite.MaxUses, invite.Uses,
+		invite.CreatedAt.UTC(), invite.ExpiresAt.UTC(), nullRevokedAt(invite),
+	)
+	return err
+}
+
+// GetByID retrieves an invite by ID
+func (i *Invite) GetByID(ctx context.Context, id uuid.UUID) (*model.Invite, error) {
+	invite, err := scanInvite(conn(ctx, i.db).QueryRowContext(ctx, `SELECT `+inviteColumns+` FROM invites WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInviteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+// GetByRoomID retrieves the invites of a room, oldest first
+func (i *Invite) GetByRoomID(ctx context.Context, roomID uuid.UUID) ([]*model.Invite, error) {
+	rows, err := conn(ctx, i.db).QueryContext(ctx, `SELECT `+inviteColumns+` FROM invites WHERE room_id = $1 ORDER BY created_at`, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invites := []*model.Invite{}
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return nil, err
+		}
+		invites = append(invites, invite)
+	}
+
+	return invites, rows.Err()
+}
+
+// Update updates an existing invite, keeping the stored uses
+func (i *Invite) Update(ctx context.Context, invite *model.Invite) error {
+	result, err := conn(ctx, i.db).ExecContext(ctx,
+		`UPDATE invites SET email = NULLIF($2, ''), role = $3, max_uses = $4, expires_at = $5, revoked_at = $6 WHERE id = $1`,
+		invite.ID, invite.Email, invite.Role, invite.MaxUses, invite.ExpiresAt.UTC(), nullRevokedAt(invite),
+	)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result, ErrInviteNotFound)
+}
+
+// IncrementUses records a use of an invite that is not revoked and has uses left
+// The conditions are checked by the UPDATE itself, so concurrent redemptions cannot exceed max_uses
+func (i *Invite) IncrementUses(ctx context.Context, id uuid.UUID) error {
+	db := conn(ctx, i.db)
+	result, err := db.ExecContext(ctx,
+		`UPDATE invites SET uses = uses + 1 WHERE id = $1 AND revoked_at IS NULL AND (max_uses = 0 OR uses < max_uses)`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(result, ErrInviteUnavailable); err != nil {
+		// 更新されなかった理由が存在しないことか使えないことかを区別する
+		var exists bool
+		if err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM invites WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrInviteNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func scanInvite(row scanner) (*model.Invite, error) {
+	var invite model.Invite
+	var revokedAt sql.NullTime
+	if err := row.Scan(
+		&invite.ID, &invite.RoomID, &invite.CreatedBy, &invite.Email, &invite.Role, &invite.MaxUses, &invite.Uses,
+		&invite.CreatedAt, &invite.ExpiresAt, &revokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		invite.RevokedAt = &revokedAt.Time
+	}
+	return &invite, nil
+}
+
+// nullRevokedAt converts the invite's revocation time for storage
+func nullRevokedAt(invite *model.Invite) sql.NullTime {
+	if invite.RevokedAt == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: invite.RevokedAt.UTC(), Valid: true}
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+func TestInvite_CreateGetAndUpdate(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewInvite(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	room := model.NewRoom("Test Room", host.ID, false)
+	if err := NewRoom(db).Create(ctx, room); err != nil {
+		t.Fatalf("Expected no error creating room, got %v", err)
+	}
+
+	invite := model.NewInvite(room.ID, host.ID, "alice@example.com", model.RolePresenter, time.Hour, 2)
+	if err := repo.Create(ctx, invite); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, invite.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.RoomID != room.ID || got.CreatedBy != host.ID || got.Email != invite.Email || got.Role != model.RolePresenter || got.MaxUses != 2 {
+		t.Errorf("Expected %+v, got %+v", invite, got)
+	}
+	if got.IsRevoked() {
+		t.Error("Expected new invite not to be revoked")
+	}
+
+	if err := repo.IncrementUses(ctx, invite.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// got was read before the use, so Update must keep the stored uses
+	got.Revoke()
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	updated, _ := repo.GetByID(ctx, invite.ID)
+	if updated.Uses != 1 {
+		t.Errorf("Expected Uses 1, got %d", updated.Uses)
+	}
+	if !updated.IsRevoked() {
+		t.Error("Expected invite to be revoked after Update")
+	}
+}
+
+func TestInvite_GetByRoomID(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewInvite(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	room := model.NewRoom("Test Room", host.ID, false)
+	NewRoom(db).Create(ctx, room)
+
+	first := model.NewInvite(room.ID, host.ID, "", model.RoleAttendee, time.Hour, 0)
+	second := model.NewInvite(room.ID, host.ID, "", model.RoleViewer, time.Hour, 0)
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	repo.Create(ctx, second)
+	repo.Create(ctx, first)
+
+	invites, err := repo.GetByRoomID(ctx, room.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(invites) != 2 || invites[0].ID != first.ID || invites[1].ID != second.ID {
+		t.Errorf("Expected invites ordered by creation time, got %+v", invites)
+	}
+
+	// ルーム削除で招待も削除される
+	if err := NewRoom(db).Delete(ctx, room.ID); err != nil {
+		t.Fatalf("Expected no error deleting room, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, first.ID); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("Expected ErrInviteNotFound after room deletion, got %v", err)
+	}
+}
+
+func TestInvite_IncrementUses(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewInvite(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	room := model.NewRoom("Test Room", host.ID, false)
+	if err := NewRoom(db).Create(ctx, room); err != nil {
+		t.Fatalf("Expected no error creating room, got %v", err)
+	}
+
+	limited := model.NewInvite(room.ID, host.ID, "", model.RoleAttendee, time.Hour, 1)
+	revoked := model.NewInvite(room.ID, host.ID, "", model.RoleAttendee, time.Hour, 0)
+	revoked.Revoke()
+	for _, invite := range []*model.Invite{limited, revoked} {
+		if err := repo.Create(ctx, invite); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if err := repo.IncrementUses(ctx, limited.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.IncrementUses(ctx, limited.ID); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("Expected repository.ErrConflict for a used up invite, got %v", err)
+	}
+	if err := repo.IncrementUses(ctx, revoked.ID); !errors.Is(err, ErrInviteUnavailable) {
+		t.Errorf("Expected ErrInviteUnavailable for a revoked invite, got %v", err)
+	}
+
+	stored, _ := repo.GetByID(ctx, limited.ID)
+	if stored.Uses != 1 {
+		t.Errorf("Expected Uses 1, got %d", stored.Uses)
+	}
+}
+
+func TestInvite_NotFound(t *testing.T) {
+	repo := NewInvite(newTestDB(t))
+	ctx := context.Background()
+
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+
+	missing := model.NewInvite(uuid.New(), uuid.New(), "", model.RoleAttendee, time.Hour, 0)
+	if err := repo.Update(ctx, missing); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("Expected ErrInviteNotFound, got %v", err)
+	}
+	if err := repo.IncrementUses(ctx, missing.ID); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("Expected ErrInviteNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"testing"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+// newTestDB opens a migrated in-memory SQLite database
+// SQLite accepts the $N placeholders and the portable schema used by the migrations
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Expected no error opening database, got %v", err)
+	}
+	// :memory: はコネクションごとに別DBになるため1本に制限する
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	if err := Migrate(context.Background(), db); err != nil {
+		t.Fatalf("Expected no error migrating, got %v", err)
+	}
+	return db
+}
+
+func TestMigrate_Idempotent(t *testing.T) {
+	db := newTestDB(t)
+
+	if err := Migrate(context.Background(), db); err != nil {
+		t.Fatalf("Expected second migration run to succeed, got %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	files, _ := fs.Glob(migrations, "migrations/*.sql")
+	if count != len(files) {
+		t.Errorf("Expected %d applied migrations, got %d", len(files), count)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- comment
+CREATE TABLE a (id INTEGER);
+
+-- another comment
+CREATE INDEX idx_a ON a(id);
+`
+	statements := splitStatements(script)
+	if len(statements) != 2 {
+		t.Fatalf("Expected 2 statements, got %d: %q", len(statements), statements)
+	}
+	if statements[0] != "CREATE TABLE a (id INTEGER)" {
+		t.Errorf("Unexpected first statement %q", statements[0])
+	}
+}
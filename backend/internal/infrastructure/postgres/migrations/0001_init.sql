@@ -0,0 +1,35 @@
+-- ユーザーテーブル
+CREATE TABLE users (
+    id UUID PRIMARY KEY,
+    google_id VARCHAR UNIQUE,
+    email VARCHAR,
+    name VARCHAR,
+    avatar_url VARCHAR,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- ルームテーブル
+CREATE TABLE rooms (
+    id UUID PRIMARY KEY,
+    name VARCHAR,
+    host_id UUID REFERENCES users(id),
+    is_waiting_room BOOLEAN DEFAULT true,
+    max_capacity INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- 参加者テーブル
+CREATE TABLE participants (
+    room_id UUID REFERENCES rooms(id),
+    user_id UUID REFERENCES users(id),
+    is_host BOOLEAN DEFAULT false,
+    is_muted BOOLEAN DEFAULT false,
+    joined_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (room_id, user_id)
+);
+
+-- インデックス
+CREATE INDEX idx_rooms_host_id ON rooms(host_id);
+CREATE INDEX idx_rooms_expires_at ON rooms(expires_at);
+CREATE INDEX idx_participants_room_id ON participants(room_id);
@@ -0,0 +1,2 @@
+-- 共同ホスト（ホスト退出時の後継候補）
+ALTER TABLE participants ADD COLUMN is_co_host BOOLEAN NOT NULL DEFAULT false;
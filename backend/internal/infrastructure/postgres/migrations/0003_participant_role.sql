@@ -0,0 +1,5 @@
+-- 参加者の役割（host / co_host / presenter / attendee / viewer）
+ALTER TABLE participants ADD COLUMN role VARCHAR NOT NULL DEFAULT 'attendee';
+UPDATE participants SET role = 'co_host' WHERE is_co_host;
+UPDATE participants SET role = 'host' WHERE is_host;
+ALTER TABLE participants DROP COLUMN is_co_host;
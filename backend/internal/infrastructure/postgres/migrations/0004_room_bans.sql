@@ -0,0 +1,6 @@
+-- 再入室を禁止されたユーザー（ルームの存続期間中のみ有効）
+CREATE TABLE room_bans (
+    room_id UUID REFERENCES rooms(id),
+    user_id UUID REFERENCES users(id),
+    PRIMARY KEY (room_id, user_id)
+);
@@ -0,0 +1,3 @@
+-- ルームのロックとパスコード（bcryptハッシュ）
+ALTER TABLE rooms ADD COLUMN is_locked BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE rooms ADD COLUMN passcode_hash VARCHAR;
@@ -0,0 +1,15 @@
+-- ルームへの招待（トークンは署名のみで保存しない）
+CREATE TABLE invites (
+    id UUID PRIMARY KEY,
+    room_id UUID REFERENCES rooms(id),
+    created_by UUID REFERENCES users(id),
+    email VARCHAR,
+    role VARCHAR NOT NULL,
+    max_uses INTEGER NOT NULL DEFAULT 0,
+    uses INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_invites_room_id ON invites(room_id);
@@ -0,0 +1,6 @@
+-- 予定された会議（未設定の場合は即時のルーム）
+ALTER TABLE rooms ADD COLUMN scheduled_start TIMESTAMP;
+ALTER TABLE rooms ADD COLUMN scheduled_end TIMESTAMP;
+ALTER TABLE rooms ADD COLUMN early_join_minutes INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_rooms_host_scheduled_start ON rooms(host_id, scheduled_start);
@@ -0,0 +1,42 @@
+-- 定期的な会議（各回のルームは rooms に作成される）
+CREATE TABLE series (
+    id UUID PRIMARY KEY,
+    name VARCHAR,
+    host_id UUID REFERENCES users(id),
+    is_waiting_room BOOLEAN NOT NULL DEFAULT FALSE,
+    max_capacity INTEGER NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    duration_minutes INTEGER NOT NULL,
+    early_join_minutes INTEGER NOT NULL DEFAULT 0,
+    rrule VARCHAR NOT NULL,
+    time_zone VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_series_host_id ON series(host_id);
+
+CREATE TABLE series_co_hosts (
+    series_id UUID REFERENCES series(id),
+    user_id UUID REFERENCES users(id),
+    PRIMARY KEY (series_id, user_id)
+);
+
+-- 中止した回（EXDATE）
+CREATE TABLE series_exceptions (
+    series_id UUID REFERENCES series(id),
+    starts_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (series_id, starts_at)
+);
+
+-- 参加時に共同ホストになるユーザー
+CREATE TABLE room_co_hosts (
+    room_id UUID REFERENCES rooms(id),
+    user_id UUID REFERENCES users(id),
+    PRIMARY KEY (room_id, user_id)
+);
+
+-- 定期的な会議の回として作成されたルーム（シリーズ削除後も残るため外部キーは付けない）
+ALTER TABLE rooms ADD COLUMN series_id UUID;
+ALTER TABLE rooms ADD COLUMN occurrence_start TIMESTAMP;
+
+CREATE UNIQUE INDEX idx_rooms_series_occurrence ON rooms(series_id, occurrence_start);
@@ -0,0 +1,3 @@
+-- カレンダーの更新・取消を順序付けるSEQUENCE（iCalendar）
+ALTER TABLE rooms ADD COLUMN schedule_sequence INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE series ADD COLUMN sequence INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- ルームごとの設定（既存のルームは従来どおり空になったら削除し、24時間まで延長できる）
+ALTER TABLE rooms ADD COLUMN auto_delete BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE rooms ADD COLUMN max_extension_minutes INTEGER NOT NULL DEFAULT 1440;
+ALTER TABLE rooms ADD COLUMN extended_minutes INTEGER NOT NULL DEFAULT 0;
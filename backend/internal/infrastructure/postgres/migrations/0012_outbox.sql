@@ -0,0 +1,12 @@
+-- 配信待ちのドメインイベント（変更と同じトランザクションで書き込む）
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY,
+    type VARCHAR NOT NULL,
+    room_id UUID NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_outbox_events_next_attempt_at ON outbox_events(next_attempt_at);
@@ -0,0 +1,2 @@
+-- 楽観的排他制御のバージョン（UPDATEごとに1増える）
+ALTER TABLE series ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+-- 各回のルームの設定（既存のシリーズは従来どおり空になったら削除し、24時間まで延長できる）
+ALTER TABLE series ADD COLUMN auto_delete BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE series ADD COLUMN max_extension_minutes INTEGER NOT NULL DEFAULT 1440;
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ErrEventNotFound is returned when an outbox_events row does not exist
+var ErrEventNotFound = fmt.Errorf("event %w", repository.ErrNotFound)
+
+const eventColumns = `id, type, room_id, payload, occurred_at, attempts, next_attempt_at`
+
+// Outbox is a SQL implementation of repository.Outbox backed by the outbox_events table
+// Events added within a Transactor transaction are committed together with the change they describe
+type Outbox struct {
+	db *sql.DB
+}
+
+var _ repository.Outbox = (*Outbox)(nil)
+
+// NewOutbox creates a new SQL Outbox repository
+func NewOutbox(db *sql.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+// Add stores an event to be delivered
+func (o *Outbox) Add(ctx context.Context, event *model.Event) error {
+	_, err := conn(ctx, o.db).ExecContext(ctx,
+		`INSERT INTO outbox_events (`+eventColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		event.ID, event.Type, event.RoomID, string(event.Payload), event.OccurredAt.UTC(), event.Attempts, event.NextAttemptAt.UTC(),
+	)
+	return err
+}
+
+// GetDue returns up to limit events whose NextAttemptAt is not after now, oldest first
+func (o *Outbox) GetDue(ctx context.Context, now time.Time, limit int) ([]*model.Event, error) {
+	rows, err := conn(ctx, o.db).QueryContext(ctx,
+		`SELECT `+eventColumns+` FROM outbox_events WHERE next_attempt_at <= $1 ORDER BY occurred_at LIMIT $2`,
+		now.UTC(), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*model.Event{}
+	for rows.Next() {
+		var event model.Event
+		var payload string
+		if err := rows.Scan(
+			&event.ID, &event.Type, &event.RoomID, &payload, &event.OccurredAt, &event.Attempts, &event.NextAttemptAt,
+		); err != nil {
+			return nil, err
+		}
+		event.Payload = []byte(payload)
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// Reschedule stores the event's Attempts and NextAttemptAt after a failed delivery
+func (o *Outbox) Reschedule(ctx context.Context, event *model.Event) error {
+	result, err := conn(ctx, o.db).ExecContext(ctx,
+		`UPDATE outbox_events SET attempts = $2, next_attempt_at = $3 WHERE id = $1`,
+		event.ID, event.Attempts, event.NextAttemptAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result, ErrEventNotFound)
+}
+
+// Delete removes a delivered event
+func (o *Outbox) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, o.db).ExecContext(ctx, `DELETE FROM outbox_events WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,422 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrRoomNotFound is returned when a room row does not exist
+	ErrRoomNotFound = fmt.Errorf("room %w", repository.ErrNotFound)
+
+	// ErrRoomConflict is returned when updating a room row whose version changed since it was read
+	ErrRoomConflict = fmt.Errorf("room %w", repository.ErrConflict)
+)
+
+const roomColumns = `id, COALESCE(name, ''), host_id, is_waiting_room, max_capacity, created_at, expires_at, is_locked, COALESCE(passcode_hash, ''),
+	scheduled_start, scheduled_end, early_join_minutes, schedule_sequence, series_id, occurrence_start,
+	auto_delete, max_extension_minutes, extended_minutes, version`
+
+// roomDetailTables hold the rows owned by a room that are replaced together with it
+var roomDetailTables = []string{"participants", "room_bans", "room_co_hosts"}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Room is a SQL implementation of repository.Room
+// Participants, bans and designated co-hosts are stored in the participants, room_bans and room_co_hosts tables
+// and written in the same transaction as the room
+type Room struct {
+	db *sql.DB
+}
+
+var _ repository.Room = (*Room)(nil)
+
+// NewRoom creates a new SQL Room repository
+func NewRoom(db *sql.DB) *Room {
+	return &Room{db: db}
+}
+
+// Create creates a new room together with its participants
+func (r *Room) Create(ctx context.Context, room *model.Room) error {
+	start, end, earlyJoin, sequence := scheduleColumns(room)
+	seriesID, occurrenceStart := occurrenceColumns(room)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO rooms (id, name, host_id, is_waiting_room, max_capacity, created_at, expires_at, is_locked, passcode_hash,
+			scheduled_start, scheduled_end, early_join_minutes, schedule_sequence, series_id, occurrence_start,
+			auto_delete, max_extension_minutes, extended_minutes, version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+			room.ID, room.Name, room.HostID, room.IsWaitingRoom, room.MaxCapacity, room.CreatedAt.UTC(), room.ExpiresAt.UTC(),
+			room.IsLocked, room.PasscodeHash, start, end, earlyJoin, sequence, seriesID, occurrenceStart,
+			room.AutoDelete, room.MaxExtensionMinutes, room.ExtendedMinutes, room.Version,
+		); err != nil {
+			return err
+		}
+
+		return insertRoomDetails(ctx, tx, room)
+	})
+}
+
+// GetByID retrieves a room by ID
+func (r *Room) GetByID(ctx context.Context, id uuid.UUID) (*model.Room, error) {
+	room, err := scanRoom(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+roomColumns+` FROM rooms WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRoomNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loadRoomDetails(ctx, conn(ctx, r.db), room); err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// GetByHostID retrieves rooms by host ID, oldest first
+func (r *Room) GetByHostID(ctx context.Context, hostID uuid.UUID) ([]*model.Room, error) {
+	return r.list(ctx, `SELECT `+roomColumns+` FROM rooms WHERE host_id = $1 ORDER BY created_at`, hostID)
+}
+
+// GetUpcomingByHostID retrieves scheduled rooms by host ID that end after from, ordered by start time
+func (r *Room) GetUpcomingByHostID(ctx context.Context, hostID uuid.UUID, from time.Time) ([]*model.Room, error) {
+	return r.list(ctx,
+		`SELECT `+roomColumns+` FROM rooms WHERE host_id = $1 AND scheduled_end > $2 ORDER BY scheduled_start, created_at`,
+		hostID, from.UTC(),
+	)
+}
+
+// GetBySeriesID retrieves the rooms materialized for a series, ordered by occurrence
+func (r *Room) GetBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*model.Room, error) {
+	return r.list(ctx, `SELECT `+roomColumns+` FROM rooms WHERE series_id = $1 ORDER BY occurrence_start`, seriesID)
+}
+
+// Update updates an existing room and replaces its participants
+// The row is only updated while its version matches, so concurrent updates cannot overwrite each other
+func (r *Room) Update(ctx context.Context, room *model.Room) error {
+	start, end, earlyJoin, sequence := scheduleColumns(room)
+
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx,
+			`UPDATE rooms SET name = $2, host_id = $3, is_waiting_room = $4, max_capacity = $5, expires_at = $6,
+			is_locked = $7, passcode_hash = $8, scheduled_start = $9, scheduled_end = $10, early_join_minutes = $11,
+			schedule_sequence = $12, auto_delete = $13, max_extension_minutes = $14, extended_minutes = $15,
+			version = version + 1
+			WHERE id = $1 AND version = $16`,
+			room.ID, room.Name, room.HostID, room.IsWaitingRoom, room.MaxCapacity, room.ExpiresAt.UTC(),
+			room.IsLocked, room.PasscodeHash, start, end, earlyJoin, sequence,
+			room.AutoDelete, room.MaxExtensionMinutes, room.ExtendedMinutes, room.Version,
+		)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			// 更新されなかった理由が削除か競合かを区別する
+			var exists bool
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM rooms WHERE id = $1)`, room.ID).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return ErrRoomNotFound
+			}
+			return ErrRoomConflict
+		}
+
+		if err := deleteRoomDetails(ctx, tx, `room_id = $1`, room.ID); err != nil {
+			return err
+		}
+
+		return insertRoomDetails(ctx, tx, room)
+	})
+	if err != nil {
+		return err
+	}
+
+	room.Version++
+	return nil
+}
+
+// Delete deletes a room with its participants, bans, co-hosts and invites
+func (r *Room) Delete(ctx context.Context, id uuid.UUID) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := deleteRoomDetails(ctx, tx, `room_id = $1`, id); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM invites WHERE room_id = $1`, id); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM rooms WHERE id = $1`, id)
+		if err != nil {
+			return err
+		}
+
+		return requireAffected(result, ErrRoomNotFound)
+	})
+}
+
+// GetActiveRooms retrieves all active (non-expired) rooms, oldest first
+func (r *Room) GetActiveRooms(ctx context.Context) ([]*model.Room, error) {
+	return r.list(ctx, `SELECT `+roomColumns+` FROM rooms WHERE expires_at > $1 ORDER BY created_at`, time.Now().UTC())
+}
+
+// CountActiveRooms counts the active (non-expired) rooms by their number of participants in a single query
+func (r *Room) CountActiveRooms(ctx context.Context) (repository.RoomCounts, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT size, COUNT(*) FROM (
+			SELECT COUNT(p.user_id) AS size FROM rooms r LEFT JOIN participants p ON p.room_id = r.id
+			WHERE r.expires_at > $1 GROUP BY r.id
+		) sizes GROUP BY size`,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return repository.RoomCounts{}, err
+	}
+	defer rows.Close()
+
+	counts := repository.RoomCounts{BySize: make(map[int]int)}
+	for rows.Next() {
+		var size, rooms int
+		if err := rows.Scan(&size, &rooms); err != nil {
+			return repository.RoomCounts{}, err
+		}
+		counts.Rooms += rooms
+		counts.Participants += size * rooms
+		counts.BySize[size] = rooms
+	}
+	return counts, rows.Err()
+}
+
+// CleanupExpiredRooms removes expired rooms with their participants, bans, co-hosts and invites
+// The removed rooms are read in the same transaction, so they are returned with their participants
+func (r *Room) CleanupExpiredRooms(ctx context.Context) ([]*model.Room, error) {
+	now := time.Now().UTC()
+
+	var removed []*model.Room
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		removed, err = listRooms(ctx, tx, `SELECT `+roomColumns+` FROM rooms WHERE expires_at <= $1 ORDER BY created_at`, now)
+		if err != nil {
+			return err
+		}
+
+		if err := deleteRoomDetails(ctx, tx, `room_id IN (SELECT id FROM rooms WHERE expires_at <= $1)`, now); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM invites WHERE room_id IN (SELECT id FROM rooms WHERE expires_at <= $1)`, now,
+		); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM rooms WHERE expires_at <= $1`, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+func (r *Room) list(ctx context.Context, query string, args ...interface{}) ([]*model.Room, error) {
+	return listRooms(ctx, conn(ctx, r.db), query, args...)
+}
+
+// listRooms reads the rooms returned by query together with their details
+func listRooms(ctx context.Context, q queryer, query string, args ...interface{}) ([]*model.Room, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms := []*model.Room{}
+	for rows.Next() {
+		room, err := scanRoom(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// 行の走査が終わってから参加者を読み込む（接続を占有しないため）
+	for _, room := range rooms {
+		if err := loadRoomDetails(ctx, q, room); err != nil {
+			return nil, err
+		}
+	}
+
+	return rooms, nil
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRoom(row scanner) (*model.Room, error) {
+	var room model.Room
+	var start, end sql.NullTime
+	var earlyJoin, sequence int
+	var seriesID uuid.NullUUID
+	var occurrenceStart sql.NullTime
+	if err := row.Scan(
+		&room.ID, &room.Name, &room.HostID, &room.IsWaitingRoom, &room.MaxCapacity, &room.CreatedAt, &room.ExpiresAt,
+		&room.IsLocked, &room.PasscodeHash, &start, &end, &earlyJoin, &sequence, &seriesID, &occurrenceStart,
+		&room.AutoDelete, &room.MaxExtensionMinutes, &room.ExtendedMinutes, &room.Version,
+	); err != nil {
+		return nil, err
+	}
+
+	if start.Valid && end.Valid {
+		room.Schedule = &model.Schedule{StartsAt: start.Time, EndsAt: end.Time, EarlyJoinMinutes: earlyJoin, Sequence: sequence}
+	}
+	if seriesID.Valid && occurrenceStart.Valid {
+		room.Occurrence = &model.OccurrenceRef{SeriesID: seriesID.UUID, StartsAt: occurrenceStart.Time}
+	}
+
+	room.Participants = []model.Participant{}
+	return &room, nil
+}
+
+// scheduleColumns returns the schedule values to store, NULL for unscheduled rooms
+func scheduleColumns(room *model.Room) (start, end sql.NullTime, earlyJoin, sequence int) {
+	if room.Schedule == nil {
+		return sql.NullTime{}, sql.NullTime{}, 0, 0
+	}
+	return sql.NullTime{Time: room.Schedule.StartsAt.UTC(), Valid: true},
+		sql.NullTime{Time: room.Schedule.EndsAt.UTC(), Valid: true},
+		room.Schedule.EarlyJoinMinutes, room.Schedule.Sequence
+}
+
+// occurrenceColumns returns the series occurrence values to store, NULL for standalone rooms
+func occurrenceColumns(room *model.Room) (seriesID uuid.NullUUID, start sql.NullTime) {
+	if room.Occurrence == nil {
+		return uuid.NullUUID{}, sql.NullTime{}
+	}
+	return uuid.NullUUID{UUID: room.Occurrence.SeriesID, Valid: true},
+		sql.NullTime{Time: room.Occurrence.StartsAt.UTC(), Valid: true}
+}
+
+// loadRoomDetails loads the participants, bans and designated co-hosts of a room
+func loadRoomDetails(ctx context.Context, q queryer, room *model.Room) error {
+	if err := loadParticipants(ctx, q, room); err != nil {
+		return err
+	}
+
+	var err error
+	if room.BannedUserIDs, err = loadUserIDs(ctx, q, "room_bans", "room_id", room.ID); err != nil {
+		return err
+	}
+	room.CoHostIDs, err = loadUserIDs(ctx, q, "room_co_hosts", "room_id", room.ID)
+	return err
+}
+
+// insertRoomDetails inserts the participants, bans and designated co-hosts of a room
+func insertRoomDetails(ctx context.Context, q queryer, room *model.Room) error {
+	if err := insertParticipants(ctx, q, room); err != nil {
+		return err
+	}
+	if err := insertUserIDs(ctx, q, "room_bans", "room_id", room.ID, room.BannedUserIDs); err != nil {
+		return err
+	}
+	return insertUserIDs(ctx, q, "room_co_hosts", "room_id", room.ID, room.CoHostIDs)
+}
+
+// deleteRoomDetails deletes the rows in roomDetailTables matching the condition on room_id
+func deleteRoomDetails(ctx context.Context, q queryer, condition string, args ...interface{}) error {
+	for _, table := range roomDetailTables {
+		if _, err := q.ExecContext(ctx, `DELETE FROM `+table+` WHERE `+condition, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadParticipants(ctx context.Context, q queryer, room *model.Room) error {
+	rows, err := q.QueryContext(ctx,
+		`SELECT user_id, is_host, role, is_muted, joined_at FROM participants WHERE room_id = $1 ORDER BY joined_at`,
+		room.ID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p model.Participant
+		if err := rows.Scan(&p.UserID, &p.IsHost, &p.Role, &p.IsMuted, &p.JoinedAt); err != nil {
+			return err
+		}
+		room.Participants = append(room.Participants, p)
+	}
+
+	return rows.Err()
+}
+
+func insertParticipants(ctx context.Context, q queryer, room *model.Room) error {
+	for _, p := range room.Participants {
+		if _, err := q.ExecContext(ctx,
+			`INSERT INTO participants (room_id, user_id, is_host, role, is_muted, joined_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+			room.ID, p.UserID, p.IsHost, p.Role, p.IsMuted, p.JoinedAt.UTC(),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadUserIDs loads the user IDs of a (owner, user_id) link table such as room_bans
+func loadUserIDs(ctx context.Context, q queryer, table, ownerColumn string, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.QueryContext(ctx, `SELECT user_id FROM `+table+` WHERE `+ownerColumn+` = $1 ORDER BY user_id`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// insertUserIDs inserts the user IDs of a (owner, user_id) link table such as room_bans
+func insertUserIDs(ctx context.Context, q queryer, table, ownerColumn string, ownerID uuid.UUID, userIDs []uuid.UUID) error {
+	for _, userID := range userIDs {
+		if _, err := q.ExecContext(ctx,
+			`INSERT INTO `+table+` (`+ownerColumn+`, user_id) VALUES ($1, $2)`, ownerID, userID,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,397 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+func createTestUser(t *testing.T, db *sql.DB) *model.User {
+	t.Helper()
+
+	user := model.NewUser(uuid.NewString(), "test@example.com", "Test User", "")
+	if err := NewUser(db).Create(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error creating user, got %v", err)
+	}
+	return user
+}
+
+func TestRoom_CreateAndGetByID(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	guest := createTestUser(t, db)
+
+	room := model.NewRoom("Test Room", host.ID, true)
+	room.AddParticipant(host.ID)
+	room.AddParticipant(guest.ID)
+	room.Participants[1].JoinedAt = room.Participants[0].JoinedAt.Add(time.Second)
+	room.Participants[1].IsMuted = true
+	room.Participants[1].Role = model.RoleCoHost
+
+	if err := repo.Create(ctx, room); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, room.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Name != room.Name || got.HostID != room.HostID || got.IsWaitingRoom != room.IsWaitingRoom {
+		t.Errorf("Expected %+v, got %+v", room, got)
+	}
+	if got.MaxCapacity != room.MaxCapacity {
+		t.Errorf("Expected MaxCapacity %d, got %d", room.MaxCapacity, got.MaxCapacity)
+	}
+	if !got.ExpiresAt.Equal(room.ExpiresAt) {
+		t.Errorf("Expected ExpiresAt %v, got %v", room.ExpiresAt, got.ExpiresAt)
+	}
+	if len(got.Participants) != 2 {
+		t.Fatalf("Expected 2 participants, got %d", len(got.Participants))
+	}
+	if got.Participants[0].UserID != host.ID || !got.Participants[0].IsHost {
+		t.Errorf("Expected host as first participant, got %+v", got.Participants[0])
+	}
+	if got.Participants[1].UserID != guest.ID || !got.Participants[1].IsMuted || got.Participants[1].Role != model.RoleCoHost {
+		t.Errorf("Expected muted co-host guest as second participant, got %+v", got.Participants[1])
+	}
+}
+
+func TestRoom_CreateWithOptions(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+
+	room := model.NewRoomWithOptions("Office hours", host.ID, model.RoomOptions{
+		MaxCapacity: 4, Lifetime: 8 * time.Hour, MaxExtension: 2 * time.Hour, IsWaitingRoom: true,
+	})
+	if err := repo.Create(ctx, room); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, room.ID)
+	if got.MaxCapacity != 4 || got.AutoDelete || got.MaxExtensionMinutes != 120 || got.ExtendedMinutes != 0 || !got.IsWaitingRoom {
+		t.Errorf("Expected options %+v, got %+v", room, got)
+	}
+}
+
+func TestRoom_GetByID_NotFound(t *testing.T) {
+	repo := NewRoom(newTestDB(t))
+
+	if _, err := repo.GetByID(context.Background(), uuid.New()); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound, got %v", err)
+	}
+	if _, err := repo.GetByID(context.Background(), uuid.New()); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+}
+
+func TestRoom_Create_RollsBackOnParticipantError(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+
+	// 同じユーザーを2回登録すると主キー違反になる
+	room := model.NewRoom("Test Room", host.ID, false)
+	room.Participants = []model.Participant{
+		{UserID: host.ID, IsHost: true, JoinedAt: time.Now()},
+		{UserID: host.ID, IsHost: true, JoinedAt: time.Now()},
+	}
+
+	if err := repo.Create(ctx, room); err == nil {
+		t.Fatal("Expected error for duplicate participant")
+	}
+	if _, err := repo.GetByID(ctx, room.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected room insert to be rolled back, got %v", err)
+	}
+}
+
+func TestRoom_Update(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	guest := createTestUser(t, db)
+
+	room := model.NewRoom("Test Room", host.ID, false)
+	room.AddParticipant(host.ID)
+	repo.Create(ctx, room)
+
+	room.AddParticipant(guest.ID)
+	room.MuteParticipant(host.ID, guest.ID)
+	room.ExtendExpiry(time.Hour)
+	room.IsLocked = true
+	room.SetPasscode("1234")
+	if err := repo.Update(ctx, room); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, room.ID)
+	if len(got.Participants) != 2 {
+		t.Fatalf("Expected 2 participants, got %d", len(got.Participants))
+	}
+	if !got.IsLocked || got.CheckPasscode("1234") != nil {
+		t.Errorf("Expected lock and passcode to be stored, got locked=%v hash=%q", got.IsLocked, got.PasscodeHash)
+	}
+	if !got.ExpiresAt.Equal(room.ExpiresAt) {
+		t.Errorf("Expected ExpiresAt %v, got %v", room.ExpiresAt, got.ExpiresAt)
+	}
+	if got.ExtendedMinutes != 60 || got.MaxExtensionMinutes != room.MaxExtensionMinutes || !got.AutoDelete {
+		t.Errorf("Expected room options to be stored, got %+v", got)
+	}
+
+	room.KickParticipant(host.ID, guest.ID, true)
+	if err := repo.Update(ctx, room); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, _ = repo.GetByID(ctx, room.ID)
+	if len(got.Participants) != 1 {
+		t.Errorf("Expected 1 participant after removal, got %d", len(got.Participants))
+	}
+	if !got.IsBanned(guest.ID) || len(got.BannedUserIDs) != 1 {
+		t.Errorf("Expected guest to be banned, got %v", got.BannedUserIDs)
+	}
+
+	missing := model.NewRoom("Missing", host.ID, false)
+	if err := repo.Update(ctx, missing); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound, got %v", err)
+	}
+}
+
+func TestRoom_Update_Conflict(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+
+	room := model.NewRoom("Test Room", host.ID, false)
+	repo.Create(ctx, room)
+
+	first, _ := repo.GetByID(ctx, room.ID)
+	second, _ := repo.GetByID(ctx, room.ID)
+
+	first.IsLocked = true
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Expected version 2, got %d", first.Version)
+	}
+
+	// 古いバージョンからの更新は拒否される
+	second.Name = "Stale"
+	if err := repo.Update(ctx, second); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, room.ID)
+	if got.Name != "Test Room" || !got.IsLocked || got.Version != 2 {
+		t.Errorf("Expected the first update only, got %+v", got)
+	}
+}
+
+func TestRoom_Delete(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+
+	room := model.NewRoom("Test Room", host.ID, false)
+	room.AddParticipant(host.ID)
+	repo.Create(ctx, room)
+
+	if err := repo.Delete(ctx, room.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, room.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound after delete, got %v", err)
+	}
+
+	var count int
+	db.QueryRow(`SELECT COUNT(*) FROM participants WHERE room_id = $1`, room.ID).Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected participants to be deleted, got %d", count)
+	}
+
+	if err := repo.Delete(ctx, room.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected ErrRoomNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestRoom_GetByHostID(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	other := createTestUser(t, db)
+
+	first := model.NewRoom("First", host.ID, false)
+	first.AddParticipant(host.ID)
+	second := model.NewRoom("Second", host.ID, false)
+	second.CreatedAt = first.CreatedAt.Add(time.Minute)
+	second.AddParticipant(host.ID)
+
+	repo.Create(ctx, second)
+	repo.Create(ctx, first)
+	repo.Create(ctx, model.NewRoom("Other", other.ID, false))
+
+	rooms, err := repo.GetByHostID(ctx, host.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rooms) != 2 {
+		t.Fatalf("Expected 2 rooms, got %d", len(rooms))
+	}
+	if rooms[0].ID != first.ID || rooms[1].ID != second.ID {
+		t.Error("Expected rooms ordered by CreatedAt")
+	}
+	if len(rooms[0].Participants) != 1 {
+		t.Errorf("Expected participants to be loaded, got %d", len(rooms[0].Participants))
+	}
+}
+
+func TestRoom_GetActiveRoomsAndCleanup(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+
+	active := model.NewRoom("Active", host.ID, false)
+	expired := model.NewRoom("Expired", host.ID, false)
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	// 期限切れのルームにはAddParticipantできないため直接設定する
+	expired.Participants = []model.Participant{{UserID: host.ID, IsHost: true, JoinedAt: time.Now()}}
+
+	repo.Create(ctx, active)
+	repo.Create(ctx, expired)
+
+	rooms, err := repo.GetActiveRooms(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rooms) != 1 || rooms[0].ID != active.ID {
+		t.Errorf("Expected only the active room, got %d rooms", len(rooms))
+	}
+
+	removed, err := repo.CleanupExpiredRooms(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(removed) != 1 || removed[0].ID != expired.ID {
+		t.Fatalf("Expected the expired room to be returned, got %d rooms", len(removed))
+	}
+	if len(removed[0].Participants) != 1 || removed[0].Participants[0].UserID != host.ID {
+		t.Errorf("Expected removed room with its participants, got %+v", removed[0].Participants)
+	}
+	if _, err := repo.GetByID(ctx, expired.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected expired room to be removed, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, active.ID); err != nil {
+		t.Errorf("Expected active room to remain, got %v", err)
+	}
+
+	var count int
+	db.QueryRow(`SELECT COUNT(*) FROM participants`).Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected expired participants to be removed, got %d", count)
+	}
+}
+
+func TestRoom_CountActiveRooms(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	guest := createTestUser(t, db)
+
+	active := model.NewRoom("Active", host.ID, false)
+	active.AddParticipant(host.ID)
+	active.AddParticipant(guest.ID)
+	expired := model.NewRoom("Expired", host.ID, false)
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	expired.Participants = []model.Participant{{UserID: host.ID, IsHost: true, JoinedAt: time.Now()}}
+	for _, room := range []*model.Room{active, expired, model.NewRoom("Empty", host.ID, false)} {
+		if err := repo.Create(ctx, room); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	counts, err := repo.CountActiveRooms(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := repository.RoomCounts{Rooms: 2, Participants: 2, BySize: map[int]int{0: 1, 2: 1}}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("Expected %+v, got %+v", want, counts)
+	}
+}
+
+func TestRoom_Schedule(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	now := time.Now().Truncate(time.Second)
+
+	later, _ := model.NewSchedule(now.Add(3*time.Hour), now.Add(4*time.Hour), 5*time.Minute)
+	sooner, _ := model.NewSchedule(now.Add(time.Hour), now.Add(2*time.Hour), 0)
+
+	laterRoom := model.NewScheduledRoom("Later", host.ID, false, later)
+	soonerRoom := model.NewScheduledRoom("Sooner", host.ID, false, sooner)
+	for _, room := range []*model.Room{laterRoom, soonerRoom, model.NewRoom("Ad hoc", host.ID, false)} {
+		if err := repo.Create(ctx, room); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	got, err := repo.GetByID(ctx, laterRoom.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Schedule == nil {
+		t.Fatal("Expected schedule to be stored")
+	}
+	if !got.Schedule.StartsAt.Equal(later.StartsAt) || !got.Schedule.EndsAt.Equal(later.EndsAt) || got.Schedule.EarlyJoinMinutes != 5 {
+		t.Errorf("Expected schedule %+v, got %+v", later, got.Schedule)
+	}
+
+	rooms, err := repo.GetUpcomingByHostID(ctx, host.ID, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rooms) != 2 || rooms[0].ID != soonerRoom.ID || rooms[1].ID != laterRoom.ID {
+		t.Errorf("Expected upcoming rooms ordered by start time, got %d rooms", len(rooms))
+	}
+
+	// 予定の変更
+	rescheduled, _ := model.NewSchedule(now.Add(5*time.Hour), now.Add(6*time.Hour), 0)
+	got.SetSchedule(rescheduled)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	updated, _ := repo.GetByID(ctx, laterRoom.ID)
+	if !updated.Schedule.StartsAt.Equal(rescheduled.StartsAt) || !updated.ExpiresAt.Equal(rescheduled.EndsAt.Add(model.ScheduleGracePeriod)) {
+		t.Errorf("Expected rescheduled room, got %+v (expires %v)", updated.Schedule, updated.ExpiresAt)
+	}
+	if updated.Schedule.Sequence != 1 {
+		t.Errorf("Expected sequence 1 after rescheduling, got %d", updated.Schedule.Sequence)
+	}
+
+	adHoc, _ := repo.GetByHostID(ctx, host.ID)
+	for _, room := range adHoc {
+		if room.Name == "Ad hoc" && room.Schedule != nil {
+			t.Errorf("Expected unscheduled room to have no schedule, got %+v", room.Schedule)
+		}
+	}
+}
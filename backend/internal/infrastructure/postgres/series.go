@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSeriesNotFound is returned when a series row does not exist
+	ErrSeriesNotFound = fmt.Errorf("series %w", repository.ErrNotFound)
+
+	// ErrSeriesConflict is returned when updating a series row whose version changed since it was read
+	ErrSeriesConflict = fmt.Errorf("series %w", repository.ErrConflict)
+)
+
+const seriesColumns = `id, COALESCE(name, ''), host_id, is_waiting_room, max_capacity, starts_at, duration_minutes, early_join_minutes,
+	rrule, time_zone, sequence, version, created_at, max_extension_minutes, auto_delete`
+
+// Series is a SQL implementation of repository.Series
+// Co-hosts and cancelled occurrences are stored in the series_co_hosts and series_exceptions tables
+type Series struct {
+	db *sql.DB
+}
+
+var _ repository.Series = (*Series)(nil)
+
+// NewSeries creates a new SQL Series repository
+func NewSeries(db *sql.DB) *Series {
+	return &Series{db: db}
+}
+
+// Create creates a new series together with its co-hosts and exceptions
+func (s *Series) Create(ctx context.Context, series *model.Series) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO series (id, name, host_id, is_waiting_room, max_capacity, starts_at, duration_minutes, early_join_minutes,
+			rrule, time_zone, sequence, version, created_at, max_extension_minutes, auto_delete)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`,
+			series.ID, series.Name, series.HostID, series.IsWaitingRoom, series.MaxCapacity, series.StartsAt.UTC(),
+			series.DurationMinutes, series.EarlyJoinMinutes, series.RRule, series.TimeZone, series.Sequence, series.Version,
+			series.CreatedAt.UTC(), series.MaxExtensionMinutes, series.AutoDelete,
+		); err != nil {
+			return err
+		}
+
+		return insertSeriesDetails(ctx, tx, series)
+	})
+}
+
+// GetByID retrieves a series by ID
+func (s *Series) GetByID(ctx context.Context, id uuid.UUID) (*model.Series, error) {
+	series, err := scanSeries(conn(ctx, s.db).QueryRowContext(ctx, `SELECT `+seriesColumns+` FROM series WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSeriesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loadSeriesDetails(ctx, conn(ctx, s.db), series); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// GetByHostID retrieves the series of a host, oldest first
+func (s *Series) GetByHostID(ctx context.Context, hostID uuid.UUID) ([]*model.Series, error) {
+	rows, err := conn(ctx, s.db).QueryContext(ctx, `SELECT `+seriesColumns+` FROM series WHERE host_id = $1 ORDER BY created_at`, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*model.Series{}
+	for rows.Next() {
+		series, err := scanSeries(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		list = append(list, series)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, series := range list {
+		if err := loadSeriesDetails(ctx, conn(ctx, s.db), series); err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+// Update updates an existing series and replaces its co-hosts and exceptions
+// The row is only updated while its version matches, so concurrent updates cannot overwrite each other
+func (s *Series) Update(ctx context.Context, series *model.Series) error {
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx,
+			`UPDATE series SET name = $2, host_id = $3, is_waiting_room = $4, max_capacity = $5, starts_at = $6,
+			duration_minutes = $7, early_join_minutes = $8, rrule = $9, time_zone = $10,
+			sequence = $11, max_extension_minutes = $13, auto_delete = $14, version = version + 1
+			WHERE id = $1 AND version = $12`,
+			series.ID, series.Name, series.HostID, series.IsWaitingRoom, series.MaxCapacity, series.StartsAt.UTC(),
+			series.DurationMinutes, series.EarlyJoinMinutes, series.RRule, series.TimeZone, series.Sequence, series.Version,
+			series.MaxExtensionMinutes, series.AutoDelete,
+		)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			// 更新されなかった理由が削除か競合かを区別する
+			var exists bool
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM series WHERE id = $1)`, series.ID).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return ErrSeriesNotFound
+			}
+			return ErrSeriesConflict
+		}
+
+		if err := deleteSeriesDetails(ctx, tx, series.ID); err != nil {
+			return err
+		}
+		return insertSeriesDetails(ctx, tx, series)
+	})
+	if err != nil {
+		return err
+	}
+
+	series.Version++
+	return nil
+}
+
+// Delete deletes a series with its co-hosts and exceptions
+// Rooms already materialized for its occurrences are kept
+func (s *Series) Delete(ctx context.Context, id uuid.UUID) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		if err := deleteSeriesDetails(ctx, tx, id); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM series WHERE id = $1`, id)
+		if err != nil {
+			return err
+		}
+
+		return requireAffected(result, ErrSeriesNotFound)
+	})
+}
+
+func scanSeries(row scanner) (*model.Series, error) {
+	var series model.Series
+	if err := row.Scan(
+		&series.ID, &series.Name, &series.HostID, &series.IsWaitingRoom, &series.MaxCapacity, &series.StartsAt,
+		&series.DurationMinutes, &series.EarlyJoinMinutes, &series.RRule, &series.TimeZone, &series.Sequence, &series.Version,
+		&series.CreatedAt, &series.MaxExtensionMinutes, &series.AutoDelete,
+	); err != nil {
+		return nil, err
+	}
+
+	series.CoHostIDs = []uuid.UUID{}
+	series.ExceptionDates = []time.Time{}
+	return &series, nil
+}
+
+func loadSeriesDetails(ctx context.Context, q queryer, series *model.Series) error {
+	coHostIDs, err := loadUserIDs(ctx, q, "series_co_hosts", "series_id", series.ID)
+	if err != nil {
+		return err
+	}
+	series.CoHostIDs = append(series.CoHostIDs, coHostIDs...)
+
+	rows, err := q.QueryContext(ctx, `SELECT starts_at FROM series_exceptions WHERE series_id = $1 ORDER BY starts_at`, series.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var startsAt time.Time
+		if err := rows.Scan(&startsAt); err != nil {
+			return err
+		}
+		series.ExceptionDates = append(series.ExceptionDates, startsAt)
+	}
+
+	return rows.Err()
+}
+
+func insertSeriesDetails(ctx context.Context, q queryer, series *model.Series) error {
+	if err := insertUserIDs(ctx, q, "series_co_hosts", "series_id", series.ID, series.CoHostIDs); err != nil {
+		return err
+	}
+
+	for _, startsAt := range series.ExceptionDates {
+		if _, err := q.ExecContext(ctx,
+			`INSERT INTO series_exceptions (series_id, starts_at) VALUES ($1, $2)`, series.ID, startsAt.UTC(),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteSeriesDetails(ctx context.Context, q queryer, seriesID uuid.UUID) error {
+	if _, err := q.ExecContext(ctx, `DELETE FROM series_co_hosts WHERE series_id = $1`, seriesID); err != nil {
+		return err
+	}
+	_, err := q.ExecContext(ctx, `DELETE FROM series_exceptions WHERE series_id = $1`, seriesID)
+	return err
+}
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+func TestSeries_CreateGetAndUpdate(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewSeries(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	coHost := createTestUser(t, db)
+
+	start := time.Date(2030, 3, 4, 9, 30, 0, 0, time.UTC)
+	series, err := model.NewSeries("Standup", host.ID, true, start, 15*time.Minute, 5*time.Minute, "FREQ=WEEKLY;BYDAY=MO,WE,FR", "Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	series.SetCoHosts([]uuid.UUID{coHost.ID})
+	series.MaxExtensionMinutes = 30
+	series.AutoDelete = false
+	if err := repo.Create(ctx, series); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Name != "Standup" || got.HostID != host.ID || !got.IsWaitingRoom || got.RRule != series.RRule || got.TimeZone != "Asia/Tokyo" {
+		t.Errorf("Expected %+v, got %+v", series, got)
+	}
+	if !got.StartsAt.Equal(start) || got.DurationMinutes != 15 || got.EarlyJoinMinutes != 5 {
+		t.Errorf("Expected start %v for 15 minutes, got %v for %d", start, got.StartsAt, got.DurationMinutes)
+	}
+	if !got.IsCoHost(coHost.ID) {
+		t.Errorf("Expected co-host %s, got %v", coHost.ID, got.CoHostIDs)
+	}
+	if got.MaxExtensionMinutes != 30 || got.AutoDelete {
+		t.Errorf("Expected the room options to be kept, got %d minutes and auto delete %v", got.MaxExtensionMinutes, got.AutoDelete)
+	}
+
+	// 中止した回は保存後の一覧にも含まれない
+	occurrences, _ := got.Occurrences(start, 2)
+	if err := got.CancelOccurrence(occurrences[0].StartsAt); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got.SetCoHosts(nil)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	updated, _ := repo.GetByID(ctx, series.ID)
+	if len(updated.CoHostIDs) != 0 {
+		t.Errorf("Expected co-hosts to be cleared, got %v", updated.CoHostIDs)
+	}
+	if updated.Sequence != 1 {
+		t.Errorf("Expected sequence 1 after cancelling, got %d", updated.Sequence)
+	}
+	remaining, _ := updated.Occurrences(start, 1)
+	if len(remaining) != 1 || !remaining[0].StartsAt.Equal(occurrences[1].StartsAt) {
+		t.Errorf("Expected next occurrence %v, got %v", occurrences[1].StartsAt, remaining)
+	}
+
+	list, err := repo.GetByHostID(ctx, host.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(list) != 1 || len(list[0].ExceptionDates) != 1 {
+		t.Errorf("Expected 1 series with 1 exception, got %v", list)
+	}
+}
+
+func TestSeries_Update_Conflict(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewSeries(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+
+	start := time.Date(2030, 3, 4, 9, 30, 0, 0, time.UTC)
+	series, err := model.NewSeries("Standup", host.ID, false, start, 15*time.Minute, 0, "FREQ=DAILY", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	repo.Create(ctx, series)
+
+	first, _ := repo.GetByID(ctx, series.ID)
+	second, _ := repo.GetByID(ctx, series.ID)
+	occurrences, _ := first.Occurrences(start, 2)
+
+	first.CancelOccurrence(occurrences[0].StartsAt)
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Expected version 2, got %d", first.Version)
+	}
+
+	// 古いバージョンからの更新は拒否される
+	second.CancelOccurrence(occurrences[1].StartsAt)
+	if err := repo.Update(ctx, second); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, series.ID)
+	if len(got.ExceptionDates) != 1 || got.Version != 2 {
+		t.Errorf("Expected the first update only, got %+v", got)
+	}
+}
+
+func TestSeries_DeleteKeepsRooms(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewSeries(db)
+	rooms := NewRoom(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+	coHost := createTestUser(t, db)
+
+	series, _ := model.NewSeries("Standup", host.ID, false, time.Now().Add(time.Hour), 15*time.Minute, 0, "FREQ=DAILY", "")
+	series.SetCoHosts([]uuid.UUID{coHost.ID})
+	repo.Create(ctx, series)
+
+	occurrences, _ := series.Occurrences(time.Now(), 2)
+	second, _ := series.NewOccurrenceRoom(&occurrences[1])
+	first, _ := series.NewOccurrenceRoom(&occurrences[0])
+	for _, room := range []*model.Room{second, first} {
+		if err := rooms.Create(ctx, room); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// 同じ回のルームは重複して作成できない
+	duplicate, _ := series.NewOccurrenceRoom(&occurrences[0])
+	if err := rooms.Create(ctx, duplicate); err == nil {
+		t.Error("Expected an error creating a second room for the same occurrence")
+	}
+
+	materialized, err := rooms.GetBySeriesID(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(materialized) != 2 || materialized[0].ID != first.ID || materialized[1].ID != second.ID {
+		t.Fatalf("Expected rooms ordered by occurrence, got %v", materialized)
+	}
+	if !materialized[0].IsDesignatedCoHost(coHost.ID) || !materialized[0].Occurrence.StartsAt.Equal(occurrences[0].StartsAt) {
+		t.Errorf("Expected co-host and occurrence to be stored, got %v %+v", materialized[0].CoHostIDs, materialized[0].Occurrence)
+	}
+
+	if err := repo.Delete(ctx, series.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, series.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, series.ID); !errors.Is(err, ErrSeriesNotFound) {
+		t.Errorf("Expected ErrSeriesNotFound deleting twice, got %v", err)
+	}
+
+	if kept, _ := rooms.GetBySeriesID(ctx, series.ID); len(kept) != 2 {
+		t.Errorf("Expected materialized rooms to be kept, got %d", len(kept))
+	}
+
+	// ルームの削除で共同ホストも削除される
+	if err := rooms.Delete(ctx, first.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var count int
+	db.QueryRow(`SELECT COUNT(*) FROM room_co_hosts WHERE room_id = $1`, first.ID).Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected room co-hosts to be deleted, got %d", count)
+	}
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/cline-meet/backend/internal/domain/repository"
+)
+
+// txKey is the context key of the transaction started by Transactor
+type txKey struct{}
+
+// afterCommitKey is the context key of the functions to run once the transaction commits
+type afterCommitKey struct{}
+
+// afterCommitQueue holds the functions queued with AfterCommit
+type afterCommitQueue struct {
+	fns   []func(ctx context.Context)
+	mutex sync.Mutex
+}
+
+// Transactor is a SQL implementation of repository.Transactor
+// The repositories of this package run their statements in the transaction carried by the context
+type Transactor struct {
+	db *sql.DB
+}
+
+var _ repository.Transactor = (*Transactor)(nil)
+
+// NewTransactor creates a new SQL Transactor
+func NewTransactor(db *sql.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTransaction runs fn in a database transaction
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 外側のトランザクションに参加する
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	queue := &afterCommitQueue{}
+	err := withTx(ctx, t.db, func(tx *sql.Tx) error {
+		return fn(context.WithValue(context.WithValue(ctx, txKey{}, tx), afterCommitKey{}, queue))
+	})
+	if err != nil {
+		return err
+	}
+
+	// コミット後にトランザクションを持たない ctx で実行する
+	for _, fn := range queue.fns {
+		fn(ctx)
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction carried by ctx commits, or right away outside a transaction
+func (t *Transactor) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	queue, ok := ctx.Value(afterCommitKey{}).(*afterCommitQueue)
+	if !ok {
+		fn(ctx)
+		return
+	}
+
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.fns = append(queue.fns, fn)
+}
+
+// conn returns the transaction carried by ctx, or db outside a transaction
+func conn(ctx context.Context, db *sql.DB) queryer {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+)
+
+func TestTransactor_CommitsOnSuccess(t *testing.T) {
+	db := newTestDB(t)
+	rooms := NewRoom(db)
+	transactor := NewTransactor(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+
+	room := model.NewRoom("Test Room", host.ID, false)
+	room.AddParticipant(host.ID)
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := rooms.Create(ctx, room); err != nil {
+			return err
+		}
+
+		// 同じトランザクション内では書き込んだ内容が見える
+		got, err := rooms.GetByID(ctx, room.ID)
+		if err != nil {
+			return err
+		}
+		got.IsLocked = true
+		return rooms.Update(ctx, got)
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, err := rooms.GetByID(ctx, room.ID)
+	if err != nil {
+		t.Fatalf("Expected committed room, got %v", err)
+	}
+	if !got.IsLocked || len(got.Participants) != 1 {
+		t.Errorf("Expected the locked room with its host, got %+v", got)
+	}
+}
+
+func TestTransactor_RollsBackOnError(t *testing.T) {
+	db := newTestDB(t)
+	rooms := NewRoom(db)
+	transactor := NewTransactor(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+
+	kept := model.NewRoom("Kept", host.ID, false)
+	rooms.Create(ctx, kept)
+
+	failure := errors.New("failure")
+	created := model.NewRoom("Created", host.ID, false)
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := rooms.Create(ctx, created); err != nil {
+			return err
+		}
+
+		// ネストした呼び出しは外側のトランザクションに参加する
+		return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := rooms.Delete(ctx, kept.ID); err != nil {
+				return err
+			}
+			return failure
+		})
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the error of fn, got %v", err)
+	}
+
+	if _, err := rooms.GetByID(ctx, created.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("Expected the created room to be rolled back, got %v", err)
+	}
+	if _, err := rooms.GetByID(ctx, kept.ID); err != nil {
+		t.Errorf("Expected the deleted room to be rolled back, got %v", err)
+	}
+}
+
+func TestTransactor_AfterCommit(t *testing.T) {
+	db := newTestDB(t)
+	rooms := NewRoom(db)
+	transactor := NewTransactor(db)
+	ctx := context.Background()
+	host := createTestUser(t, db)
+
+	room := model.NewRoom("Test Room", host.ID, false)
+	var committed []error
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := rooms.Create(ctx, room); err != nil {
+				return err
+			}
+			// コミット後はトランザクションの外から書き込んだ内容が見える
+			transactor.AfterCommit(ctx, func(ctx context.Context) {
+				_, err := rooms.GetByID(ctx, room.ID)
+				committed = append(committed, err)
+			})
+			if len(committed) != 0 {
+				t.Error("Expected nothing to run before the outer commit")
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(committed) != 1 || committed[0] != nil {
+		t.Errorf("Expected the function to see the committed room, got %v", committed)
+	}
+
+	failure := errors.New("failure")
+	ran := false
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		transactor.AfterCommit(ctx, func(ctx context.Context) { ran = true })
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the error of fn, got %v", err)
+	}
+	if ran {
+		t.Error("Expected nothing to run after a rollback")
+	}
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ErrUserNotFound is returned when a user row does not exist
+var ErrUserNotFound = fmt.Errorf("user %w", repository.ErrNotFound)
+
+const userColumns = `id, COALESCE(google_id, ''), COALESCE(email, ''), COALESCE(name, ''), COALESCE(avatar_url, ''), created_at`
+
+// User is a SQL implementation of repository.User backed by the users table
+type User struct {
+	db *sql.DB
+}
+
+var _ repository.User = (*User)(nil)
+
+// NewUser creates a new SQL User repository
+func NewUser(db *sql.DB) *User {
+	return &User{db: db}
+}
+
+// Create creates a new user
+func (u *User) Create(ctx context.Context, user *model.User) error {
+	_, err := conn(ctx, u.db).ExecContext(ctx,
+		`INSERT INTO users (id, google_id, email, name, avatar_url, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6)`,
+		user.ID, user.GoogleID, user.Email, user.Name, user.AvatarURL, user.CreatedAt.UTC(),
+	)
+	return err
+}
+
+// GetByID retrieves a user by ID
+func (u *User) GetByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	return u.get(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+}
+
+// GetByGoogleID retrieves a user by Google ID
+func (u *User) GetByGoogleID(ctx context.Context, googleID string) (*model.User, error) {
+	return u.get(ctx, `SELECT `+userColumns+` FROM users WHERE google_id = $1`, googleID)
+}
+
+// GetByEmail retrieves a user by email
+func (u *User) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	return u.get(ctx, `SELECT `+userColumns+` FROM users WHERE email = $1 ORDER BY created_at LIMIT 1`, email)
+}
+
+// Update updates an existing user
+func (u *User) Update(ctx context.Context, user *model.User) error {
+	result, err := conn(ctx, u.db).ExecContext(ctx,
+		`UPDATE users SET google_id = NULLIF($2, ''), email = $3, name = $4, avatar_url = $5 WHERE id = $1`,
+		user.ID, user.GoogleID, user.Email, user.Name, user.AvatarURL,
+	)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result, ErrUserNotFound)
+}
+
+// Delete deletes a user
+func (u *User) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := conn(ctx, u.db).ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result, ErrUserNotFound)
+}
+
+func (u *User) get(ctx context.Context, query string, arg interface{}) (*model.User, error) {
+	var user model.User
+	err := conn(ctx, u.db).QueryRowContext(ctx, query, arg).Scan(
+		&user.ID, &user.GoogleID, &user.Email, &user.Name, &user.AvatarURL, &user.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// requireAffected returns notFound when a statement did not touch any row
+func requireAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
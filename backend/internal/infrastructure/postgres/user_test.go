@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+func TestUser_CreateAndGet(t *testing.T) {
+	repo := NewUser(newTestDB(t))
+	ctx := context.Background()
+	user := model.NewUser("google123", "test@example.com", "Test User", "https://example.com/a.png")
+
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	byID, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if byID.GoogleID != user.GoogleID || byID.Email != user.Email || byID.Name != user.Name || byID.AvatarURL != user.AvatarURL {
+		t.Errorf("Expected %+v, got %+v", user, byID)
+	}
+	if !byID.CreatedAt.Equal(user.CreatedAt) {
+		t.Errorf("Expected CreatedAt %v, got %v", user.CreatedAt, byID.CreatedAt)
+	}
+
+	if got, err := repo.GetByGoogleID(ctx, "google123"); err != nil || got.ID != user.ID {
+		t.Errorf("Expected user by Google ID, got %v (err %v)", got, err)
+	}
+	if got, err := repo.GetByEmail(ctx, "test@example.com"); err != nil || got.ID != user.ID {
+		t.Errorf("Expected user by email, got %v (err %v)", got, err)
+	}
+}
+
+func TestUser_Create_DuplicateGoogleID(t *testing.T) {
+	repo := NewUser(newTestDB(t))
+	ctx := context.Background()
+	repo.Create(ctx, model.NewUser("google123", "a@example.com", "A", ""))
+
+	if err := repo.Create(ctx, model.NewUser("google123", "b@example.com", "B", "")); err == nil {
+		t.Error("Expected unique constraint error for duplicate Google ID")
+	}
+
+	// 空のGoogle IDはNULLとして保存され、重複しない
+	if err := repo.Create(ctx, model.NewUser("", "c@example.com", "C", "")); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := repo.Create(ctx, model.NewUser("", "d@example.com", "D", "")); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestUser_NotFound(t *testing.T) {
+	repo := NewUser(newTestDB(t))
+	ctx := context.Background()
+
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected repository.ErrNotFound, got %v", err)
+	}
+	if _, err := repo.GetByGoogleID(ctx, "missing"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if err := repo.Update(ctx, model.NewUser("g", "e", "n", "")); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, uuid.New()); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestUser_UpdateAndDelete(t *testing.T) {
+	repo := NewUser(newTestDB(t))
+	ctx := context.Background()
+	user := model.NewUser("google123", "test@example.com", "Test User", "")
+	repo.Create(ctx, user)
+
+	user.UpdateProfile("New Name", "https://example.com/b.png")
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, user.ID)
+	if got.Name != "New Name" || got.AvatarURL != "https://example.com/b.png" {
+		t.Errorf("Expected updated profile, got %+v", got)
+	}
+
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, user.ID); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound after delete, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package realtime
+
+import (
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Time allowed to write a message to the peer
+	writeWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the peer
+	pongWait = 60 * time.Second
+
+	// Send pings to peer with this period (must be less than pongWait)
+	pingPeriod = (pongWait * 9) / 10
+
+	// Maximum message size allowed from peer (SDP offers can be large)
+	maxMessageSize = 64 * 1024
+
+	// Number of outbound messages buffered per client
+	sendBufferSize = 256
+)
+
+// Client is a single WebSocket connection registered with the Hub
+// A waiting client belongs to a user in the room's waiting room: it only receives direct messages
+// until the user is admitted; the hub guards the flag with its mutex
+type Client struct {
+	hub     *Hub
+	conn    *websocket.Conn
+	send    chan []byte
+	userID  uuid.UUID
+	roomID  uuid.UUID
+	waiting bool
+}
+
+func newClient(hub *Hub, conn *websocket.Conn, userID, roomID uuid.UUID) *Client {
+	return &Client{
+		hub:    hub,
+		conn:   conn,
+		send:   make(chan []byte, sendBufferSize),
+		userID: userID,
+		roomID: roomID,
+	}
+}
+
+// readPump reads messages from the connection and hands them to the hub
+func (c *Client) readPump() {
+	defer func() {
+		select {
+		case c.hub.unregister <- c:
+		case <-c.hub.done:
+		}
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		// 接続が生きている間はセッションを回収されないようにする
+		c.hub.touchSession(c.userID)
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		message, err := model.FromJSON(data)
+		if err != nil {
+			// 不正なメッセージは無視する
+			continue
+		}
+
+		c.hub.handleMessage(c, message)
+	}
+}
+
+// writePump writes queued messages and pings to the connection
+// The connection is closed once the hub closes the send channel
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case data, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				// The hub closed the channel
+				c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
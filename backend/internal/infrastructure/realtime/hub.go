@@ -0,0 +1,608 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+var (
+	// ErrUserNotConnected is returned when a direct message target has no connection on this hub
+	ErrUserNotConnected = errors.New("user is not connected")
+
+	// ErrNoTargetUser is returned when a direct message has no target user
+	ErrNoTargetUser = errors.New("message has no target user")
+)
+
+// DefaultMaxConnections is the per-pod connection limit (README: 1Pod = 1万接続想定)
+const DefaultMaxConnections = 10000
+
+// Router routes client-originated messages to their recipients
+type Router interface {
+	Route(ctx context.Context, message *model.Message) error
+}
+
+// Metrics records the messages fanned out by the hub
+type Metrics interface {
+	// MessageDelivered records a message queued on at least one local client and how long it took
+	MessageDelivered(messageType model.MessageType, elapsed time.Duration)
+
+	// SendBufferDropped records a client dropped because its send buffer was full
+	SendBufferDropped()
+}
+
+// nopMetrics is the Metrics of a hub without SetMetrics
+type nopMetrics struct{}
+
+func (nopMetrics) MessageDelivered(model.MessageType, time.Duration) {}
+
+func (nopMetrics) SendBufferDropped() {}
+
+// Hub manages WebSocket clients and fans messages out to rooms
+// It implements service.RealtimeNotifier
+type Hub struct {
+	// WebSocket接続管理
+	clients    map[*Client]bool
+	register   chan *Client
+	unregister chan *Client
+	done       chan struct{}
+
+	// ルーム・ユーザー別の接続
+	rooms map[uuid.UUID]map[*Client]bool
+	users map[uuid.UUID]map[*Client]bool
+
+	upgrader       websocket.Upgrader
+	roomRepo       repository.Room
+	signer         service.ConnectionSigner
+	waiting        repository.WaitingRoom
+	allowedOrigins []string
+	router         Router
+	sessions       service.SessionManager
+	metrics        Metrics
+	maxConnections int
+
+	// 並行処理制御
+	mutex   sync.RWMutex
+	writers sync.WaitGroup
+}
+
+var _ service.RealtimeNotifier = (*Hub)(nil)
+
+// NewHub creates a new Hub
+// Connections are authenticated with tokens verified by signer
+// Only current participants of a room in roomRepo can connect to it, and waiting users once SetWaitingRoom is called
+func NewHub(roomRepo repository.Room, signer service.ConnectionSigner) *Hub {
+	h := &Hub{
+		clients:    make(map[*Client]bool),
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		done:       make(chan struct{}),
+		rooms:      make(map[uuid.UUID]map[*Client]bool),
+		users:      make(map[uuid.UUID]map[*Client]bool),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		},
+		roomRepo:       roomRepo,
+		signer:         signer,
+		metrics:        nopMetrics{},
+		maxConnections: DefaultMaxConnections,
+	}
+	h.router = h
+	h.upgrader.CheckOrigin = h.checkOrigin
+	return h
+}
+
+// Run processes client registration until the context is cancelled
+// On cancellation every client is sent a close frame and Run returns once all writes are flushed
+func (h *Hub) Run(ctx context.Context) {
+	defer close(h.done)
+
+	for {
+		select {
+		case client := <-h.register:
+			// 書き込みgoroutineはシャットダウン時に待ち合わせる
+			h.writers.Add(1)
+			h.addClient(client)
+		case client := <-h.unregister:
+			h.removeClient(client)
+		case <-ctx.Done():
+			h.closeAll()
+			h.writers.Wait()
+			return
+		}
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection
+// The user and the room are identified by the connection token in the token query parameter,
+// since browsers cannot set headers on the upgrade request
+// The user must be a participant of the room or wait for admission to it, and must connect from an allowed origin
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.signer.VerifyConnection(r.URL.Query().Get("token"))
+	if err != nil {
+		http.Error(w, "missing or invalid token", http.StatusUnauthorized)
+		return
+	}
+	userID, roomID := claims.UserID, claims.RoomID
+
+	// 他サイトのページからの接続 (Cross-Site WebSocket Hijacking) と Origin を送らないクライアントを拒否する
+	if !h.checkOrigin(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	// 接続数の上限を超える場合は他のPodに振り分けてもらう
+	if h.maxConnections > 0 && h.ClientCount() >= h.maxConnections {
+		http.Error(w, "too many connections", http.StatusServiceUnavailable)
+		return
+	}
+
+	// ルームの参加者だけがルームのイベントを受け取れる
+	room, err := h.roomRepo.GetByID(r.Context(), roomID)
+	if errors.Is(err, repository.ErrNotFound) {
+		http.Error(w, "room not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to get room", http.StatusInternalServerError)
+		return
+	}
+	waiting := false
+	if !room.IsParticipant(userID) {
+		// 待機室のユーザーは入室の可否を受け取るためだけに接続できる
+		if waiting, err = h.isWaiting(r.Context(), roomID, userID); err != nil {
+			http.Error(w, "failed to get waiting room", http.StatusInternalServerError)
+			return
+		}
+		if !waiting {
+			http.Error(w, "user is not a participant of the room", http.StatusForbidden)
+			return
+		}
+	}
+
+	// このPodをダイレクトメッセージの配送先として記録する
+	// 記録できなければダイレクトメッセージが届かないので接続を受け付けない
+	if h.sessions != nil {
+		if err := h.sessions.CreateSession(r.Context(), userID, uuid.NewString()); err != nil {
+			http.Error(w, "failed to create session", http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already written an error response
+		return
+	}
+
+	client := newClient(h, conn, userID, roomID)
+	client.waiting = waiting
+
+	select {
+	case h.register <- client:
+	case <-h.done:
+		conn.Close()
+		return
+	}
+
+	go func() {
+		defer h.writers.Done()
+		client.writePump()
+	}()
+	go client.readPump()
+}
+
+// ClientCount returns the number of connected clients
+func (h *Hub) ClientCount() int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.clients)
+}
+
+// RoomClientCount returns the number of clients connected to a room
+func (h *Hub) RoomClientCount(roomID uuid.UUID) int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.rooms[roomID])
+}
+
+// NotifyRoomJoined notifies all participants that a user joined the room
+func (h *Hub) NotifyRoomJoined(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	return h.Deliver(model.NewUserJoinedMessage(roomID, userID, userName))
+}
+
+// NotifyRoomLeft notifies all participants that a user left the room
+func (h *Hub) NotifyRoomLeft(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	return h.Deliver(model.NewUserLeftMessage(roomID, userID, userName))
+}
+
+// NotifyUserMuted notifies all participants that the actor muted or unmuted a user
+func (h *Hub) NotifyUserMuted(ctx context.Context, roomID, actorID, userID uuid.UUID, isMuted bool) error {
+	return h.Deliver(model.NewMuteUserMessage(roomID, actorID, userID, isMuted))
+}
+
+// BroadcastChatMessage broadcasts a chat message to all room participants
+func (h *Hub) BroadcastChatMessage(ctx context.Context, message *model.Message) error {
+	return h.fanout(message, h.broadcastToRoom)
+}
+
+// SendDirectMessage sends a direct message to a specific user
+func (h *Hub) SendDirectMessage(ctx context.Context, message *model.Message) error {
+	if !message.IsDirectMessage() {
+		return ErrNoTargetUser
+	}
+	return h.Deliver(message)
+}
+
+// NotifyRoomUpdate notifies participants about room setting changes
+func (h *Hub) NotifyRoomUpdate(ctx context.Context, room *model.Room) error {
+	return h.Deliver(model.NewRoomUpdateMessage(room))
+}
+
+// NotifyHostChanged notifies all participants that the host role moved to another user
+func (h *Hub) NotifyHostChanged(ctx context.Context, roomID, previousHostID, newHostID uuid.UUID, reason string) error {
+	return h.Deliver(model.NewHostChangedMessage(roomID, previousHostID, newHostID, reason))
+}
+
+// NotifyRoomClosed notifies the remaining participants that the room ended and disconnects them
+func (h *Hub) NotifyRoomClosed(ctx context.Context, roomID uuid.UUID, reason string) error {
+	return h.Deliver(model.NewRoomClosedMessage(roomID, reason))
+}
+
+// Route delivers a client-originated message on this hub only
+func (h *Hub) Route(ctx context.Context, message *model.Message) error {
+	return h.Deliver(message)
+}
+
+// SetRouter replaces the router used for client-originated messages
+// It must be called before the hub starts serving connections
+func (h *Hub) SetRouter(router Router) {
+	h.router = router
+}
+
+// SetWaitingRoom lets users waiting for admission to a room connect to it
+// Their connections only receive direct messages until an admit user message lets them in; denied connections are closed
+// It must be called before the hub starts serving connections
+func (h *Hub) SetWaitingRoom(waitingRoomRepo repository.WaitingRoom) {
+	h.waiting = waitingRoomRepo
+}
+
+// isWaiting reports whether the user waits for admission to the room
+func (h *Hub) isWaiting(ctx context.Context, roomID, userID uuid.UUID) (bool, error) {
+	if h.waiting == nil {
+		return false, nil
+	}
+	return h.waiting.IsWaitingUser(ctx, roomID, userID)
+}
+
+// SetSessionManager records a session bound to this pod for every new connection
+// It must be called before the hub starts serving connections
+func (h *Hub) SetSessionManager(sessions service.SessionManager) {
+	h.sessions = sessions
+}
+
+// touchSession refreshes the session of a user whose connection answered a ping
+func (h *Hub) touchSession(userID uuid.UUID) {
+	if h.sessions != nil {
+		h.sessions.TouchSession(context.Background(), userID)
+	}
+}
+
+// SetMetrics records the hub's deliveries and dropped clients in metrics
+// It must be called before the hub starts serving connections
+func (h *Hub) SetMetrics(metrics Metrics) {
+	h.metrics = metrics
+}
+
+// fanout sends message with send and records the delivery when at least one client was queued
+func (h *Hub) fanout(message *model.Message, send func(*model.Message) (int, error)) error {
+	start := time.Now()
+	queued, err := send(message)
+	if queued > 0 {
+		h.metrics.MessageDelivered(message.Type, time.Since(start))
+	}
+	return err
+}
+
+// SetAllowedOrigins sets the origins, such as "https://meet.example.com", browsers may connect from
+// Without allowed origins only pages served from the hub's own host may connect
+// Requests without an Origin header are rejected
+// It must be called before the hub starts serving connections
+func (h *Hub) SetAllowedOrigins(origins []string) {
+	h.allowedOrigins = origins
+}
+
+// checkOrigin reports whether the request's Origin header is allowed
+func (h *Hub) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+
+	if len(h.allowedOrigins) == 0 {
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+	for _, allowed := range h.allowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMaxConnections sets the number of connections accepted before new ones are rejected
+// A non-positive value disables the limit
+func (h *Hub) SetMaxConnections(n int) {
+	h.maxConnections = n
+}
+
+// Deliver sends a message to the local clients it is addressed to
+// Direct messages go to the target user's connections, all others to the room
+// Kick messages also close the target user's connections to the room, room closed messages all connections to the room
+func (h *Hub) Deliver(message *model.Message) error {
+	return h.fanout(message, h.route)
+}
+
+// route sends a message with the delivery matching its type and returns the number of clients it was queued on
+func (h *Hub) route(message *model.Message) (int, error) {
+	switch message.Type {
+	case model.MessageTypeKickUser:
+		return h.kickUser(message)
+	case model.MessageTypeAdmitUser:
+		return h.admitUser(message)
+	case model.MessageTypeRoomClosed:
+		return h.closeRoom(message)
+	}
+	if message.IsDirectMessage() {
+		return h.sendToUser(message)
+	}
+	return h.broadcastToRoom(message)
+}
+
+// sendToUser sends a message to every connection of the target user
+func (h *Hub) sendToUser(message *model.Message) (int, error) {
+	data, err := message.ToJSON()
+	if err != nil {
+		return 0, err
+	}
+
+	h.mutex.RLock()
+	targets := h.users[message.TargetUserID]
+	if len(targets) == 0 {
+		h.mutex.RUnlock()
+		return 0, ErrUserNotConnected
+	}
+	queued, slow := h.deliver(targets, data)
+	h.mutex.RUnlock()
+
+	h.dropClients(slow)
+	return queued, nil
+}
+
+// kickUser sends a kick message to the target user's connections to the room and closes them
+// Queued messages are still written before the close frame
+func (h *Hub) kickUser(message *model.Message) (int, error) {
+	data, err := message.ToJSON()
+	if err != nil {
+		return 0, err
+	}
+
+	h.mutex.RLock()
+	targets := make(map[*Client]bool)
+	for client := range h.users[message.TargetUserID] {
+		if client.roomID == message.RoomID {
+			targets[client] = true
+		}
+	}
+	queued, _ := h.deliver(targets, data)
+	h.mutex.RUnlock()
+
+	if len(targets) == 0 {
+		return 0, ErrUserNotConnected
+	}
+
+	for client := range targets {
+		h.removeClient(client)
+	}
+	return queued, nil
+}
+
+// admitUser sends an admission decision to the target user's connections
+// Waiting connections to the room start receiving the room's messages when admitted, and are closed when denied
+// once the message is written
+func (h *Hub) admitUser(message *model.Message) (int, error) {
+	queued, err := h.sendToUser(message)
+	if err != nil {
+		return queued, err
+	}
+
+	h.mutex.Lock()
+	var denied []*Client
+	for client := range h.users[message.TargetUserID] {
+		if !client.waiting || client.roomID != message.RoomID {
+			continue
+		}
+		if !message.IsAdmitted() {
+			denied = append(denied, client)
+			continue
+		}
+		client.waiting = false
+		h.addToRoom(client)
+	}
+	h.mutex.Unlock()
+
+	h.dropClients(denied)
+	return queued, nil
+}
+
+// closeRoom sends a room closed message to every client connected to the room and closes them
+// Queued messages are still written before the close frame
+func (h *Hub) closeRoom(message *model.Message) (int, error) {
+	data, err := message.ToJSON()
+	if err != nil {
+		return 0, err
+	}
+
+	h.mutex.RLock()
+	targets := make([]*Client, 0, len(h.rooms[message.RoomID]))
+	for client := range h.rooms[message.RoomID] {
+		targets = append(targets, client)
+	}
+	queued, _ := h.deliver(h.rooms[message.RoomID], data)
+	h.mutex.RUnlock()
+
+	for _, client := range targets {
+		h.removeClient(client)
+	}
+	return queued, nil
+}
+
+// broadcastToRoom sends a message to every client connected to the message's room
+func (h *Hub) broadcastToRoom(message *model.Message) (int, error) {
+	data, err := message.ToJSON()
+	if err != nil {
+		return 0, err
+	}
+
+	h.mutex.RLock()
+	queued, slow := h.deliver(h.rooms[message.RoomID], data)
+	h.mutex.RUnlock()
+
+	h.dropClients(slow)
+	return queued, nil
+}
+
+// deliver queues data on each client's send buffer
+// It returns the number of clients it was queued on and the clients whose buffer was full
+// The caller must hold at least a read lock
+func (h *Hub) deliver(targets map[*Client]bool, data []byte) (int, []*Client) {
+	queued := 0
+	var slow []*Client
+	for client := range targets {
+		select {
+		case client.send <- data:
+			queued++
+		default:
+			h.metrics.SendBufferDropped()
+			slow = append(slow, client)
+		}
+	}
+	return queued, slow
+}
+
+// dropClients disconnects clients that cannot keep up with their send buffer
+func (h *Hub) dropClients(clients []*Client) {
+	for _, client := range clients {
+		h.removeClient(client)
+	}
+}
+
+// handleMessage routes a message received from a client
+func (h *Hub) handleMessage(client *Client, message *model.Message) {
+	// 送信者・ルームはクライアントの接続情報で上書きする
+	message.ID = uuid.New()
+	message.SenderUserID = client.userID
+	message.RoomID = client.roomID
+
+	if !message.IsValid() {
+		return
+	}
+
+	// 入室前のユーザーはルームにメッセージを送れない
+	h.mutex.RLock()
+	waiting := client.waiting
+	h.mutex.RUnlock()
+	if waiting {
+		return
+	}
+
+	switch message.Type {
+	case model.MessageTypeWebRTCOffer, model.MessageTypeWebRTCAnswer, model.MessageTypeICECandidate, model.MessageTypeScreenShare:
+		// 役割で許可されていないシグナリングは転送しない
+		if !h.canSignal(client, message.Type) {
+			return
+		}
+		h.router.Route(context.Background(), message)
+	}
+}
+
+// canSignal reports whether the client's current role in its room allows the signaling message
+// The role is read from the room repository on every message since it changes while connected
+func (h *Hub) canSignal(client *Client, messageType model.MessageType) bool {
+	room, err := h.roomRepo.GetByID(context.Background(), client.roomID)
+	if err != nil {
+		return false
+	}
+	return room.RoleOf(client.userID).CanSignal(messageType)
+}
+
+func (h *Hub) addClient(client *Client) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.clients[client] = true
+	if !client.waiting {
+		h.addToRoom(client)
+	}
+
+	if h.users[client.userID] == nil {
+		h.users[client.userID] = make(map[*Client]bool)
+	}
+	h.users[client.userID][client] = true
+}
+
+// addToRoom makes the client receive the messages of its room
+// The caller must hold the write lock
+func (h *Hub) addToRoom(client *Client) {
+	if h.rooms[client.roomID] == nil {
+		h.rooms[client.roomID] = make(map[*Client]bool)
+	}
+	h.rooms[client.roomID][client] = true
+}
+
+func (h *Hub) removeClient(client *Client) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.clients[client] {
+		return
+	}
+
+	delete(h.clients, client)
+
+	delete(h.rooms[client.roomID], client)
+	if len(h.rooms[client.roomID]) == 0 {
+		delete(h.rooms, client.roomID)
+	}
+
+	delete(h.users[client.userID], client)
+	if len(h.users[client.userID]) == 0 {
+		delete(h.users, client.userID)
+	}
+
+	close(client.send)
+}
+
+func (h *Hub) closeAll() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for client := range h.clients {
+		close(client.send)
+	}
+	h.clients = make(map[*Client]bool)
+	h.rooms = make(map[uuid.UUID]map[*Client]bool)
+	h.users = make(map[uuid.UUID]map[*Client]bool)
+}
@@ -0,0 +1,815 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/cline-meet/backend/internal/infrastructure/token"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// testSigner signs the connection tokens of the test clients
+var testSigner, _ = token.NewHMACSigner([]byte("0123456789abcdef0123456789abcdef"))
+
+// connectionToken signs a token for userID to connect to roomID that expires after ttl
+func connectionToken(userID, roomID uuid.UUID, ttl time.Duration) string {
+	signed, _ := testSigner.SignConnection(&service.ConnectionClaims{UserID: userID, RoomID: roomID, ExpiresAt: time.Now().Add(ttl)})
+	return signed
+}
+
+func newTestHub(t *testing.T) (*Hub, *httptest.Server) {
+	t.Helper()
+
+	hub := NewHub(memory.NewRoom(), testSigner)
+	ctx, cancel := context.WithCancel(context.Background())
+	go hub.Run(ctx)
+
+	server := httptest.NewServer(hub)
+	t.Cleanup(func() {
+		server.Close()
+		cancel()
+	})
+	return hub, server
+}
+
+// joinRoom makes the user a participant of the room in the hub's room repository, creating the room if needed
+func joinRoom(t *testing.T, hub *Hub, userID, roomID uuid.UUID) {
+	t.Helper()
+
+	ctx := context.Background()
+	room, err := hub.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		room = model.NewRoom("Room", userID, false)
+		room.ID = roomID
+		if err := hub.roomRepo.Create(ctx, room); err != nil {
+			t.Fatalf("Expected no error creating room, got %v", err)
+		}
+	}
+	if room.IsParticipant(userID) {
+		return
+	}
+	if err := room.AddParticipant(userID); err != nil {
+		t.Fatalf("Expected no error joining room, got %v", err)
+	}
+	if err := hub.roomRepo.Update(ctx, room); err != nil {
+		t.Fatalf("Expected no error updating room, got %v", err)
+	}
+}
+
+// dialRequest connects to the hub as userID without joining the room first
+// Without a header the request comes from a page served by the hub
+func dialRequest(server *httptest.Server, userID, roomID uuid.UUID, header http.Header) (*websocket.Conn, *http.Response, error) {
+	if header == nil {
+		header = http.Header{"Origin": {server.URL}}
+	}
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?token=" + connectionToken(userID, roomID, time.Minute)
+	return websocket.DefaultDialer.Dial(url, header)
+}
+
+// dial joins the room and connects to the hub as userID
+func dial(t *testing.T, hub *Hub, server *httptest.Server, userID, roomID uuid.UUID) *websocket.Conn {
+	t.Helper()
+
+	joinRoom(t, hub, userID, roomID)
+
+	before := hub.ClientCount()
+	conn, _, err := dialRequest(server, userID, roomID, nil)
+	if err != nil {
+		t.Fatalf("Expected no error dialing hub, got %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	// 登録はHubのgoroutineで非同期に行われる
+	waitFor(t, func() bool { return hub.ClientCount() > before })
+	return conn
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) *model.Message {
+	t.Helper()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("Expected message, got error %v", err)
+	}
+	message, err := model.FromJSON(data)
+	if err != nil {
+		t.Fatalf("Expected valid JSON, got error %v", err)
+	}
+	return message
+}
+
+func expectNoMessage(t *testing.T, conn *websocket.Conn) {
+	t.Helper()
+
+	conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, data, err := conn.ReadMessage(); err == nil {
+		t.Errorf("Expected no message, got %s", data)
+	}
+}
+
+func TestHub_ServeHTTP_InvalidToken(t *testing.T) {
+	hub, server := newTestHub(t)
+	userID := uuid.New()
+	roomID := uuid.New()
+	joinRoom(t, hub, userID, roomID)
+	other, _ := token.NewHMACSigner([]byte("fedcba9876543210fedcba9876543210"))
+	forged, _ := other.SignConnection(&service.ConnectionClaims{UserID: userID, RoomID: roomID, ExpiresAt: time.Now().Add(time.Minute)})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"missing token", ""},
+		{"invalid token", "invalid"},
+		{"expired token", connectionToken(userID, roomID, -time.Second)},
+		{"other secret", forged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"?token="+tt.token, nil)
+			req.Header.Set("Origin", server.URL)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestHub_ServeHTTP_RequiresParticipant(t *testing.T) {
+	hub, server := newTestHub(t)
+	hostID := uuid.New()
+	roomID := uuid.New()
+	joinRoom(t, hub, hostID, roomID)
+
+	// 参加していないユーザーは他人のルームを購読できない
+	_, resp, err := dialRequest(server, uuid.New(), roomID, nil)
+	if err == nil {
+		t.Fatal("Expected connection to be rejected")
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, resp.StatusCode)
+	}
+
+	_, resp, err = dialRequest(server, hostID, uuid.New(), nil)
+	if err == nil {
+		t.Fatal("Expected connection to be rejected")
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+// dialWaiting puts the user in the room's waiting room and connects to the hub as them
+func dialWaiting(t *testing.T, hub *Hub, server *httptest.Server, waiting *memory.WaitingRoom, userID, roomID uuid.UUID) *websocket.Conn {
+	t.Helper()
+
+	if err := waiting.AddWaitingUser(context.Background(), roomID, userID); err != nil {
+		t.Fatalf("Expected no error adding waiting user, got %v", err)
+	}
+	before := hub.ClientCount()
+	conn, _, err := dialRequest(server, userID, roomID, nil)
+	if err != nil {
+		t.Fatalf("Expected the waiting user to connect, got %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	waitFor(t, func() bool { return hub.ClientCount() > before })
+	return conn
+}
+
+func TestHub_ServeHTTP_WaitingUserGetsAdmitted(t *testing.T) {
+	hub, server := newTestHub(t)
+	waiting := memory.NewWaitingRoom()
+	hub.SetWaitingRoom(waiting)
+	ctx := context.Background()
+	roomID := uuid.New()
+	hostID := uuid.New()
+	guestID := uuid.New()
+
+	host := dial(t, hub, server, hostID, roomID)
+	guest := dialWaiting(t, hub, server, waiting, guestID, roomID)
+
+	// 入室前はルームのメッセージを受け取らない
+	if hub.RoomClientCount(roomID) != 1 {
+		t.Errorf("Expected only the host in the room, got %d clients", hub.RoomClientCount(roomID))
+	}
+	hub.NotifyRoomJoined(ctx, roomID, uuid.New(), "Bob")
+	readMessage(t, host)
+
+	// 入室の許可はダイレクトメッセージで届き、以降はルームのメッセージも受け取る
+	if err := hub.SendDirectMessage(ctx, model.NewAdmitUserMessage(roomID, hostID, guestID, true, "")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if message := readMessage(t, guest); !message.IsAdmitted() {
+		t.Errorf("Expected the admission as the first message, got %+v", message)
+	}
+	if hub.RoomClientCount(roomID) != 2 {
+		t.Errorf("Expected the admitted guest in the room, got %d clients", hub.RoomClientCount(roomID))
+	}
+	hub.NotifyRoomJoined(ctx, roomID, guestID, "Guest")
+	for _, conn := range []*websocket.Conn{guest, host} {
+		if message := readMessage(t, conn); message.Type != model.MessageTypeUserJoined {
+			t.Errorf("Expected Type %s, got %s", model.MessageTypeUserJoined, message.Type)
+		}
+	}
+}
+
+func TestHub_ServeHTTP_WaitingUserGetsDenied(t *testing.T) {
+	hub, server := newTestHub(t)
+	waiting := memory.NewWaitingRoom()
+	hub.SetWaitingRoom(waiting)
+	roomID := uuid.New()
+	hostID := uuid.New()
+	guestID := uuid.New()
+
+	host := dial(t, hub, server, hostID, roomID)
+	guest := dialWaiting(t, hub, server, waiting, guestID, roomID)
+
+	// 待機中のメッセージは転送しない
+	offer := model.NewWebRTCOffer(guestID, hostID, roomID, "v=0")
+	data, _ := offer.ToJSON()
+	if err := guest.WriteMessage(websocket.TextMessage, data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectNoMessage(t, host)
+
+	if err := hub.SendDirectMessage(context.Background(), model.NewAdmitUserMessage(roomID, hostID, guestID, false, "full")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 拒否を受け取ってから切断される
+	message := readMessage(t, guest)
+	if payload := message.Payload.(model.ControlPayload); payload.Action != "deny" || payload.Reason != "full" {
+		t.Errorf("Expected denial with reason, got %+v", payload)
+	}
+	guest.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := guest.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("Expected normal closure, got %v", err)
+	}
+	if hub.ClientCount() != 1 {
+		t.Errorf("Expected only the host to stay connected, got %d clients", hub.ClientCount())
+	}
+}
+
+func TestHub_ServeHTTP_ChecksOrigin(t *testing.T) {
+	tests := []struct {
+		name     string
+		allowed  []string
+		origin   func(server *httptest.Server) string
+		accepted bool
+	}{
+		{"no origin", nil, func(*httptest.Server) string { return "" }, false},
+		{"same host", nil, func(server *httptest.Server) string { return server.URL }, true},
+		{"cross site", nil, func(*httptest.Server) string { return "https://evil.example.com" }, false},
+		{"allowed origin", []string{"https://meet.example.com/"}, func(*httptest.Server) string { return "https://meet.example.com" }, true},
+		{"not allowed origin", []string{"https://meet.example.com"}, func(server *httptest.Server) string { return server.URL }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub, server := newTestHub(t)
+			hub.SetAllowedOrigins(tt.allowed)
+			userID := uuid.New()
+			roomID := uuid.New()
+			joinRoom(t, hub, userID, roomID)
+
+			header := http.Header{}
+			if origin := tt.origin(server); origin != "" {
+				header.Set("Origin", origin)
+			}
+			conn, resp, err := dialRequest(server, userID, roomID, header)
+			if tt.accepted {
+				if err != nil {
+					t.Fatalf("Expected connection to be accepted, got %v", err)
+				}
+				conn.Close()
+				return
+			}
+			if err == nil {
+				conn.Close()
+				t.Fatal("Expected connection to be rejected")
+			}
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("Expected status %d, got %d", http.StatusForbidden, resp.StatusCode)
+			}
+		})
+	}
+}
+
+// failingSessionManager is a service.SessionManager whose sessions cannot be created
+type failingSessionManager struct {
+	service.SessionManager
+}
+
+func (failingSessionManager) CreateSession(ctx context.Context, userID uuid.UUID, connectionID string) error {
+	return errors.New("redis unavailable")
+}
+
+func TestHub_ServeHTTP_RejectsWithoutSession(t *testing.T) {
+	hub, server := newTestHub(t)
+	hub.SetSessionManager(failingSessionManager{})
+	userID := uuid.New()
+	roomID := uuid.New()
+	joinRoom(t, hub, userID, roomID)
+
+	// セッションがないとダイレクトメッセージが届かないので接続させない
+	_, resp, err := dialRequest(server, userID, roomID, nil)
+	if err == nil {
+		t.Fatal("Expected connection to be rejected")
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	if hub.ClientCount() != 0 {
+		t.Errorf("Expected no clients, got %d", hub.ClientCount())
+	}
+}
+
+func TestHub_ServeHTTP_MaxConnections(t *testing.T) {
+	hub, server := newTestHub(t)
+	hub.SetMaxConnections(1)
+	roomID := uuid.New()
+
+	dial(t, hub, server, uuid.New(), roomID)
+
+	otherID := uuid.New()
+	joinRoom(t, hub, otherID, roomID)
+	_, resp, err := dialRequest(server, otherID, roomID, nil)
+	if err == nil {
+		t.Fatal("Expected connection to be rejected")
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+}
+
+func TestHub_ServeHTTP_CreatesSession(t *testing.T) {
+	hub, server := newTestHub(t)
+	sessions := memory.NewSessionManager("realtime-hub-1")
+	hub.SetSessionManager(sessions)
+	userID := uuid.New()
+
+	dial(t, hub, server, userID, uuid.New())
+
+	var session *service.UserSession
+	waitFor(t, func() bool {
+		session, _ = sessions.GetSession(context.Background(), userID)
+		return session != nil
+	})
+	if session.ServerPod != "realtime-hub-1" {
+		t.Errorf("Expected ServerPod realtime-hub-1, got %s", session.ServerPod)
+	}
+	if session.ConnectionID == "" {
+		t.Error("Expected ConnectionID to be set")
+	}
+}
+
+func TestHub_NotifyRoomJoined(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	otherRoomID := uuid.New()
+
+	conn1 := dial(t, hub, server, uuid.New(), roomID)
+	conn2 := dial(t, hub, server, uuid.New(), roomID)
+	outsider := dial(t, hub, server, uuid.New(), otherRoomID)
+
+	joinedID := uuid.New()
+	if err := hub.NotifyRoomJoined(context.Background(), roomID, joinedID, "Alice"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, conn := range []*websocket.Conn{conn1, conn2} {
+		message := readMessage(t, conn)
+		if message.Type != model.MessageTypeUserJoined {
+			t.Errorf("Expected Type %s, got %s", model.MessageTypeUserJoined, message.Type)
+		}
+		if message.RoomID != roomID {
+			t.Errorf("Expected RoomID %s, got %s", roomID, message.RoomID)
+		}
+
+		payload, _ := json.Marshal(message.Payload)
+		var participant model.ParticipantPayload
+		json.Unmarshal(payload, &participant)
+		if participant.UserID != joinedID || participant.UserName != "Alice" {
+			t.Errorf("Expected payload for %s/Alice, got %+v", joinedID, participant)
+		}
+	}
+
+	// 別ルームには配信されない
+	expectNoMessage(t, outsider)
+}
+
+func TestHub_NotifyRoomLeft(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	conn := dial(t, hub, server, uuid.New(), roomID)
+
+	if err := hub.NotifyRoomLeft(context.Background(), roomID, uuid.New(), "Bob"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	message := readMessage(t, conn)
+	if message.Type != model.MessageTypeUserLeft {
+		t.Errorf("Expected Type %s, got %s", model.MessageTypeUserLeft, message.Type)
+	}
+}
+
+func TestHub_NotifyUserMuted(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	actorID := uuid.New()
+	targetID := uuid.New()
+	conn := dial(t, hub, server, uuid.New(), roomID)
+
+	tests := []struct {
+		name    string
+		isMuted bool
+		action  string
+	}{
+		{"mute", true, "mute"},
+		{"unmute", false, "unmute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := hub.NotifyUserMuted(context.Background(), roomID, actorID, targetID, tt.isMuted); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			message := readMessage(t, conn)
+			if message.Type != model.MessageTypeMuteUser {
+				t.Errorf("Expected Type %s, got %s", model.MessageTypeMuteUser, message.Type)
+			}
+			if message.SenderUserID != actorID || !message.IsValid() {
+				t.Errorf("Expected a valid message sent by %s, got %+v", actorID, message)
+			}
+
+			payload, _ := json.Marshal(message.Payload)
+			var control model.ControlPayload
+			json.Unmarshal(payload, &control)
+			if control.Action != tt.action {
+				t.Errorf("Expected Action %s, got %s", tt.action, control.Action)
+			}
+			if control.TargetID != targetID {
+				t.Errorf("Expected TargetID %s, got %s", targetID, control.TargetID)
+			}
+		})
+	}
+}
+
+func TestHub_BroadcastChatMessage(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	senderID := uuid.New()
+	conn := dial(t, hub, server, senderID, roomID)
+
+	chat := model.NewChatMessage(senderID, roomID, "Hello", "Alice")
+	if err := hub.BroadcastChatMessage(context.Background(), chat); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	message := readMessage(t, conn)
+	if message.ID != chat.ID {
+		t.Errorf("Expected ID %s, got %s", chat.ID, message.ID)
+	}
+	if message.Type != model.MessageTypeChatMessage {
+		t.Errorf("Expected Type %s, got %s", model.MessageTypeChatMessage, message.Type)
+	}
+}
+
+func TestHub_SendDirectMessage(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	senderID := uuid.New()
+	targetID := uuid.New()
+
+	sender := dial(t, hub, server, senderID, roomID)
+	target := dial(t, hub, server, targetID, roomID)
+
+	offer := model.NewWebRTCOffer(senderID, targetID, roomID, "v=0")
+	if err := hub.SendDirectMessage(context.Background(), offer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	message := readMessage(t, target)
+	if message.Type != model.MessageTypeWebRTCOffer {
+		t.Errorf("Expected Type %s, got %s", model.MessageTypeWebRTCOffer, message.Type)
+	}
+	expectNoMessage(t, sender)
+
+	// 未接続のユーザー
+	offline := model.NewWebRTCOffer(senderID, uuid.New(), roomID, "v=0")
+	if err := hub.SendDirectMessage(context.Background(), offline); !errors.Is(err, ErrUserNotConnected) {
+		t.Errorf("Expected ErrUserNotConnected, got %v", err)
+	}
+
+	// ターゲット未指定
+	broadcast := model.NewChatMessage(senderID, roomID, "Hello", "Alice")
+	if err := hub.SendDirectMessage(context.Background(), broadcast); err == nil {
+		t.Error("Expected error for message without target")
+	}
+}
+
+func TestHub_NotifyRoomUpdate(t *testing.T) {
+	hub, server := newTestHub(t)
+	room := model.NewRoom("Test Room", uuid.New(), false)
+	conn := dial(t, hub, server, room.HostID, room.ID)
+
+	if err := hub.NotifyRoomUpdate(context.Background(), room); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	message := readMessage(t, conn)
+	if message.Type != model.MessageTypeRoomUpdate {
+		t.Errorf("Expected Type %s, got %s", model.MessageTypeRoomUpdate, message.Type)
+	}
+
+	payload, _ := json.Marshal(message.Payload)
+	var updated model.Room
+	json.Unmarshal(payload, &updated)
+	if updated.ID != room.ID {
+		t.Errorf("Expected room %s, got %s", room.ID, updated.ID)
+	}
+}
+
+func TestHub_NotifyHostChanged(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	previousHostID := uuid.New()
+	newHostID := uuid.New()
+	conn := dial(t, hub, server, newHostID, roomID)
+
+	if err := hub.NotifyHostChanged(context.Background(), roomID, previousHostID, newHostID, model.HostChangeReasonLeft); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	message := readMessage(t, conn)
+	if message.Type != model.MessageTypeHostChanged {
+		t.Errorf("Expected Type %s, got %s", model.MessageTypeHostChanged, message.Type)
+	}
+	if payload := message.Payload.(model.HostChangedPayload); payload.NewHostID != newHostID {
+		t.Errorf("Expected NewHostID %s, got %s", newHostID, payload.NewHostID)
+	}
+}
+
+func TestHub_KickDisconnectsUser(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	hostID := uuid.New()
+	targetID := uuid.New()
+
+	host := dial(t, hub, server, hostID, roomID)
+	target := dial(t, hub, server, targetID, roomID)
+	otherRoom := dial(t, hub, server, targetID, uuid.New())
+
+	kick := model.NewKickUserMessage(roomID, hostID, targetID, true, "spam")
+	if err := hub.SendDirectMessage(context.Background(), kick); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 通知を受け取ってから切断される
+	message := readMessage(t, target)
+	if payload := message.Payload.(model.ControlPayload); payload.Action != "ban" || payload.Reason != "spam" {
+		t.Errorf("Expected ban with reason, got %+v", payload)
+	}
+	target.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := target.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("Expected normal closure, got %v", err)
+	}
+
+	if hub.RoomClientCount(roomID) != 1 {
+		t.Errorf("Expected only the host to remain, got %d clients", hub.RoomClientCount(roomID))
+	}
+	expectNoMessage(t, host)
+	expectNoMessage(t, otherRoom)
+	if hub.ClientCount() != 2 {
+		t.Errorf("Expected connection to another room to stay open, got %d clients", hub.ClientCount())
+	}
+}
+
+func TestHub_NotifyRoomClosed(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+
+	first := dial(t, hub, server, uuid.New(), roomID)
+	second := dial(t, hub, server, uuid.New(), roomID)
+	otherRoom := dial(t, hub, server, uuid.New(), uuid.New())
+
+	if err := hub.NotifyRoomClosed(context.Background(), roomID, model.RoomCloseReasonExpired); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 全員に通知してから切断する
+	for _, conn := range []*websocket.Conn{first, second} {
+		message := readMessage(t, conn)
+		if payload := message.Payload.(model.RoomClosedPayload); payload.Reason != model.RoomCloseReasonExpired {
+			t.Errorf("Expected reason %s, got %+v", model.RoomCloseReasonExpired, payload)
+		}
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			t.Errorf("Expected normal closure, got %v", err)
+		}
+	}
+
+	if hub.RoomClientCount(roomID) != 0 {
+		t.Errorf("Expected no clients in the closed room, got %d", hub.RoomClientCount(roomID))
+	}
+	expectNoMessage(t, otherRoom)
+	if hub.ClientCount() != 1 {
+		t.Errorf("Expected connection to another room to stay open, got %d clients", hub.ClientCount())
+	}
+}
+
+func TestHub_RelaysSignalingFromClient(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	senderID := uuid.New()
+	targetID := uuid.New()
+
+	sender := dial(t, hub, server, senderID, roomID)
+	target := dial(t, hub, server, targetID, roomID)
+
+	// 送信者IDは接続情報で上書きされる
+	spoofed := model.NewICECandidate(uuid.New(), targetID, uuid.New(), "candidate:1", "0", 0)
+	data, _ := spoofed.ToJSON()
+	if err := sender.WriteMessage(websocket.TextMessage, data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	message := readMessage(t, target)
+	if message.Type != model.MessageTypeICECandidate {
+		t.Errorf("Expected Type %s, got %s", model.MessageTypeICECandidate, message.Type)
+	}
+	if message.SenderUserID != senderID {
+		t.Errorf("Expected SenderUserID %s, got %s", senderID, message.SenderUserID)
+	}
+	if message.RoomID != roomID {
+		t.Errorf("Expected RoomID %s, got %s", roomID, message.RoomID)
+	}
+}
+
+func TestHub_DropsSignalingNotAllowedByRole(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	hostID := uuid.New()
+	attendeeID := uuid.New()
+	viewerID := uuid.New()
+
+	host := dial(t, hub, server, hostID, roomID)
+	attendee := dial(t, hub, server, attendeeID, roomID)
+	viewer := dial(t, hub, server, viewerID, roomID)
+
+	ctx := context.Background()
+	room, _ := hub.roomRepo.GetByID(ctx, roomID)
+	if err := room.SetRole(hostID, viewerID, model.RoleViewer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := hub.roomRepo.Update(ctx, room); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	send := func(conn *websocket.Conn, message *model.Message) {
+		t.Helper()
+		data, _ := message.ToJSON()
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// 同じ接続のメッセージは順に処理されるので、破棄されたメッセージの次のメッセージが届く
+	send(attendee, model.NewMessage(model.MessageTypeScreenShare, attendeeID, roomID, model.ControlPayload{Action: "start"}))
+	send(attendee, model.NewWebRTCOffer(attendeeID, hostID, roomID, "v=0"))
+	if message := readMessage(t, host); message.Type != model.MessageTypeWebRTCOffer || message.SenderUserID != attendeeID {
+		t.Errorf("Expected the attendee's offer, got %+v", message)
+	}
+
+	// 視聴者は受信のための応答とICE候補だけ送れる
+	send(viewer, model.NewWebRTCOffer(viewerID, hostID, roomID, "v=0"))
+	send(viewer, model.NewWebRTCAnswer(viewerID, hostID, roomID, "v=0"))
+	if message := readMessage(t, host); message.Type != model.MessageTypeWebRTCAnswer || message.SenderUserID != viewerID {
+		t.Errorf("Expected the viewer's answer, got %+v", message)
+	}
+}
+
+func TestHub_UnregisterOnClose(t *testing.T) {
+	hub, server := newTestHub(t)
+	roomID := uuid.New()
+	conn := dial(t, hub, server, uuid.New(), roomID)
+
+	if hub.RoomClientCount(roomID) != 1 {
+		t.Errorf("Expected 1 client in room, got %d", hub.RoomClientCount(roomID))
+	}
+
+	conn.Close()
+	waitFor(t, func() bool { return hub.ClientCount() == 0 })
+
+	if hub.RoomClientCount(roomID) != 0 {
+		t.Errorf("Expected 0 clients in room, got %d", hub.RoomClientCount(roomID))
+	}
+}
+
+func TestHub_DropsSlowClient(t *testing.T) {
+	hub := NewHub(memory.NewRoom(), testSigner)
+	roomID := uuid.New()
+
+	// 接続なしのクライアントを直接登録し、送信バッファを溢れさせる
+	client := &Client{hub: hub, send: make(chan []byte, 1), userID: uuid.New(), roomID: roomID}
+	hub.addClient(client)
+
+	ctx := context.Background()
+	hub.NotifyRoomJoined(ctx, roomID, uuid.New(), "Alice")
+	hub.NotifyRoomJoined(ctx, roomID, uuid.New(), "Bob")
+
+	if hub.ClientCount() != 0 {
+		t.Errorf("Expected slow client to be dropped, got %d clients", hub.ClientCount())
+	}
+	if _, ok := <-client.send; !ok {
+		t.Error("Expected buffered message before close")
+	}
+	if _, ok := <-client.send; ok {
+		t.Error("Expected send channel to be closed")
+	}
+}
+
+// recordingMetrics is a Metrics that records delivered message types and drops
+type recordingMetrics struct {
+	delivered []model.MessageType
+	drops     int
+}
+
+func (m *recordingMetrics) MessageDelivered(messageType model.MessageType, elapsed time.Duration) {
+	m.delivered = append(m.delivered, messageType)
+}
+
+func (m *recordingMetrics) SendBufferDropped() {
+	m.drops++
+}
+
+func TestHub_RecordsMetrics(t *testing.T) {
+	hub := NewHub(memory.NewRoom(), testSigner)
+	metrics := &recordingMetrics{}
+	hub.SetMetrics(metrics)
+	roomID := uuid.New()
+
+	client := &Client{hub: hub, send: make(chan []byte, 1), userID: uuid.New(), roomID: roomID}
+	hub.addClient(client)
+
+	ctx := context.Background()
+	hub.BroadcastChatMessage(ctx, model.NewChatMessage(client.userID, roomID, "hello", "Alice"))
+	// 送信バッファが満杯のクライアントと、接続のないルームへの配信は数えない
+	hub.NotifyRoomJoined(ctx, roomID, uuid.New(), "Alice")
+	hub.NotifyRoomJoined(ctx, uuid.New(), uuid.New(), "Bob")
+
+	if len(metrics.delivered) != 1 || metrics.delivered[0] != model.MessageTypeChatMessage {
+		t.Errorf("Expected only the chat message to be delivered, got %v", metrics.delivered)
+	}
+	if metrics.drops != 1 {
+		t.Errorf("Expected the full send buffer to be recorded once, got %d", metrics.drops)
+	}
+}
+
+func TestHub_RunClosesClientsOnShutdown(t *testing.T) {
+	hub := NewHub(memory.NewRoom(), testSigner)
+	ctx, cancel := context.WithCancel(context.Background())
+	go hub.Run(ctx)
+
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	conn := dial(t, hub, server, uuid.New(), uuid.New())
+	cancel()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("Expected normal closure, got %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	// EventsChannel is the Redis Pub/Sub channel shared by all pods
+	EventsChannel = "realtime_events"
+
+	// seenTTL is how long a message ID is remembered for deduplication
+	seenTTL = time.Minute
+)
+
+// event is the envelope published on EventsChannel
+type event struct {
+	Origin    string          `json:"origin"`
+	TargetPod string          `json:"targetPod,omitempty"`
+	Message   json.RawMessage `json:"message"`
+}
+
+// Relay fans messages out across pods via Redis Pub/Sub
+// It delivers to the local Hub directly and publishes to the other pods,
+// routing direct messages only to the pod recorded in the target's UserSession.ServerPod
+type Relay struct {
+	hub      *Hub
+	client   goredis.UniversalClient
+	sessions service.SessionManager
+	pod      string
+	seen     *seenCache
+}
+
+var _ service.RealtimeNotifier = (*Relay)(nil)
+
+// NewRelay creates a new Relay for the hub running on pod
+// The relay becomes the hub's router so client signaling also crosses pods
+func NewRelay(hub *Hub, client goredis.UniversalClient, sessions service.SessionManager, pod string) *Relay {
+	r := &Relay{
+		hub:      hub,
+		client:   client,
+		sessions: sessions,
+		pod:      pod,
+		seen:     newSeenCache(seenTTL),
+	}
+	hub.SetRouter(r)
+	return r
+}
+
+// Run subscribes to EventsChannel and delivers events from other pods until the context is cancelled
+func (r *Relay) Run(ctx context.Context) error {
+	pubsub := r.client.Subscribe(ctx, EventsChannel)
+	defer pubsub.Close()
+
+	// 購読の確立を待つ
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", EventsChannel, err)
+	}
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			r.handleEvent([]byte(msg.Payload))
+		}
+	}
+}
+
+// NotifyRoomJoined notifies all participants that a user joined the room
+func (r *Relay) NotifyRoomJoined(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	return r.Route(ctx, model.NewUserJoinedMessage(roomID, userID, userName))
+}
+
+// NotifyRoomLeft notifies all participants that a user left the room
+func (r *Relay) NotifyRoomLeft(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	return r.Route(ctx, model.NewUserLeftMessage(roomID, userID, userName))
+}
+
+// NotifyUserMuted notifies all participants that the actor muted or unmuted a user
+func (r *Relay) NotifyUserMuted(ctx context.Context, roomID, actorID, userID uuid.UUID, isMuted bool) error {
+	return r.Route(ctx, model.NewMuteUserMessage(roomID, actorID, userID, isMuted))
+}
+
+// BroadcastChatMessage broadcasts a chat message to all room participants on every pod
+func (r *Relay) BroadcastChatMessage(ctx context.Context, message *model.Message) error {
+	return r.broadcast(ctx, message)
+}
+
+// SendDirectMessage sends a direct message to the pod holding the target user's connection
+func (r *Relay) SendDirectMessage(ctx context.Context, message *model.Message) error {
+	if !message.IsDirectMessage() {
+		return ErrNoTargetUser
+	}
+	return r.sendDirect(ctx, message)
+}
+
+// NotifyRoomUpdate notifies participants about room setting changes
+func (r *Relay) NotifyRoomUpdate(ctx context.Context, room *model.Room) error {
+	return r.Route(ctx, model.NewRoomUpdateMessage(room))
+}
+
+// NotifyHostChanged notifies all participants that the host role moved to another user
+func (r *Relay) NotifyHostChanged(ctx context.Context, roomID, previousHostID, newHostID uuid.UUID, reason string) error {
+	return r.Route(ctx, model.NewHostChangedMessage(roomID, previousHostID, newHostID, reason))
+}
+
+// NotifyRoomClosed notifies the remaining participants on every pod that the room ended and disconnects them
+func (r *Relay) NotifyRoomClosed(ctx context.Context, roomID uuid.UUID, reason string) error {
+	return r.Route(ctx, model.NewRoomClosedMessage(roomID, reason))
+}
+
+// Route delivers a message locally and to the other pods
+func (r *Relay) Route(ctx context.Context, message *model.Message) error {
+	if message.IsDirectMessage() {
+		return r.sendDirect(ctx, message)
+	}
+	return r.broadcast(ctx, message)
+}
+
+// broadcast delivers a room message on this pod and publishes it to all other pods
+func (r *Relay) broadcast(ctx context.Context, message *model.Message) error {
+	r.seen.add(message.ID)
+
+	// 1. 自分のPod内の該当ルームに配信
+	if err := r.hub.Deliver(message); err != nil {
+		return err
+	}
+
+	// 2. 他のPodにも通知（Redis Pub/Sub）
+	return r.publish(ctx, message, "")
+}
+
+// sendDirect delivers a direct message only on the pod named in the target's session
+func (r *Relay) sendDirect(ctx context.Context, message *model.Message) error {
+	session, err := r.sessions.GetSession(ctx, message.TargetUserID)
+	if err == nil && session.ServerPod != "" && session.ServerPod != r.pod {
+		return r.publish(ctx, message, session.ServerPod)
+	}
+
+	// セッションが見つからない場合はローカル接続にのみ配信する
+	r.seen.add(message.ID)
+	return r.hub.Deliver(message)
+}
+
+func (r *Relay) publish(ctx context.Context, message *model.Message, targetPod string) error {
+	data, err := message.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event{Origin: r.pod, TargetPod: targetPod, Message: data})
+	if err != nil {
+		return err
+	}
+
+	return r.client.Publish(ctx, EventsChannel, payload).Err()
+}
+
+// handleEvent delivers an event received from Pub/Sub unless it was already delivered here
+func (r *Relay) handleEvent(data []byte) {
+	var e event
+	if err := json.Unmarshal(data, &e); err != nil {
+		return
+	}
+	if e.TargetPod != "" && e.TargetPod != r.pod {
+		return
+	}
+
+	message, err := model.FromJSON(e.Message)
+	if err != nil {
+		return
+	}
+
+	// 自分が配信済みのメッセージは二重配信しない
+	if !r.seen.add(message.ID) {
+		return
+	}
+
+	// 対象ユーザーが既に切断している場合は何もしない
+	r.hub.Deliver(message)
+}
+
+// seenCache remembers message IDs for a limited time
+type seenCache struct {
+	ttl       time.Duration
+	entries   map[uuid.UUID]time.Time
+	nextPrune time.Time
+	mutex     sync.Mutex
+}
+
+func newSeenCache(ttl time.Duration) *seenCache {
+	return &seenCache{
+		ttl:     ttl,
+		entries: make(map[uuid.UUID]time.Time),
+	}
+}
+
+// add records the ID and reports whether it was not seen before
+func (c *seenCache) add(id uuid.UUID) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	if expiresAt, ok := c.entries[id]; ok && now.Before(expiresAt) {
+		return false
+	}
+
+	// 期限切れのIDをTTLごとにまとめて掃除する
+	if !now.Before(c.nextPrune) {
+		for seenID, expiresAt := range c.entries {
+			if !now.Before(expiresAt) {
+				delete(c.entries, seenID)
+			}
+		}
+		c.nextPrune = now.Add(c.ttl)
+	}
+
+	c.entries[id] = now.Add(c.ttl)
+	return true
+}
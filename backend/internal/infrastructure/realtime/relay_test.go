@@ -0,0 +1,216 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	redisstore "github.com/cline-meet/backend/internal/infrastructure/redis"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+type testPod struct {
+	hub    *Hub
+	server *httptest.Server
+	relay  *Relay
+}
+
+// newTestPods starts one hub and relay per pod name, all sharing a single Redis
+func newTestPods(t *testing.T, names ...string) (map[string]*testPod, *redisstore.SessionManager) {
+	t.Helper()
+
+	redisServer := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	newClient := func() *goredis.Client {
+		client := goredis.NewClient(&goredis.Options{Addr: redisServer.Addr()})
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+	sessions := redisstore.NewSessionManager(newClient(), "")
+
+	// ルームは全Podで共有するデータベースにある
+	rooms := memory.NewRoom()
+
+	pods := make(map[string]*testPod)
+	for _, name := range names {
+		hub := NewHub(rooms, testSigner)
+		go hub.Run(ctx)
+
+		server := httptest.NewServer(hub)
+		t.Cleanup(server.Close)
+
+		relay := NewRelay(hub, newClient(), sessions, name)
+		go relay.Run(ctx)
+
+		pods[name] = &testPod{hub: hub, server: server, relay: relay}
+	}
+
+	waitFor(t, func() bool {
+		return redisServer.PubSubNumSub(EventsChannel)[EventsChannel] == len(names)
+	})
+	return pods, sessions
+}
+
+func TestRelay_BroadcastAcrossPods(t *testing.T) {
+	pods, _ := newTestPods(t, "pod-a", "pod-b")
+	a, b := pods["pod-a"], pods["pod-b"]
+	roomID := uuid.New()
+
+	connA := dial(t, a.hub, a.server, uuid.New(), roomID)
+	connB := dial(t, b.hub, b.server, uuid.New(), roomID)
+
+	joinedID := uuid.New()
+	if err := a.relay.NotifyRoomJoined(context.Background(), roomID, joinedID, "Alice"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, message := range []*model.Message{readMessage(t, connA), readMessage(t, connB)} {
+		if message.Type != model.MessageTypeUserJoined {
+			t.Errorf("Expected Type %s, got %s", model.MessageTypeUserJoined, message.Type)
+		}
+		payload := message.Payload.(model.ParticipantPayload)
+		if payload.UserID != joinedID {
+			t.Errorf("Expected UserID %s, got %s", joinedID, payload.UserID)
+		}
+	}
+
+	// 発行元Podでは自分のイベントを二重配信しない
+	expectNoMessage(t, connA)
+	expectNoMessage(t, connB)
+}
+
+func TestRelay_BroadcastChatMessageKeepsID(t *testing.T) {
+	pods, _ := newTestPods(t, "pod-a", "pod-b")
+	a, b := pods["pod-a"], pods["pod-b"]
+	roomID := uuid.New()
+	connB := dial(t, b.hub, b.server, uuid.New(), roomID)
+
+	chat := model.NewChatMessage(uuid.New(), roomID, "Hello", "Alice")
+	if err := a.relay.BroadcastChatMessage(context.Background(), chat); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	message := readMessage(t, connB)
+	if message.ID != chat.ID {
+		t.Errorf("Expected ID %s, got %s", chat.ID, message.ID)
+	}
+	if payload := message.Payload.(model.ChatPayload); payload.Message != "Hello" {
+		t.Errorf("Expected Message Hello, got %s", payload.Message)
+	}
+}
+
+func TestRelay_SendDirectMessageRoutesToSessionPod(t *testing.T) {
+	pods, sessions := newTestPods(t, "pod-a", "pod-b", "pod-c")
+	a, b, c := pods["pod-a"], pods["pod-b"], pods["pod-c"]
+	roomID := uuid.New()
+	senderID := uuid.New()
+	targetID := uuid.New()
+
+	// 対象ユーザーはpod-bとpod-cの両方に接続しているが、セッションはpod-b
+	connB := dial(t, b.hub, b.server, targetID, roomID)
+	connC := dial(t, c.hub, c.server, targetID, roomID)
+	sessions.UpdateSession(context.Background(), &service.UserSession{UserID: targetID, RoomID: roomID, ServerPod: "pod-b"})
+
+	offer := model.NewWebRTCOffer(senderID, targetID, roomID, "v=0")
+	if err := a.relay.SendDirectMessage(context.Background(), offer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	message := readMessage(t, connB)
+	if message.ID != offer.ID {
+		t.Errorf("Expected ID %s, got %s", offer.ID, message.ID)
+	}
+	if payload := message.Payload.(model.WebRTCPayload); payload.SDP != "v=0" {
+		t.Errorf("Expected SDP v=0, got %s", payload.SDP)
+	}
+	expectNoMessage(t, connC)
+}
+
+func TestRelay_SendDirectMessageLocalSession(t *testing.T) {
+	pods, sessions := newTestPods(t, "pod-a", "pod-b")
+	a, b := pods["pod-a"], pods["pod-b"]
+	roomID := uuid.New()
+	targetID := uuid.New()
+
+	connA := dial(t, a.hub, a.server, targetID, roomID)
+	connB := dial(t, b.hub, b.server, targetID, roomID)
+	sessions.UpdateSession(context.Background(), &service.UserSession{UserID: targetID, RoomID: roomID, ServerPod: "pod-a"})
+
+	offer := model.NewWebRTCOffer(uuid.New(), targetID, roomID, "v=0")
+	if err := a.relay.SendDirectMessage(context.Background(), offer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	readMessage(t, connA)
+	expectNoMessage(t, connB)
+}
+
+func TestRelay_ClientSignalingCrossesPods(t *testing.T) {
+	pods, sessions := newTestPods(t, "pod-a", "pod-b")
+	a, b := pods["pod-a"], pods["pod-b"]
+	roomID := uuid.New()
+	senderID := uuid.New()
+	targetID := uuid.New()
+
+	sender := dial(t, a.hub, a.server, senderID, roomID)
+	target := dial(t, b.hub, b.server, targetID, roomID)
+	sessions.UpdateSession(context.Background(), &service.UserSession{UserID: targetID, RoomID: roomID, ServerPod: "pod-b"})
+
+	answer := model.NewWebRTCAnswer(senderID, targetID, roomID, "v=0")
+	data, _ := answer.ToJSON()
+	if err := sender.WriteJSON(json.RawMessage(data)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	message := readMessage(t, target)
+	if message.Type != model.MessageTypeWebRTCAnswer {
+		t.Errorf("Expected Type %s, got %s", model.MessageTypeWebRTCAnswer, message.Type)
+	}
+	if message.SenderUserID != senderID {
+		t.Errorf("Expected SenderUserID %s, got %s", senderID, message.SenderUserID)
+	}
+}
+
+func TestRelay_DedupesByMessageID(t *testing.T) {
+	pods, _ := newTestPods(t, "pod-a")
+	a := pods["pod-a"]
+	roomID := uuid.New()
+	conn := dial(t, a.hub, a.server, uuid.New(), roomID)
+
+	message := model.NewChatMessage(uuid.New(), roomID, "Hello", "Alice")
+	data, _ := message.ToJSON()
+	payload, _ := json.Marshal(event{Origin: "pod-x", Message: data})
+
+	a.relay.handleEvent(payload)
+	a.relay.handleEvent(payload)
+
+	readMessage(t, conn)
+	expectNoMessage(t, conn)
+}
+
+func TestSeenCache_Add(t *testing.T) {
+	cache := newSeenCache(seenTTL)
+	id := uuid.New()
+
+	if !cache.add(id) {
+		t.Error("Expected first add to report unseen")
+	}
+	if cache.add(id) {
+		t.Error("Expected second add to report seen")
+	}
+
+	// 期限切れのIDは再度配信対象になる
+	expired := newSeenCache(0)
+	expired.add(id)
+	if !expired.add(id) {
+		t.Error("Expected expired ID to be reported unseen")
+	}
+}
@@ -0,0 +1,69 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// leaderKey returns the Redis key holding the name of the pod that leads election
+func leaderKey(election string) string {
+	return "leader:" + election
+}
+
+// acquireScript takes the lease when it is free and renews it when this pod already holds it
+var acquireScript = goredis.NewScript(`
+local holder = redis.call('GET', KEYS[1])
+if holder == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+if holder == false then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// releaseScript deletes the lease only when this pod holds it
+var releaseScript = goredis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// LeaderElector is a Redis implementation of service.LeaderElector
+// The lease is a key holding the leader's pod name that expires unless the leader renews it
+type LeaderElector struct {
+	client goredis.UniversalClient
+	key    string
+	pod    string
+}
+
+var _ service.LeaderElector = (*LeaderElector)(nil)
+
+// NewLeaderElector creates a new Redis LeaderElector for election, competing as pod
+func NewLeaderElector(client goredis.UniversalClient, election, pod string) *LeaderElector {
+	return &LeaderElector{
+		client: client,
+		key:    leaderKey(election),
+		pod:    pod,
+	}
+}
+
+// Acquire takes the lease or renews it if this pod already holds it
+func (e *LeaderElector) Acquire(ctx context.Context, ttl time.Duration) (bool, error) {
+	acquired, err := acquireScript.Run(ctx, e.client, []string{e.key}, e.pod, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return acquired == 1, nil
+}
+
+// Release gives up the lease if this pod holds it
+func (e *LeaderElector) Release(ctx context.Context) error {
+	return releaseScript.Run(ctx, e.client, []string{e.key}, e.pod).Err()
+}
@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLeaderElector_Acquire(t *testing.T) {
+	server, client := newTestRedis(t)
+	first := NewLeaderElector(client, "scheduler", "realtime-hub-1")
+	second := NewLeaderElector(client, "scheduler", "realtime-hub-2")
+	ctx := context.Background()
+
+	if ok, err := first.Acquire(ctx, 30*time.Second); !ok || err != nil {
+		t.Fatalf("Expected first pod to become leader, got %v %v", ok, err)
+	}
+	if ok, _ := second.Acquire(ctx, 30*time.Second); ok {
+		t.Error("Expected second pod not to become leader while the lease is held")
+	}
+
+	// リーダーは期限前に更新できる
+	server.FastForward(20 * time.Second)
+	if ok, _ := first.Acquire(ctx, 30*time.Second); !ok {
+		t.Error("Expected leader to renew its lease")
+	}
+	if ttl := server.TTL(leaderKey("scheduler")); ttl != 30*time.Second {
+		t.Errorf("Expected renewed TTL of 30s, got %v", ttl)
+	}
+
+	// 更新が途絶えると他のPodが引き継ぐ
+	server.FastForward(30 * time.Second)
+	if ok, _ := second.Acquire(ctx, 30*time.Second); !ok {
+		t.Error("Expected second pod to take over an expired lease")
+	}
+	if ok, _ := first.Acquire(ctx, 30*time.Second); ok {
+		t.Error("Expected previous leader to lose the lease")
+	}
+}
+
+func TestLeaderElector_Release(t *testing.T) {
+	server, client := newTestRedis(t)
+	first := NewLeaderElector(client, "scheduler", "realtime-hub-1")
+	second := NewLeaderElector(client, "scheduler", "realtime-hub-2")
+	ctx := context.Background()
+
+	first.Acquire(ctx, 30*time.Second)
+
+	// リーダーでないPodは解放できない
+	if err := second.Release(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got, _ := server.Get(leaderKey("scheduler")); got != "realtime-hub-1" {
+		t.Errorf("Expected lease to stay with realtime-hub-1, got %q", got)
+	}
+
+	if err := first.Release(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ok, _ := second.Acquire(ctx, 30*time.Second); !ok {
+		t.Error("Expected second pod to take over a released lease")
+	}
+}
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	// MaxChatHistory is the number of chat messages kept per room (README: 最新100件)
+	MaxChatHistory = 100
+
+	// defaultChatTTL is used when the room's expiry cannot be resolved
+	defaultChatTTL = 24 * time.Hour
+)
+
+// chatKey returns the Redis List key holding a room's chat history
+func chatKey(roomID uuid.UUID) string {
+	return fmt.Sprintf("room:%s:chat", roomID)
+}
+
+// Message is a Redis implementation of repository.Message
+// Each room's history is a List capped with LTRIM that expires together with the room
+type Message struct {
+	client   goredis.UniversalClient
+	roomRepo repository.Room
+}
+
+var _ repository.Message = (*Message)(nil)
+
+// NewMessage creates a new Redis Message repository
+// roomRepo is used to align the history TTL with Room.ExpiresAt
+func NewMessage(client goredis.UniversalClient, roomRepo repository.Room) *Message {
+	return &Message{
+		client:   client,
+		roomRepo: roomRepo,
+	}
+}
+
+// SaveChatMessage appends a chat message to the room's history
+func (m *Message) SaveChatMessage(ctx context.Context, message *model.Message) error {
+	data, err := message.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	key := chatKey(message.RoomID)
+	expiresAt := m.expiresAt(ctx, message.RoomID)
+
+	_, err = m.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.RPush(ctx, key, data)
+		pipe.LTrim(ctx, key, -MaxChatHistory, -1)
+		pipe.ExpireAt(ctx, key, expiresAt)
+		return nil
+	})
+	return err
+}
+
+// GetChatHistory returns the most recent messages (up to limit) in chronological order
+// A non-positive limit returns the whole stored history
+func (m *Message) GetChatHistory(ctx context.Context, roomID uuid.UUID, limit int) ([]*model.Message, error) {
+	start := int64(0)
+	if limit > 0 {
+		start = int64(-limit)
+	}
+
+	values, err := m.client.LRange(ctx, chatKey(roomID), start, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*model.Message, 0, len(values))
+	for _, value := range values {
+		message, err := model.FromJSON([]byte(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// DeleteChatHistory deletes all chat history for a room
+func (m *Message) DeleteChatHistory(ctx context.Context, roomID uuid.UUID) error {
+	return m.client.Del(ctx, chatKey(roomID)).Err()
+}
+
+// expiresAt returns when the room's chat history should expire
+func (m *Message) expiresAt(ctx context.Context, roomID uuid.UUID) time.Time {
+	room, err := m.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return time.Now().Add(defaultChatTTL)
+	}
+	return room.ExpiresAt
+}
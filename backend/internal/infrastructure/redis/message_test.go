@@ -0,0 +1,155 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *goredis.Client) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func TestMessage_SaveAndGetChatHistory(t *testing.T) {
+	_, client := newTestRedis(t)
+	repo := NewMessage(client, memory.NewRoom())
+	ctx := context.Background()
+	roomID := uuid.New()
+	senderID := uuid.New()
+
+	for i := 0; i < 5; i++ {
+		if err := repo.SaveChatMessage(ctx, model.NewChatMessage(senderID, roomID, fmt.Sprintf("message %d", i), "Alice")); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{"limit smaller than history", 2, []string{"message 3", "message 4"}},
+		{"limit larger than history", 10, []string{"message 0", "message 1", "message 2", "message 3", "message 4"}},
+		{"non-positive limit", 0, []string{"message 0", "message 1", "message 2", "message 3", "message 4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, err := repo.GetChatHistory(ctx, roomID, tt.limit)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(messages) != len(tt.want) {
+				t.Fatalf("Expected %d messages, got %d", len(tt.want), len(messages))
+			}
+			for i, message := range messages {
+				payload, ok := message.Payload.(model.ChatPayload)
+				if !ok {
+					t.Fatalf("Expected ChatPayload, got %T", message.Payload)
+				}
+				if payload.Message != tt.want[i] {
+					t.Errorf("Expected message %d to be %s, got %s", i, tt.want[i], payload.Message)
+				}
+				if payload.UserName != "Alice" {
+					t.Errorf("Expected UserName Alice, got %s", payload.UserName)
+				}
+			}
+		})
+	}
+}
+
+func TestMessage_CapsHistory(t *testing.T) {
+	server, client := newTestRedis(t)
+	repo := NewMessage(client, memory.NewRoom())
+	ctx := context.Background()
+	roomID := uuid.New()
+
+	for i := 0; i < MaxChatHistory+10; i++ {
+		repo.SaveChatMessage(ctx, model.NewChatMessage(uuid.New(), roomID, fmt.Sprintf("message %d", i), "Alice"))
+	}
+
+	values, _ := server.List(chatKey(roomID))
+	if len(values) != MaxChatHistory {
+		t.Fatalf("Expected %d stored messages, got %d", MaxChatHistory, len(values))
+	}
+
+	messages, _ := repo.GetChatHistory(ctx, roomID, 0)
+	first := messages[0].Payload.(model.ChatPayload)
+	if first.Message != "message 10" {
+		t.Errorf("Expected oldest kept message to be message 10, got %s", first.Message)
+	}
+}
+
+func TestMessage_TTLFollowsRoomExpiry(t *testing.T) {
+	server, client := newTestRedis(t)
+	rooms := memory.NewRoom()
+	repo := NewMessage(client, rooms)
+	ctx := context.Background()
+
+	room := model.NewRoom("Test Room", uuid.New(), false)
+	room.ExpiresAt = time.Now().Add(2 * time.Hour)
+	rooms.Create(ctx, room)
+
+	repo.SaveChatMessage(ctx, model.NewChatMessage(room.HostID, room.ID, "Hello", "Alice"))
+
+	ttl := server.TTL(chatKey(room.ID))
+	if ttl < 2*time.Hour-time.Minute || ttl > 2*time.Hour {
+		t.Errorf("Expected TTL close to 2h, got %v", ttl)
+	}
+
+	// 延長後の保存でTTLも延びる
+	room.ExtendExpiry(time.Hour)
+	rooms.Update(ctx, room)
+	repo.SaveChatMessage(ctx, model.NewChatMessage(room.HostID, room.ID, "Hello again", "Alice"))
+
+	ttl = server.TTL(chatKey(room.ID))
+	if ttl < 3*time.Hour-time.Minute || ttl > 3*time.Hour {
+		t.Errorf("Expected TTL close to 3h, got %v", ttl)
+	}
+
+	server.FastForward(3 * time.Hour)
+	messages, _ := repo.GetChatHistory(ctx, room.ID, 10)
+	if len(messages) != 0 {
+		t.Errorf("Expected history to expire with the room, got %d messages", len(messages))
+	}
+}
+
+func TestMessage_DefaultTTLWithoutRoom(t *testing.T) {
+	server, client := newTestRedis(t)
+	repo := NewMessage(client, memory.NewRoom())
+	roomID := uuid.New()
+
+	repo.SaveChatMessage(context.Background(), model.NewChatMessage(uuid.New(), roomID, "Hello", "Alice"))
+
+	ttl := server.TTL(chatKey(roomID))
+	if ttl < defaultChatTTL-time.Minute || ttl > defaultChatTTL {
+		t.Errorf("Expected default TTL %v, got %v", defaultChatTTL, ttl)
+	}
+}
+
+func TestMessage_DeleteChatHistory(t *testing.T) {
+	server, client := newTestRedis(t)
+	repo := NewMessage(client, memory.NewRoom())
+	ctx := context.Background()
+	roomID := uuid.New()
+	repo.SaveChatMessage(ctx, model.NewChatMessage(uuid.New(), roomID, "Hello", "Alice"))
+
+	if err := repo.DeleteChatHistory(ctx, roomID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if server.Exists(chatKey(roomID)) {
+		t.Error("Expected chat key to be deleted")
+	}
+}
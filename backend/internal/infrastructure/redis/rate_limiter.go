@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// rateLimitKey returns the Redis key counting attempts for key
+func rateLimitKey(key string) string {
+	return "ratelimit:" + key
+}
+
+// RateLimiter is a Redis fixed-window implementation of service.RateLimiter
+// Attempts are counted with INCR on a key that expires with the window, so the limit holds across pods
+type RateLimiter struct {
+	client goredis.UniversalClient
+	limit  int
+	window time.Duration
+}
+
+var _ service.RateLimiter = (*RateLimiter)(nil)
+
+// NewRateLimiter creates a new Redis RateLimiter allowing limit attempts per window
+func NewRateLimiter(client goredis.UniversalClient, limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		client: client,
+		limit:  limit,
+		window: window,
+	}
+}
+
+// Allow records an attempt for key and reports whether it is within the limit
+func (l *RateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	redisKey := rateLimitKey(key)
+
+	count, err := l.client.Incr(ctx, redisKey).Result()
+	if err != nil {
+		return false, err
+	}
+
+	// 最初の試行でウィンドウを開始する
+	if count == 1 {
+		if err := l.client.Expire(ctx, redisKey, l.window).Err(); err != nil {
+			return false, err
+		}
+	}
+
+	return count <= int64(l.limit), nil
+}
+
+// Reset forgets the attempts recorded for key
+func (l *RateLimiter) Reset(ctx context.Context, key string) error {
+	return l.client.Del(ctx, rateLimitKey(key)).Err()
+}
@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	server, client := newTestRedis(t)
+	limiter := NewRateLimiter(client, 2, time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if ok, err := limiter.Allow(ctx, "alice"); !ok || err != nil {
+			t.Fatalf("Expected attempt %d to be allowed, got %v %v", i+1, ok, err)
+		}
+	}
+	if ok, _ := limiter.Allow(ctx, "alice"); ok {
+		t.Error("Expected attempt over the limit to be rejected")
+	}
+	if ttl := server.TTL(rateLimitKey("alice")); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("Expected TTL within the window, got %v", ttl)
+	}
+
+	// ウィンドウが過ぎると再び試行できる
+	server.FastForward(time.Minute)
+	if ok, _ := limiter.Allow(ctx, "alice"); !ok {
+		t.Error("Expected attempt in a new window to be allowed")
+	}
+
+	limiter.Allow(ctx, "alice")
+	limiter.Reset(ctx, "alice")
+	if server.Exists(rateLimitKey("alice")) {
+		t.Error("Expected reset to delete the counter")
+	}
+}
@@ -0,0 +1,335 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ErrSessionNotFound is returned when a user has no session hash
+var ErrSessionNotFound = service.ErrSessionNotFound
+
+// maxTxRetries bounds optimistic transaction retries on concurrent modification
+const maxTxRetries = 10
+
+// Session hash fields (README: session:{userID})
+const (
+	fieldRoomID         = "roomId"
+	fieldConnectionID   = "connectionId"
+	fieldServerInstance = "serverInstance"
+	fieldIsHost         = "isHost"
+	fieldIsMuted        = "isMuted"
+	fieldLastSeen       = "lastSeen"
+)
+
+// sessionKey returns the Redis Hash key holding a user's session
+func sessionKey(userID uuid.UUID) string {
+	return fmt.Sprintf("session:%s", userID)
+}
+
+// lastSeenKey is the Sorted Set indexing sessions by lastSeen so stale ones can be found without scanning
+const lastSeenKey = "sessions:lastSeen"
+
+// participantsKey returns the Redis Set key holding a room's active users
+func participantsKey(roomID uuid.UUID) string {
+	return fmt.Sprintf("room:%s:participants", roomID)
+}
+
+// SessionManager is a Redis implementation of service.SessionManager
+// Sessions are stored in session:{userID} hashes and mirrored into room:{roomID}:participants sets
+// and the sessions:lastSeen sorted set
+type SessionManager struct {
+	client    goredis.UniversalClient
+	serverPod string
+}
+
+var _ service.SessionManager = (*SessionManager)(nil)
+
+// NewSessionManager creates a new Redis SessionManager
+// serverPod identifies this pod and is recorded on sessions created here
+func NewSessionManager(client goredis.UniversalClient, serverPod string) *SessionManager {
+	return &SessionManager{
+		client:    client,
+		serverPod: serverPod,
+	}
+}
+
+// CreateSession creates a new user session bound to this pod
+// An existing room membership is kept so reconnecting users stay in their room
+func (s *SessionManager) CreateSession(ctx context.Context, userID uuid.UUID, connectionID string) error {
+	now := time.Now().Unix()
+
+	_, err := s.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKey(userID),
+			fieldConnectionID, connectionID,
+			fieldServerInstance, s.serverPod,
+			fieldLastSeen, formatLastSeen(now),
+		)
+		pipe.ZAdd(ctx, lastSeenKey, lastSeenMember(userID, now))
+		return nil
+	})
+	return err
+}
+
+// GetSession retrieves a user session
+func (s *SessionManager) GetSession(ctx context.Context, userID uuid.UUID) (*service.UserSession, error) {
+	fields, err := s.client.HGetAll(ctx, sessionKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrSessionNotFound
+	}
+
+	return parseSession(userID, fields)
+}
+
+// UpdateSession updates a user session and keeps the room participant sets in sync
+// Empty ConnectionID and ServerPod keep the stored values
+func (s *SessionManager) UpdateSession(ctx context.Context, session *service.UserSession) error {
+	key := sessionKey(session.UserID)
+
+	return s.watch(ctx, func(tx *goredis.Tx) error {
+		previousRoomID, err := currentRoomID(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		values := []interface{}{
+			fieldIsHost, strconv.FormatBool(session.IsHost),
+			fieldIsMuted, strconv.FormatBool(session.IsMuted),
+			fieldLastSeen, formatLastSeen(session.LastSeen),
+		}
+		if session.ConnectionID != "" {
+			values = append(values, fieldConnectionID, session.ConnectionID)
+		}
+		if session.ServerPod != "" {
+			values = append(values, fieldServerInstance, session.ServerPod)
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.HSet(ctx, key, values...)
+			pipe.ZAdd(ctx, lastSeenKey, lastSeenMember(session.UserID, session.LastSeen))
+
+			if session.RoomID == uuid.Nil {
+				pipe.HDel(ctx, key, fieldRoomID)
+			} else {
+				pipe.HSet(ctx, key, fieldRoomID, session.RoomID.String())
+				pipe.SAdd(ctx, participantsKey(session.RoomID), session.UserID.String())
+			}
+
+			// ルームが変わった場合は旧ルームの参加者セットから外す
+			if previousRoomID != uuid.Nil && previousRoomID != session.RoomID {
+				pipe.SRem(ctx, participantsKey(previousRoomID), session.UserID.String())
+			}
+			return nil
+		})
+		return err
+	}, key)
+}
+
+// DeleteSession deletes a user session and removes the user from its room set
+func (s *SessionManager) DeleteSession(ctx context.Context, userID uuid.UUID) error {
+	key := sessionKey(userID)
+
+	return s.watch(ctx, func(tx *goredis.Tx) error {
+		roomID, err := currentRoomID(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Del(ctx, key)
+			pipe.ZRem(ctx, lastSeenKey, userID.String())
+			if roomID != uuid.Nil {
+				pipe.SRem(ctx, participantsKey(roomID), userID.String())
+			}
+			return nil
+		})
+		return err
+	}, key)
+}
+
+// GetActiveUsers returns all active users in a room
+func (s *SessionManager) GetActiveUsers(ctx context.Context, roomID uuid.UUID) ([]uuid.UUID, error) {
+	members, err := s.client.SMembers(ctx, participantsKey(roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// TouchSession refreshes LastSeen while the user's connection is alive
+func (s *SessionManager) TouchSession(ctx context.Context, userID uuid.UUID) error {
+	key := sessionKey(userID)
+
+	return s.watch(ctx, func(tx *goredis.Tx) error {
+		exists, err := tx.Exists(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if exists == 0 {
+			return ErrSessionNotFound
+		}
+
+		now := time.Now().Unix()
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.HSet(ctx, key, fieldLastSeen, formatLastSeen(now))
+			pipe.ZAdd(ctx, lastSeenKey, lastSeenMember(userID, now))
+			return nil
+		})
+		return err
+	}, key)
+}
+
+// ReapStaleSessions deletes the sessions last seen before the given time and returns them
+// Each session is re-checked in a transaction, so one touched by another pod in the meantime is kept
+func (s *SessionManager) ReapStaleSessions(ctx context.Context, before time.Time) ([]*service.UserSession, error) {
+	members, err := s.client.ZRangeByScore(ctx, lastSeenKey, &goredis.ZRangeBy{
+		Min: "-inf",
+		Max: "(" + strconv.FormatInt(before.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	reaped := []*service.UserSession{}
+	for _, member := range members {
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			s.client.ZRem(ctx, lastSeenKey, member)
+			continue
+		}
+
+		session, err := s.reapSession(ctx, userID, before)
+		if err != nil {
+			return reaped, err
+		}
+		if session != nil {
+			reaped = append(reaped, session)
+		}
+	}
+
+	return reaped, nil
+}
+
+// reapSession deletes a session if it is still stale, returning nil if it was kept or already gone
+func (s *SessionManager) reapSession(ctx context.Context, userID uuid.UUID, before time.Time) (*service.UserSession, error) {
+	key := sessionKey(userID)
+
+	var reaped *service.UserSession
+	err := s.watch(ctx, func(tx *goredis.Tx) error {
+		reaped = nil
+
+		fields, err := tx.HGetAll(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+
+		// 壊れたセッションは返さずに削除する
+		session, err := parseSession(userID, fields)
+		if len(fields) > 0 && err == nil {
+			if session.LastSeen >= before.Unix() {
+				return nil
+			}
+			reaped = session
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Del(ctx, key)
+			pipe.ZRem(ctx, lastSeenKey, userID.String())
+			if reaped != nil && reaped.RoomID != uuid.Nil {
+				pipe.SRem(ctx, participantsKey(reaped.RoomID), userID.String())
+			}
+			return nil
+		})
+		return err
+	}, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return reaped, nil
+}
+
+// watch runs fn in an optimistic transaction on keys, retrying when they change concurrently
+func (s *SessionManager) watch(ctx context.Context, fn func(tx *goredis.Tx) error, keys ...string) error {
+	for i := 0; i < maxTxRetries; i++ {
+		err := s.client.Watch(ctx, fn, keys...)
+		if !errors.Is(err, goredis.TxFailedErr) {
+			return err
+		}
+	}
+	return fmt.Errorf("session transaction retries exhausted: %w", goredis.TxFailedErr)
+}
+
+// currentRoomID reads the room stored on a session hash, uuid.Nil if none
+func currentRoomID(ctx context.Context, tx *goredis.Tx, key string) (uuid.UUID, error) {
+	value, err := tx.HGet(ctx, key, fieldRoomID).Result()
+	if errors.Is(err, goredis.Nil) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	roomID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, nil
+	}
+	return roomID, nil
+}
+
+func parseSession(userID uuid.UUID, fields map[string]string) (*service.UserSession, error) {
+	session := &service.UserSession{
+		UserID:       userID,
+		ConnectionID: fields[fieldConnectionID],
+		ServerPod:    fields[fieldServerInstance],
+		IsHost:       fields[fieldIsHost] == "true",
+		IsMuted:      fields[fieldIsMuted] == "true",
+	}
+
+	if value := fields[fieldRoomID]; value != "" {
+		roomID, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid roomId in session: %w", err)
+		}
+		session.RoomID = roomID
+	}
+
+	if value := fields[fieldLastSeen]; value != "" {
+		lastSeen, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lastSeen in session: %w", err)
+		}
+		session.LastSeen = lastSeen.Unix()
+	}
+
+	return session, nil
+}
+
+// lastSeenMember scores a user by the Unix timestamp it was last seen at
+func lastSeenMember(userID uuid.UUID, unix int64) goredis.Z {
+	return goredis.Z{Score: float64(unix), Member: userID.String()}
+}
+
+// formatLastSeen stores the Unix timestamp as RFC 3339 like the README example
+func formatLastSeen(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,207 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+func TestSessionManager_CreateAndGetSession(t *testing.T) {
+	server, client := newTestRedis(t)
+	manager := NewSessionManager(client, "realtime-hub-1")
+	ctx := context.Background()
+	userID := uuid.New()
+
+	if err := manager.CreateSession(ctx, userID, "conn456"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	session, err := manager.GetSession(ctx, userID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if session.UserID != userID {
+		t.Errorf("Expected UserID %s, got %s", userID, session.UserID)
+	}
+	if session.ConnectionID != "conn456" {
+		t.Errorf("Expected ConnectionID conn456, got %s", session.ConnectionID)
+	}
+	if session.ServerPod != "realtime-hub-1" {
+		t.Errorf("Expected ServerPod realtime-hub-1, got %s", session.ServerPod)
+	}
+	if session.RoomID != uuid.Nil {
+		t.Errorf("Expected no room, got %s", session.RoomID)
+	}
+	if time.Since(time.Unix(session.LastSeen, 0)) > time.Minute {
+		t.Errorf("Expected recent LastSeen, got %d", session.LastSeen)
+	}
+
+	// READMEのフィールド名で保存される
+	if server.HGet(sessionKey(userID), "serverInstance") != "realtime-hub-1" {
+		t.Error("Expected serverInstance field in session hash")
+	}
+}
+
+func TestSessionManager_GetSession_NotFound(t *testing.T) {
+	_, client := newTestRedis(t)
+	manager := NewSessionManager(client, "realtime-hub-1")
+
+	if _, err := manager.GetSession(context.Background(), uuid.New()); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestSessionManager_UpdateSession_SyncsRoomSets(t *testing.T) {
+	server, client := newTestRedis(t)
+	manager := NewSessionManager(client, "realtime-hub-1")
+	ctx := context.Background()
+	userID := uuid.New()
+	firstRoom := uuid.New()
+	secondRoom := uuid.New()
+
+	manager.CreateSession(ctx, userID, "conn456")
+
+	// ルームに参加
+	err := manager.UpdateSession(ctx, &service.UserSession{UserID: userID, RoomID: firstRoom, IsHost: true, LastSeen: time.Now().Unix()})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	users, _ := manager.GetActiveUsers(ctx, firstRoom)
+	if len(users) != 1 || users[0] != userID {
+		t.Errorf("Expected user in first room, got %v", users)
+	}
+
+	session, _ := manager.GetSession(ctx, userID)
+	if session.RoomID != firstRoom || !session.IsHost {
+		t.Errorf("Expected host session in first room, got %+v", session)
+	}
+	// 空のConnectionID/ServerPodは既存値を保持する
+	if session.ConnectionID != "conn456" || session.ServerPod != "realtime-hub-1" {
+		t.Errorf("Expected connection fields to be kept, got %+v", session)
+	}
+
+	// 別ルームへ移動
+	manager.UpdateSession(ctx, &service.UserSession{UserID: userID, RoomID: secondRoom, LastSeen: time.Now().Unix()})
+
+	if users, _ := manager.GetActiveUsers(ctx, firstRoom); len(users) != 0 {
+		t.Errorf("Expected user removed from first room, got %v", users)
+	}
+	if users, _ := manager.GetActiveUsers(ctx, secondRoom); len(users) != 1 {
+		t.Errorf("Expected user in second room, got %v", users)
+	}
+
+	// ルームから退出
+	manager.UpdateSession(ctx, &service.UserSession{UserID: userID, LastSeen: time.Now().Unix()})
+
+	if server.Exists(participantsKey(secondRoom)) {
+		t.Error("Expected empty participant set to be removed")
+	}
+	session, _ = manager.GetSession(ctx, userID)
+	if session.RoomID != uuid.Nil {
+		t.Errorf("Expected no room, got %s", session.RoomID)
+	}
+}
+
+func TestSessionManager_DeleteSession(t *testing.T) {
+	server, client := newTestRedis(t)
+	manager := NewSessionManager(client, "realtime-hub-1")
+	ctx := context.Background()
+	roomID := uuid.New()
+	userID := uuid.New()
+	otherID := uuid.New()
+
+	manager.UpdateSession(ctx, &service.UserSession{UserID: userID, RoomID: roomID})
+	manager.UpdateSession(ctx, &service.UserSession{UserID: otherID, RoomID: roomID})
+
+	if err := manager.DeleteSession(ctx, userID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if server.Exists(sessionKey(userID)) {
+		t.Error("Expected session hash to be deleted")
+	}
+	users, _ := manager.GetActiveUsers(ctx, roomID)
+	if len(users) != 1 || users[0] != otherID {
+		t.Errorf("Expected only the other user to remain, got %v", users)
+	}
+
+	// 存在しないセッションの削除はエラーにならない
+	if err := manager.DeleteSession(ctx, uuid.New()); err != nil {
+		t.Errorf("Expected no error deleting missing session, got %v", err)
+	}
+}
+
+func TestSessionManager_ReapStaleSessions(t *testing.T) {
+	server, client := newTestRedis(t)
+	manager := NewSessionManager(client, "realtime-hub-1")
+	ctx := context.Background()
+	roomID := uuid.New()
+	staleID := uuid.New()
+	touchedID := uuid.New()
+	activeID := uuid.New()
+
+	hourAgo := time.Now().Add(-time.Hour).Unix()
+	manager.UpdateSession(ctx, &service.UserSession{UserID: staleID, RoomID: roomID, LastSeen: hourAgo})
+	manager.UpdateSession(ctx, &service.UserSession{UserID: touchedID, RoomID: roomID, LastSeen: hourAgo})
+	manager.CreateSession(ctx, activeID, "conn456")
+
+	// 接続が生きているセッションは回収しない
+	if err := manager.TouchSession(ctx, touchedID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	reaped, err := manager.ReapStaleSessions(ctx, time.Now().Add(-5*time.Minute))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reaped) != 1 || reaped[0].UserID != staleID || reaped[0].RoomID != roomID {
+		t.Fatalf("Expected only the stale session, got %+v", reaped)
+	}
+
+	if server.Exists(sessionKey(staleID)) {
+		t.Error("Expected stale session hash to be deleted")
+	}
+	users, _ := manager.GetActiveUsers(ctx, roomID)
+	if len(users) != 1 || users[0] != touchedID {
+		t.Errorf("Expected only the touched user to remain, got %v", users)
+	}
+	if members, _ := server.ZMembers(lastSeenKey); len(members) != 2 {
+		t.Errorf("Expected 2 indexed sessions, got %v", members)
+	}
+
+	if err := manager.TouchSession(ctx, staleID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestSessionManager_ReapStaleSessions_IndexOutOfDate(t *testing.T) {
+	server, client := newTestRedis(t)
+	manager := NewSessionManager(client, "realtime-hub-1")
+	ctx := context.Background()
+	deletedID := uuid.New()
+	refreshedID := uuid.New()
+
+	// 他のPodが索引より後にセッションを更新・削除した場合
+	manager.CreateSession(ctx, refreshedID, "conn456")
+	server.ZAdd(lastSeenKey, float64(time.Now().Add(-time.Hour).Unix()), refreshedID.String())
+	server.ZAdd(lastSeenKey, float64(time.Now().Add(-time.Hour).Unix()), deletedID.String())
+
+	reaped, err := manager.ReapStaleSessions(ctx, time.Now().Add(-5*time.Minute))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reaped) != 0 {
+		t.Errorf("Expected no sessions to be reaped, got %+v", reaped)
+	}
+	if _, err := manager.GetSession(ctx, refreshedID); err != nil {
+		t.Errorf("Expected refreshed session to be kept, got %v", err)
+	}
+	if members, _ := server.ZMembers(lastSeenKey); len(members) != 1 {
+		t.Errorf("Expected the deleted session to leave the index, got %v", members)
+	}
+}
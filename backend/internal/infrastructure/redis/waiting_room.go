@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ErrNotWaiting is returned when a user is not in a room's waiting set
+var ErrNotWaiting = fmt.Errorf("waiting user %w", repository.ErrNotFound)
+
+// defaultWaitingTTL is used when the room's expiry cannot be resolved
+const defaultWaitingTTL = 24 * time.Hour
+
+// waitingKey returns the Redis Set key holding a room's waiting users
+func waitingKey(roomID uuid.UUID) string {
+	return fmt.Sprintf("room:%s:waiting", roomID)
+}
+
+// WaitingRoom is a Redis implementation of repository.WaitingRoom
+// Each room's lobby is a Set (README: room:{roomID}:waiting) that expires together with the room
+type WaitingRoom struct {
+	client   goredis.UniversalClient
+	roomRepo repository.Room
+}
+
+var _ repository.WaitingRoom = (*WaitingRoom)(nil)
+
+// NewWaitingRoom creates a new Redis WaitingRoom repository
+// roomRepo is used to align the set's TTL with Room.ExpiresAt
+func NewWaitingRoom(client goredis.UniversalClient, roomRepo repository.Room) *WaitingRoom {
+	return &WaitingRoom{
+		client:   client,
+		roomRepo: roomRepo,
+	}
+}
+
+// AddWaitingUser places a user in the room's waiting set
+func (w *WaitingRoom) AddWaitingUser(ctx context.Context, roomID, userID uuid.UUID) error {
+	key := waitingKey(roomID)
+	expiresAt := w.expiresAt(ctx, roomID)
+
+	_, err := w.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.SAdd(ctx, key, userID.String())
+		pipe.ExpireAt(ctx, key, expiresAt)
+		return nil
+	})
+	return err
+}
+
+// RemoveWaitingUser removes a user from the room's waiting set
+func (w *WaitingRoom) RemoveWaitingUser(ctx context.Context, roomID, userID uuid.UUID) error {
+	removed, err := w.client.SRem(ctx, waitingKey(roomID), userID.String()).Result()
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrNotWaiting
+	}
+	return nil
+}
+
+// IsWaitingUser checks if a user is in the room's waiting set
+func (w *WaitingRoom) IsWaitingUser(ctx context.Context, roomID, userID uuid.UUID) (bool, error) {
+	return w.client.SIsMember(ctx, waitingKey(roomID), userID.String()).Result()
+}
+
+// GetWaitingUsers returns all users waiting for the room
+func (w *WaitingRoom) GetWaitingUsers(ctx context.Context, roomID uuid.UUID) ([]uuid.UUID, error) {
+	members, err := w.client.SMembers(ctx, waitingKey(roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// DeleteWaitingUsers removes the room's waiting set
+func (w *WaitingRoom) DeleteWaitingUsers(ctx context.Context, roomID uuid.UUID) error {
+	return w.client.Del(ctx, waitingKey(roomID)).Err()
+}
+
+// expiresAt returns when the room's waiting set should expire
+func (w *WaitingRoom) expiresAt(ctx context.Context, roomID uuid.UUID) time.Time {
+	room, err := w.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return time.Now().Add(defaultWaitingTTL)
+	}
+	return room.ExpiresAt
+}
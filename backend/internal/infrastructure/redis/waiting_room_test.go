@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/google/uuid"
+)
+
+func TestWaitingRoom_AddAndRemove(t *testing.T) {
+	server, client := newTestRedis(t)
+	repo := NewWaitingRoom(client, memory.NewRoom())
+	ctx := context.Background()
+	roomID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+
+	repo.AddWaitingUser(ctx, roomID, alice)
+	repo.AddWaitingUser(ctx, roomID, alice)
+	repo.AddWaitingUser(ctx, roomID, bob)
+
+	// READMEのキーで保存される
+	members, _ := server.Members(waitingKey(roomID))
+	if len(members) != 2 {
+		t.Errorf("Expected 2 members in %s, got %v", waitingKey(roomID), members)
+	}
+
+	users, _ := repo.GetWaitingUsers(ctx, roomID)
+	if len(users) != 2 {
+		t.Errorf("Expected 2 waiting users, got %d", len(users))
+	}
+
+	if err := repo.RemoveWaitingUser(ctx, roomID, alice); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if waiting, _ := repo.IsWaitingUser(ctx, roomID, alice); waiting {
+		t.Error("Expected alice to be removed")
+	}
+	if waiting, _ := repo.IsWaitingUser(ctx, roomID, bob); !waiting {
+		t.Error("Expected bob to still be waiting")
+	}
+
+	err := repo.RemoveWaitingUser(ctx, roomID, alice)
+	if !errors.Is(err, ErrNotWaiting) || !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected ErrNotWaiting, got %v", err)
+	}
+
+	repo.DeleteWaitingUsers(ctx, roomID)
+	if server.Exists(waitingKey(roomID)) {
+		t.Error("Expected waiting set to be deleted")
+	}
+}
+
+func TestWaitingRoom_TTLFollowsRoomExpiry(t *testing.T) {
+	server, client := newTestRedis(t)
+	rooms := memory.NewRoom()
+	repo := NewWaitingRoom(client, rooms)
+	ctx := context.Background()
+
+	room := model.NewRoom("Test Room", uuid.New(), true)
+	room.ExpiresAt = time.Now().Add(2 * time.Hour)
+	rooms.Create(ctx, room)
+
+	repo.AddWaitingUser(ctx, room.ID, uuid.New())
+
+	ttl := server.TTL(waitingKey(room.ID))
+	if ttl < 2*time.Hour-time.Minute || ttl > 2*time.Hour {
+		t.Errorf("Expected TTL close to 2h, got %v", ttl)
+	}
+}
@@ -0,0 +1,137 @@
+// Package scheduler runs periodic background jobs on a single elected pod
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+// DefaultLease is how long leadership lasts without renewal
+// When the leader dies another pod takes over within this time
+const DefaultLease = 30 * time.Second
+
+// releaseTimeout bounds giving up the lease on shutdown
+const releaseTimeout = 5 * time.Second
+
+// Job is a task run periodically by the leader
+// Jobs must be idempotent: leadership can move between pods while a job is running
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler campaigns for leadership and runs its jobs only while this pod is the leader
+// Every replica runs a Scheduler, so jobs keep running when the leader pod goes away
+type Scheduler struct {
+	elector service.LeaderElector
+	lease   time.Duration
+	jobs    []Job
+	leader  atomic.Bool
+}
+
+// NewScheduler creates a new Scheduler holding leadership for lease at a time
+// The lease is renewed three times per lease period
+func NewScheduler(elector service.LeaderElector, lease time.Duration) *Scheduler {
+	return &Scheduler{
+		elector: elector,
+		lease:   lease,
+	}
+}
+
+// Add registers a job
+// It must be called before Run
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// IsLeader reports whether this pod currently holds the lease
+func (s *Scheduler) IsLeader() bool {
+	return s.leader.Load()
+}
+
+// Run campaigns for leadership and runs the jobs until the context is cancelled
+// On cancellation it waits for running jobs and releases the lease so another pod can take over immediately
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			s.runJob(ctx, job)
+		}(job)
+	}
+
+	s.campaign(ctx)
+	wg.Wait()
+
+	if s.leader.Swap(false) {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+		defer cancel()
+		if err := s.elector.Release(releaseCtx); err != nil {
+			log.Printf("scheduler: release leadership: %v", err)
+		}
+	}
+}
+
+// campaign acquires or renews the lease until the context is cancelled
+func (s *Scheduler) campaign(ctx context.Context) {
+	ticker := time.NewTicker(s.lease / 3)
+	defer ticker.Stop()
+
+	for {
+		s.renew(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) renew(ctx context.Context) {
+	leader, err := s.elector.Acquire(ctx, s.lease)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		// 更新できたか分からない場合は他のPodと二重に実行しないよう降りる
+		log.Printf("scheduler: leader election: %v", err)
+		leader = false
+	}
+
+	if s.leader.Swap(leader) != leader {
+		if leader {
+			log.Printf("scheduler: became leader")
+		} else {
+			log.Printf("scheduler: lost leadership")
+		}
+	}
+}
+
+// runJob runs a job every interval while this pod is the leader
+func (s *Scheduler) runJob(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !s.IsLeader() {
+			continue
+		}
+		if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("scheduler: job %s: %v", job.Name, err)
+		}
+	}
+}
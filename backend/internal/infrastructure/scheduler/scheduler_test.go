@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+// sharedLease is a service.LeaderElector backed by a lease shared between test pods
+type sharedLease struct {
+	holder string
+	fail   bool
+	mutex  sync.Mutex
+}
+
+type testElector struct {
+	lease *sharedLease
+	pod   string
+}
+
+var _ service.LeaderElector = (*testElector)(nil)
+
+func (e *testElector) Acquire(ctx context.Context, ttl time.Duration) (bool, error) {
+	e.lease.mutex.Lock()
+	defer e.lease.mutex.Unlock()
+
+	if e.lease.fail {
+		return false, errors.New("redis is down")
+	}
+	if e.lease.holder == "" {
+		e.lease.holder = e.pod
+	}
+	return e.lease.holder == e.pod, nil
+}
+
+func (e *testElector) Release(ctx context.Context) error {
+	e.lease.mutex.Lock()
+	defer e.lease.mutex.Unlock()
+
+	if e.lease.holder == e.pod {
+		e.lease.holder = ""
+	}
+	return nil
+}
+
+func (l *sharedLease) setFail(fail bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.fail = fail
+}
+
+// testPod runs a scheduler with a counting job
+type testPod struct {
+	scheduler *Scheduler
+	runs      atomic.Int32
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+func startPod(t *testing.T, lease *sharedLease, name string) *testPod {
+	t.Helper()
+
+	pod := &testPod{
+		scheduler: NewScheduler(&testElector{lease: lease, pod: name}, 30*time.Millisecond),
+		done:      make(chan struct{}),
+	}
+	pod.scheduler.Add(Job{
+		Name:     "count",
+		Interval: 5 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			pod.runs.Add(1)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pod.cancel = cancel
+	go func() {
+		defer close(pod.done)
+		pod.scheduler.Run(ctx)
+	}()
+	t.Cleanup(pod.stop)
+	return pod
+}
+
+func (p *testPod) stop() {
+	p.cancel()
+	<-p.done
+}
+
+func waitFor(t *testing.T, condition func() bool, message string) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal(message)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestScheduler_RunsJobsOnLeaderOnly(t *testing.T) {
+	lease := &sharedLease{}
+	leader := startPod(t, lease, "realtime-hub-1")
+	waitFor(t, leader.scheduler.IsLeader, "Expected first pod to become leader")
+	follower := startPod(t, lease, "realtime-hub-2")
+
+	waitFor(t, func() bool { return leader.runs.Load() >= 3 }, "Expected leader to run the job repeatedly")
+	if follower.scheduler.IsLeader() || follower.runs.Load() != 0 {
+		t.Errorf("Expected follower not to run jobs, ran %d times", follower.runs.Load())
+	}
+
+	// 停止したリーダーはリースを解放し、他のPodが引き継ぐ
+	leader.stop()
+	if leader.scheduler.IsLeader() {
+		t.Error("Expected stopped pod to give up leadership")
+	}
+	waitFor(t, func() bool { return follower.runs.Load() > 0 }, "Expected follower to take over the job")
+}
+
+func TestScheduler_StepsDownWhenElectionFails(t *testing.T) {
+	lease := &sharedLease{}
+	pod := startPod(t, lease, "realtime-hub-1")
+	waitFor(t, pod.scheduler.IsLeader, "Expected pod to become leader")
+
+	lease.setFail(true)
+	waitFor(t, func() bool { return !pod.scheduler.IsLeader() }, "Expected pod to step down when the lease cannot be renewed")
+
+	runs := pod.runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	// 降りた時点で実行中だった1回までは許容する
+	if pod.runs.Load() > runs+1 {
+		t.Errorf("Expected no runs without leadership, got %d more", pod.runs.Load()-runs)
+	}
+
+	lease.setFail(false)
+	waitFor(t, pod.scheduler.IsLeader, "Expected pod to regain leadership")
+}
+
+func TestScheduler_JobErrorsDoNotStopJob(t *testing.T) {
+	scheduler := NewScheduler(&testElector{lease: &sharedLease{}, pod: "realtime-hub-1"}, 30*time.Millisecond)
+	var runs atomic.Int32
+	scheduler.Add(Job{
+		Name:     "failing",
+		Interval: 5 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return errors.New("database is down")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.Run(ctx)
+	}()
+
+	waitFor(t, func() bool { return runs.Load() >= 2 }, "Expected failing job to keep running")
+	cancel()
+	<-done
+}
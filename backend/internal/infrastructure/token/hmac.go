@@ -0,0 +1,124 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+// ErrSecretTooShort is returned when the signing secret is too weak
+var ErrSecretTooShort = errors.New("token secret must be at least 32 bytes")
+
+// MinSecretLength is the minimum HMAC secret length in bytes
+const MinSecretLength = 32
+
+var encoding = base64.RawURLEncoding
+
+// connectionPurpose is signed along with connection tokens so invite tokens cannot be used to connect
+// Invite tokens are signed without a purpose; base64url never contains the separator, so the signed data cannot collide
+const connectionPurpose = "connection."
+
+// HMACSigner is an HMAC-SHA256 implementation of service.InviteSigner and service.ConnectionSigner
+// Tokens have the form base64url(claims JSON) + "." + base64url(signature)
+type HMACSigner struct {
+	secret []byte
+}
+
+var (
+	_ service.InviteSigner     = (*HMACSigner)(nil)
+	_ service.ConnectionSigner = (*HMACSigner)(nil)
+)
+
+// NewHMACSigner creates a new HMACSigner
+// All pods must share the same secret to verify each other's tokens
+func NewHMACSigner(secret []byte) (*HMACSigner, error) {
+	if len(secret) < MinSecretLength {
+		return nil, ErrSecretTooShort
+	}
+	return &HMACSigner{secret: append([]byte(nil), secret...)}, nil
+}
+
+// Sign encodes and signs the claims
+func (s *HMACSigner) Sign(claims *service.InviteClaims) (string, error) {
+	return s.sign("", claims)
+}
+
+// Verify checks the signature and expiry and returns the claims
+func (s *HMACSigner) Verify(token string) (*service.InviteClaims, error) {
+	var claims service.InviteClaims
+	if err := s.verify("", token, &claims); err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(claims.ExpiresAt) {
+		return nil, service.ErrTokenExpired
+	}
+
+	return &claims, nil
+}
+
+// SignConnection encodes and signs the connection claims
+func (s *HMACSigner) SignConnection(claims *service.ConnectionClaims) (string, error) {
+	return s.sign(connectionPurpose, claims)
+}
+
+// VerifyConnection checks the signature and expiry and returns the connection claims
+func (s *HMACSigner) VerifyConnection(token string) (*service.ConnectionClaims, error) {
+	var claims service.ConnectionClaims
+	if err := s.verify(connectionPurpose, token, &claims); err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(claims.ExpiresAt) {
+		return nil, service.ErrTokenExpired
+	}
+
+	return &claims, nil
+}
+
+// sign encodes the claims and signs them for purpose
+func (s *HMACSigner) sign(purpose string, claims any) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := encoding.EncodeToString(payload)
+	return encoded + "." + encoding.EncodeToString(s.mac(purpose+encoded)), nil
+}
+
+// verify checks the token was signed for purpose and decodes its claims
+func (s *HMACSigner) verify(purpose, token string, claims any) error {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return service.ErrInvalidToken
+	}
+
+	mac, err := encoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(purpose+encoded)) {
+		return service.ErrInvalidToken
+	}
+
+	payload, err := encoding.DecodeString(encoded)
+	if err != nil {
+		return service.ErrInvalidToken
+	}
+
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return service.ErrInvalidToken
+	}
+
+	return nil
+}
+
+func (s *HMACSigner) mac(data string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,157 @@
+package token
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestSigner(t *testing.T, secret []byte) *HMACSigner {
+	t.Helper()
+	signer, err := NewHMACSigner(secret)
+	if err != nil {
+		t.Fatalf("NewHMACSigner failed: %v", err)
+	}
+	return signer
+}
+
+func newTestClaims(ttl time.Duration) *service.InviteClaims {
+	return &service.InviteClaims{
+		InviteID:  uuid.New(),
+		ExpiresAt: time.Now().Add(ttl).Truncate(time.Second),
+	}
+}
+
+func TestNewHMACSigner_ShortSecret(t *testing.T) {
+	if _, err := NewHMACSigner([]byte("short")); !errors.Is(err, ErrSecretTooShort) {
+		t.Errorf("Expected ErrSecretTooShort, got %v", err)
+	}
+}
+
+func TestHMACSigner_RoundTrip(t *testing.T) {
+	signer := newTestSigner(t, testSecret)
+	invite := model.NewInvite(uuid.New(), uuid.New(), "alice@example.com", model.RolePresenter, time.Hour, 3)
+	claims := service.NewInviteClaims(invite)
+
+	token, err := signer.Sign(claims)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	// ペイロードは署名されているだけで暗号化されていないので、招待ID以外を含めない
+	encoded, _, _ := strings.Cut(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("Expected a base64url payload, got %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		t.Fatalf("Expected a JSON payload, got %v", err)
+	}
+	if len(fields) != 2 || fields["iid"] != invite.ID.String() || fields["exp"] == nil {
+		t.Errorf("Expected only the invite ID and expiry in the payload, got %s", payload)
+	}
+	if strings.Contains(string(payload), invite.RoomID.String()) || strings.Contains(string(payload), invite.Email) {
+		t.Errorf("Expected the payload not to reveal the room or email, got %s", payload)
+	}
+
+	got, err := signer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if got.InviteID != invite.ID {
+		t.Errorf("Expected InviteID %s, got %s", invite.ID, got.InviteID)
+	}
+	if !got.ExpiresAt.Equal(invite.ExpiresAt) {
+		t.Errorf("Expected ExpiresAt %v, got %v", invite.ExpiresAt, got.ExpiresAt)
+	}
+}
+
+func TestHMACSigner_Verify(t *testing.T) {
+	signer := newTestSigner(t, testSecret)
+	valid, _ := signer.Sign(newTestClaims(time.Hour))
+	expired, _ := signer.Sign(newTestClaims(-time.Minute))
+	otherKey, _ := newTestSigner(t, []byte("fedcba9876543210fedcba9876543210")).Sign(newTestClaims(time.Hour))
+
+	payload, signature, _ := strings.Cut(valid, ".")
+	forged, _ := signer.Sign(newTestClaims(time.Hour))
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	tests := []struct {
+		name     string
+		token    string
+		expected error
+	}{
+		{"valid", valid, nil},
+		{"expired", expired, service.ErrTokenExpired},
+		{"other secret", otherKey, service.ErrInvalidToken},
+		{"swapped payload", forgedPayload + "." + signature, service.ErrInvalidToken},
+		{"missing signature", payload, service.ErrInvalidToken},
+		{"bad encoding", payload + ".!!!", service.ErrInvalidToken},
+		{"empty", "", service.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Verify(tt.token)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestHMACSigner_VerifyConnection(t *testing.T) {
+	signer := newTestSigner(t, testSecret)
+	claims := &service.ConnectionClaims{
+		UserID:    uuid.New(),
+		RoomID:    uuid.New(),
+		ExpiresAt: time.Now().Add(time.Minute).Truncate(time.Second),
+	}
+	valid, err := signer.SignConnection(claims)
+	if err != nil {
+		t.Fatalf("SignConnection failed: %v", err)
+	}
+
+	got, err := signer.VerifyConnection(valid)
+	if err != nil {
+		t.Fatalf("VerifyConnection failed: %v", err)
+	}
+	if got.UserID != claims.UserID || got.RoomID != claims.RoomID || !got.ExpiresAt.Equal(claims.ExpiresAt) {
+		t.Errorf("Expected %+v, got %+v", claims, got)
+	}
+
+	expired, _ := signer.SignConnection(&service.ConnectionClaims{UserID: claims.UserID, RoomID: claims.RoomID, ExpiresAt: time.Now().Add(-time.Second)})
+	invite, _ := signer.Sign(newTestClaims(time.Hour))
+
+	tests := []struct {
+		name     string
+		token    string
+		expected error
+	}{
+		{"expired", expired, service.ErrTokenExpired},
+		{"invite token", invite, service.ErrInvalidToken},
+		{"empty", "", service.ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.VerifyConnection(tt.token); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	// 接続用のトークンは招待として使えない
+	if _, err := signer.Verify(valid); !errors.Is(err, service.ErrInvalidToken) {
+		t.Errorf("Expected connection token to be rejected as an invite, got %v", err)
+	}
+}
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+// CalendarFile is an encoded calendar handed out for download
+type CalendarFile struct {
+	ContentType string
+	Data        []byte
+}
+
+// Calendar handles calendar entries for scheduled rooms and series
+// Entries are sent by the organizer (the host), so attendee emails are only disclosed to hosts and co-hosts
+type Calendar struct {
+	roomRepo   repository.Room
+	seriesRepo repository.Series
+	userRepo   repository.User
+	inviteRepo repository.Invite
+	signer     service.InviteSigner
+	encoder    service.CalendarEncoder
+	baseURL    string
+}
+
+// NewCalendar creates a new Calendar usecase
+// baseURL is the public URL of the web app that join links in calendar entries point to;
+// room entries link to an invite signed with signer so attendees can join without the room ID
+func NewCalendar(
+	roomRepo repository.Room,
+	seriesRepo repository.Series,
+	userRepo repository.User,
+	inviteRepo repository.Invite,
+	signer service.InviteSigner,
+	encoder service.CalendarEncoder,
+	baseURL string,
+) *Calendar {
+	return &Calendar{
+		roomRepo:   roomRepo,
+		seriesRepo: seriesRepo,
+		userRepo:   userRepo,
+		inviteRepo: inviteRepo,
+		signer:     signer,
+		encoder:    encoder,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// RoomCalendar returns the invitation for a scheduled room (requires PermissionManageInvites)
+// Email invites that can still be redeemed become attendees; rescheduling the room updates the entry
+// The join link is an open attendee invite that lasts until the room's scheduled end
+func (c *Calendar) RoomCalendar(ctx context.Context, actorID, roomID uuid.UUID) (*CalendarFile, error) {
+	room, event, err := c.roomEvent(ctx, actorID, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.addInvitees(ctx, event, room); err != nil {
+		return nil, err
+	}
+
+	return c.encode(model.NewCalendar(model.CalendarMethodRequest, event))
+}
+
+// RoomCancellation returns the cancellation of a scheduled room (requires PermissionManageInvites)
+// Hosts hand it out before deleting the room, which removes the entry from attendees' calendars
+func (c *Calendar) RoomCancellation(ctx context.Context, actorID, roomID uuid.UUID) (*CalendarFile, error) {
+	room, event, err := c.roomEvent(ctx, actorID, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.addInvitees(ctx, event, room); err != nil {
+		return nil, err
+	}
+	event.Cancel()
+
+	return c.encode(model.NewCalendar(model.CalendarMethodCancel, event))
+}
+
+// SeriesCalendar returns the recurring invitation for a series (host and co-hosts only)
+// Co-hosts are listed as chairs and cancelled occurrences as exception dates
+func (c *Calendar) SeriesCalendar(ctx context.Context, actorID, seriesID uuid.UUID) (*CalendarFile, error) {
+	_, event, err := c.seriesEvent(ctx, actorID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.encode(model.NewCalendar(model.CalendarMethodRequest, event))
+}
+
+// OccurrenceCancellation returns the cancellation of a cancelled occurrence (host and co-hosts only)
+// The event shares the series' UID and identifies the occurrence with RECURRENCE-ID
+func (c *Calendar) OccurrenceCancellation(ctx context.Context, actorID, seriesID uuid.UUID, startsAt time.Time) (*CalendarFile, error) {
+	series, event, err := c.seriesEvent(ctx, actorID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isExceptionDate(series, startsAt) {
+		return nil, fmt.Errorf("%w: occurrence at %s is not cancelled", ErrOccurrenceNotFound, startsAt.Format(time.RFC3339))
+	}
+
+	// 中止した時点でSeriesのSEQUENCEは増えているのでCancelは使わない
+	recurrenceID := startsAt
+	event.RecurrenceID = &recurrenceID
+	event.Start = startsAt
+	event.End = startsAt.Add(series.Duration())
+	event.RRule = ""
+	event.ExceptionDates = nil
+	event.Status = model.EventStatusCancelled
+
+	return c.encode(model.NewCalendar(model.CalendarMethodCancel, event))
+}
+
+// roomEvent loads a scheduled room the actor manages and builds its event
+func (c *Calendar) roomEvent(ctx context.Context, actorID, roomID uuid.UUID) (*model.Room, *model.CalendarEvent, error) {
+	room, err := c.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, nil, roomLookupError(err)
+	}
+
+	// Check permission
+	if err := room.Authorize(actorID, model.PermissionManageInvites); err != nil {
+		return nil, nil, err
+	}
+
+	host, err := c.userRepo.GetByID(ctx, room.HostID)
+	if err != nil {
+		return nil, nil, userLookupError(err)
+	}
+
+	if room.Schedule == nil {
+		return nil, nil, fmt.Errorf("%w: room is not scheduled", ErrInvalidSchedule)
+	}
+	joinURL, err := c.joinURL(ctx, actorID, room)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	event, err := model.NewRoomEvent(room, host, joinURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.addCoHosts(ctx, event, room.CoHostIDs); err != nil {
+		return nil, nil, err
+	}
+
+	return room, event, nil
+}
+
+// seriesEvent loads a series the actor manages and builds its event
+func (c *Calendar) seriesEvent(ctx context.Context, actorID, seriesID uuid.UUID) (*model.Series, *model.CalendarEvent, error) {
+	series, err := c.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, nil, seriesLookupError(err)
+	}
+
+	// Check permission
+	if !series.CanManage(actorID) {
+		return nil, nil, fmt.Errorf("%w: only the host and co-hosts can export the series", ErrPermissionDenied)
+	}
+
+	host, err := c.userRepo.GetByID(ctx, series.HostID)
+	if err != nil {
+		return nil, nil, userLookupError(err)
+	}
+
+	event := model.NewSeriesEvent(series, host, c.baseURL+"/series/"+series.ID.String())
+	if err := c.addCoHosts(ctx, event, series.CoHostIDs); err != nil {
+		return nil, nil, err
+	}
+
+	return series, event, nil
+}
+
+// joinURL returns a link to redeem an open attendee invite valid until the room's scheduled end
+// An invite issued for an earlier download is reused as long as it still covers the schedule,
+// so every version of the entry links to the same invite
+func (c *Calendar) joinURL(ctx context.Context, actorID uuid.UUID, room *model.Room) (string, error) {
+	invites, err := c.inviteRepo.GetByRoomID(ctx, room.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get invites: %w", err)
+	}
+
+	var invite *model.Invite
+	for _, existing := range invites {
+		if isCalendarInvite(existing, room.Schedule) {
+			invite = existing
+			break
+		}
+	}
+
+	if invite == nil {
+		// 予定の終了まで使えるよう、MaxInviteTTLより長くてもよい
+		invite = model.NewInvite(room.ID, actorID, "", model.RoleAttendee, time.Until(room.Schedule.EndsAt), 0)
+		if err := c.inviteRepo.Create(ctx, invite); err != nil {
+			return "", fmt.Errorf("failed to create invite: %w", err)
+		}
+	}
+
+	// Sign token
+	token, err := c.signer.Sign(service.NewInviteClaims(invite))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign invite: %w", err)
+	}
+
+	return c.baseURL + "/invite?token=" + url.QueryEscape(token), nil
+}
+
+// addCoHosts lists designated co-hosts as chairs, skipping deleted users
+func (c *Calendar) addCoHosts(ctx context.Context, event *model.CalendarEvent, coHostIDs []uuid.UUID) error {
+	for _, id := range coHostIDs {
+		user, err := c.userRepo.GetByID(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return userLookupError(err)
+		}
+		event.AddAttendee(user.Email, user.Name, model.AttendeeRoleChair)
+	}
+	return nil
+}
+
+// addInvitees lists the recipients of email invites that can still be redeemed
+func (c *Calendar) addInvitees(ctx context.Context, event *model.CalendarEvent, room *model.Room) error {
+	invites, err := c.inviteRepo.GetByRoomID(ctx, room.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get invites: %w", err)
+	}
+
+	for _, invite := range invites {
+		if invite.Email == "" || invite.IsRevoked() || invite.IsExpired() {
+			continue
+		}
+		role := model.AttendeeRoleRequired
+		if invite.Role == model.RoleCoHost {
+			role = model.AttendeeRoleChair
+		}
+		event.AddAttendee(invite.Email, "", role)
+	}
+	return nil
+}
+
+func (c *Calendar) encode(calendar *model.Calendar) (*CalendarFile, error) {
+	data, err := c.encoder.Encode(calendar)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode calendar: %w", err)
+	}
+	return &CalendarFile{ContentType: c.encoder.ContentType(), Data: data}, nil
+}
+
+// isCalendarInvite checks if an invite can serve as the join link of a room scheduled as schedule
+func isCalendarInvite(invite *model.Invite, schedule *model.Schedule) bool {
+	return invite.Email == "" && invite.Role == model.RoleAttendee && invite.MaxUses == 0 &&
+		!invite.IsRevoked() && !invite.ExpiresAt.Before(schedule.EndsAt)
+}
+
+func isExceptionDate(series *model.Series, startsAt time.Time) bool {
+	for _, exdate := range series.ExceptionDates {
+		if exdate.Equal(startsAt) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+)
+
+// Errors returned by usecases, usable with errors.Is
+var (
+	ErrInvalidInput    = errors.New("invalid input")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrRoomNotFound    = errors.New("room not found")
+	ErrNotParticipant  = errors.New("user is not a participant in this room")
+	ErrNotWaiting      = errors.New("user is not in the waiting room")
+	ErrTooManyAttempts = errors.New("too many attempts, try again later")
+	ErrInviteNotFound  = errors.New("invite not found")
+	ErrInvalidInvite   = errors.New("invalid invite")
+	ErrSeriesNotFound  = errors.New("series not found")
+
+	// ErrConcurrentUpdate is returned when a room or series kept changing while being updated
+	ErrConcurrentUpdate = errors.New("updated concurrently, try again")
+)
+
+// Domain errors returned unchanged from model.Room and its role checks
+var (
+	ErrParticipantNotFound = model.ErrParticipantNotFound
+	ErrRoomExpired         = model.ErrRoomExpired
+	ErrRoomFull            = model.ErrRoomFull
+	ErrAlreadyInRoom       = model.ErrAlreadyInRoom
+	ErrUserBanned          = model.ErrUserBanned
+	ErrRoomLocked          = model.ErrRoomLocked
+	ErrInvalidPasscode     = model.ErrInvalidPasscode
+	ErrPermissionDenied    = model.ErrPermissionDenied
+	ErrNotHost             = model.ErrNotHost
+	ErrInvalidRole         = model.ErrInvalidRole
+	ErrInviteExpired       = model.ErrInviteExpired
+	ErrInviteRevoked       = model.ErrInviteRevoked
+	ErrInviteExhausted     = model.ErrInviteExhausted
+	ErrInviteEmailMismatch = model.ErrInviteEmailMismatch
+	ErrInvalidSchedule     = model.ErrInvalidSchedule
+	ErrRoomNotStarted      = model.ErrRoomNotStarted
+	ErrInvalidRecurrence   = model.ErrInvalidRecurrence
+	ErrOccurrenceNotFound  = model.ErrOccurrenceNotFound
+	ErrInvalidRoomOptions  = model.ErrInvalidRoomOptions
+	ErrExtensionLimit      = model.ErrExtensionLimit
+)
+
+// userLookupError maps a repository error from loading a user
+// Only a missing user becomes ErrUserNotFound; other failures are wrapped and surface as internal errors
+func userLookupError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrUserNotFound
+	}
+	return fmt.Errorf("failed to get user: %w", err)
+}
+
+// roomLookupError maps a repository error from loading a room
+// Only a missing room becomes ErrRoomNotFound; other failures are wrapped and surface as internal errors
+func roomLookupError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrRoomNotFound
+	}
+	return fmt.Errorf("failed to get room: %w", err)
+}
+
+// seriesLookupError maps a repository error from loading a series
+// Only a missing series becomes ErrSeriesNotFound; other failures are wrapped and surface as internal errors
+func seriesLookupError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrSeriesNotFound
+	}
+	return fmt.Errorf("failed to get series: %w", err)
+}
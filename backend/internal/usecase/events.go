@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+// Outbox delivery settings
+const (
+	// publishGrace is how long DispatchEvents leaves a new event to the request that recorded it
+	publishGrace = 5 * time.Second
+
+	// eventBatchSize is how many events one DispatchEvents run delivers
+	eventBatchSize = 100
+
+	// maxEventAttempts is how often an event is tried before it is dropped
+	maxEventAttempts = 10
+
+	// maxEventBackoff caps the delay between attempts
+	maxEventBackoff = time.Minute
+)
+
+// Events records domain events in the outbox and delivers them to the realtime notifier
+// Usecases record events in the transaction that makes the change and publish them after it commits;
+// events whose delivery failed are retried by DispatchEvents, so they arrive at least once
+type Events struct {
+	outboxRepo       repository.Outbox
+	transactor       repository.Transactor
+	realtimeNotifier service.RealtimeNotifier
+	failures         service.FailureCounter
+	logger           *slog.Logger
+}
+
+// NewEvents creates a new Events usecase
+// Events are published once the outermost transaction of transactor commits
+// Deliveries that fail right after commit are logged to logger and counted in failures before DispatchEvents retries them
+func NewEvents(outboxRepo repository.Outbox, transactor repository.Transactor, realtimeNotifier service.RealtimeNotifier, failures service.FailureCounter, logger *slog.Logger) *Events {
+	return &Events{
+		outboxRepo:       outboxRepo,
+		transactor:       transactor,
+		realtimeNotifier: realtimeNotifier,
+		failures:         failures,
+		logger:           logger,
+	}
+}
+
+// record adds events to the outbox; call it within the transaction that makes the change
+// The events become due for DispatchEvents after publishGrace, giving publish time to deliver them first
+func (e *Events) record(ctx context.Context, events ...*model.Event) error {
+	for _, event := range events {
+		event.NextAttemptAt = event.OccurredAt.Add(publishGrace)
+		if err := e.outboxRepo.Add(ctx, event); err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+	}
+	return nil
+}
+
+// publish delivers recorded events once the transaction carried by ctx has committed
+// Called after a nested transaction, it waits for the outermost one, so events of a change
+// that is rolled back are never delivered. Events that cannot be delivered stay in the outbox for DispatchEvents
+func (e *Events) publish(ctx context.Context, events ...*model.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	e.transactor.AfterCommit(ctx, func(ctx context.Context) {
+		for _, event := range events {
+			if err := e.deliver(ctx, event); err != nil {
+				logFailure(ctx, e.logger, e.failures, "publish_event", err, "room_id", event.RoomID, "event_id", event.ID, "event_type", event.Type)
+				continue
+			}
+			// 削除に失敗しても重複して配信されるだけ
+			if err := e.outboxRepo.Delete(ctx, event.ID); err != nil {
+				logFailure(ctx, e.logger, e.failures, "delete_event", err, "room_id", event.RoomID, "event_id", event.ID)
+			}
+		}
+	})
+}
+
+// DispatchEvents delivers the due outbox events, retrying failures with exponential backoff
+// Events still failing after maxEventAttempts are dropped and reported in the returned error
+func (e *Events) DispatchEvents(ctx context.Context) error {
+	events, err := e.outboxRepo.GetDue(ctx, time.Now(), eventBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get due events: %w", err)
+	}
+
+	var errs []error
+	for _, event := range events {
+		deliverErr := e.deliver(ctx, event)
+		if deliverErr == nil || event.Attempts+1 >= maxEventAttempts {
+			if deliverErr != nil {
+				errs = append(errs, fmt.Errorf("dropped %s event %s after %d attempts: %w", event.Type, event.ID, event.Attempts+1, deliverErr))
+			}
+			if err := e.outboxRepo.Delete(ctx, event.ID); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete event %s: %w", event.ID, err))
+			}
+			continue
+		}
+
+		event.Attempts++
+		event.NextAttemptAt = time.Now().Add(eventBackoff(event.Attempts))
+		if err := e.outboxRepo.Reschedule(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reschedule event %s: %w", event.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deliver sends an event to the room's participants
+func (e *Events) deliver(ctx context.Context, event *model.Event) error {
+	switch event.Type {
+	case model.EventTypeRoomCreated, model.EventTypeRoomExtended:
+		var room model.Room
+		if err := event.DecodePayload(&room); err != nil {
+			return err
+		}
+		return e.realtimeNotifier.NotifyRoomUpdate(ctx, &room)
+	case model.EventTypeParticipantJoined:
+		var payload model.ParticipantPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return err
+		}
+		return e.realtimeNotifier.NotifyRoomJoined(ctx, event.RoomID, payload.UserID, payload.UserName)
+	case model.EventTypeParticipantLeft:
+		var payload model.ParticipantPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return err
+		}
+		return e.realtimeNotifier.NotifyRoomLeft(ctx, event.RoomID, payload.UserID, payload.UserName)
+	case model.EventTypeParticipantMuted:
+		var payload model.ParticipantMutedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return err
+		}
+		// 送信者を記録する前に積まれたイベントは本人の操作として扱う
+		if payload.ActorID == uuid.Nil {
+			payload.ActorID = payload.UserID
+		}
+		return e.realtimeNotifier.NotifyUserMuted(ctx, event.RoomID, payload.ActorID, payload.UserID, payload.IsMuted)
+	case model.EventTypeChatPosted:
+		message, err := model.FromJSON(event.Payload)
+		if err != nil {
+			return err
+		}
+		return e.realtimeNotifier.BroadcastChatMessage(ctx, message)
+	case model.EventTypeRoomClosed:
+		var payload model.RoomClosedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return err
+		}
+		return e.realtimeNotifier.NotifyRoomClosed(ctx, event.RoomID, payload.Reason)
+	case model.EventTypeAdmissionDenied:
+		var payload model.AdmissionDeniedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return err
+		}
+		return e.realtimeNotifier.SendDirectMessage(ctx, model.NewAdmitUserMessage(event.RoomID, payload.ActorID, payload.UserID, false, payload.Reason))
+	default:
+		return fmt.Errorf("unknown event type %q", event.Type)
+	}
+}
+
+// eventBackoff returns the delay before the next attempt: 1s, 2s, 4s... up to maxEventBackoff
+func eventBackoff(attempts int) time.Duration {
+	if attempts > 6 {
+		return maxEventBackoff
+	}
+	return min(time.Second<<(attempts-1), maxEventBackoff)
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+// Invite lifetime limits
+const (
+	DefaultInviteTTL = 7 * 24 * time.Hour
+	MaxInviteTTL     = 30 * 24 * time.Hour
+)
+
+// Invite handles invite-related business logic
+type Invite struct {
+	inviteRepo repository.Invite
+	roomRepo   repository.Room
+	userRepo   repository.User
+	signer     service.InviteSigner
+	transactor repository.Transactor
+	rooms      *Room
+}
+
+// NewInvite creates a new Invite usecase
+// Redeemed invites join through rooms so the usual room checks and notifications apply;
+// the use of the invite is recorded in the same transaction as the join
+func NewInvite(
+	inviteRepo repository.Invite,
+	roomRepo repository.Room,
+	userRepo repository.User,
+	signer service.InviteSigner,
+	transactor repository.Transactor,
+	rooms *Room,
+) *Invite {
+	return &Invite{
+		inviteRepo: inviteRepo,
+		roomRepo:   roomRepo,
+		userRepo:   userRepo,
+		signer:     signer,
+		transactor: transactor,
+		rooms:      rooms,
+	}
+}
+
+// CreateInvite issues a signed invite to a room (requires PermissionManageInvites)
+// An empty email lets anyone redeem the invite, an empty role defaults to attendee,
+// a zero ttl defaults to DefaultInviteTTL and zero maxUses means unlimited
+func (i *Invite) CreateInvite(ctx context.Context, actorID, roomID uuid.UUID, email string, role model.Role, ttl time.Duration, maxUses int) (*model.Invite, string, error) {
+	// Validate input
+	if email != "" {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return nil, "", fmt.Errorf("%w: invalid email %q", ErrInvalidInput, email)
+		}
+	}
+	if role == "" {
+		role = model.RoleAttendee
+	}
+	if !role.IsValid() || role == model.RoleHost {
+		return nil, "", fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+	if ttl == 0 {
+		ttl = DefaultInviteTTL
+	}
+	if ttl < 0 || ttl > MaxInviteTTL {
+		return nil, "", fmt.Errorf("%w: invite must expire within %s", ErrInvalidInput, MaxInviteTTL)
+	}
+	if maxUses < 0 {
+		return nil, "", fmt.Errorf("%w: maxUses must not be negative", ErrInvalidInput)
+	}
+
+	// Get room
+	room, err := i.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, "", roomLookupError(err)
+	}
+
+	// Check permission
+	if err := room.Authorize(actorID, model.PermissionManageInvites); err != nil {
+		return nil, "", err
+	}
+
+	// 自分より下の役割でしか招待できない
+	if actorRole := room.RoleOf(actorID); !actorRole.Outranks(role) {
+		return nil, "", &model.PermissionError{Role: actorRole, Permission: model.PermissionManageInvites}
+	}
+
+	invite := model.NewInvite(roomID, actorID, email, role, ttl, maxUses)
+
+	// Sign token
+	token, err := i.signer.Sign(service.NewInviteClaims(invite))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to sign invite: %w", err)
+	}
+
+	// Save invite
+	if err := i.inviteRepo.Create(ctx, invite); err != nil {
+		return nil, "", fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	return invite, token, nil
+}
+
+// ListInvites returns the invites of a room, oldest first (requires PermissionManageInvites)
+func (i *Invite) ListInvites(ctx context.Context, actorID, roomID uuid.UUID) ([]*model.Invite, error) {
+	// Get room
+	room, err := i.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, roomLookupError(err)
+	}
+
+	// Check permission
+	if err := room.Authorize(actorID, model.PermissionManageInvites); err != nil {
+		return nil, err
+	}
+
+	invites, err := i.inviteRepo.GetByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invites: %w", err)
+	}
+
+	return invites, nil
+}
+
+// RevokeInvite stops an invite from being redeemed (requires PermissionManageInvites)
+// Revoking an invite twice is a no-op
+func (i *Invite) RevokeInvite(ctx context.Context, actorID, roomID, inviteID uuid.UUID) error {
+	// Get room
+	room, err := i.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return roomLookupError(err)
+	}
+
+	// Check permission
+	if err := room.Authorize(actorID, model.PermissionManageInvites); err != nil {
+		return err
+	}
+
+	// Get invite
+	invite, err := i.getInvite(ctx, inviteID)
+	if err != nil {
+		return err
+	}
+	if invite.RoomID != roomID {
+		return ErrInviteNotFound
+	}
+
+	invite.Revoke()
+
+	// Update invite in repository
+	if err := i.inviteRepo.Update(ctx, invite); err != nil {
+		return fmt.Errorf("failed to update invite: %w", err)
+	}
+
+	return nil
+}
+
+// RedeemInvite joins the user to the invite's room with the invite's role
+// Invited users skip the passcode and the waiting room, but locked rooms and bans still apply
+func (i *Invite) RedeemInvite(ctx context.Context, userID uuid.UUID, token string) (*model.Room, error) {
+	// Verify token
+	claims, err := i.signer.Verify(token)
+	if err != nil {
+		if errors.Is(err, service.ErrTokenExpired) {
+			return nil, ErrInviteExpired
+		}
+		return nil, ErrInvalidInvite
+	}
+
+	// Get invite
+	invite, err := i.getInvite(ctx, claims.InviteID)
+	if err != nil {
+		if errors.Is(err, ErrInviteNotFound) {
+			return nil, ErrInvalidInvite
+		}
+		return nil, err
+	}
+
+	// Get user
+	user, err := i.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, userLookupError(err)
+	}
+
+	// Use invite (ErrInviteRevoked / ErrInviteExpired / ErrInviteExhausted / ErrInviteEmailMismatch)
+	if err := invite.Redeem(user.Email); err != nil {
+		return nil, err
+	}
+
+	// 利用回数の加算と参加を同じトランザクションで行い、参加できなければ加算も取り消す
+	err = i.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := i.inviteRepo.IncrementUses(ctx, invite.ID); err != nil {
+			return i.incrementUsesError(ctx, invite.ID, user.Email, err)
+		}
+
+		// Join room
+		_, err := i.rooms.join(ctx, userID, invite.RoomID, "", invite.Role)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := i.roomRepo.GetByID(ctx, invite.RoomID)
+	if err != nil {
+		return nil, roomLookupError(err)
+	}
+
+	return room, nil
+}
+
+// incrementUsesError maps a failed IncrementUses to a usecase error
+// An invite revoked or used up since it was read is reloaded to tell which
+func (i *Invite) incrementUsesError(ctx context.Context, inviteID uuid.UUID, email string, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrInvalidInvite
+	case !errors.Is(err, repository.ErrConflict):
+		return fmt.Errorf("failed to update invite: %w", err)
+	}
+
+	invite, err := i.getInvite(ctx, inviteID)
+	if err != nil {
+		return err
+	}
+	if err := invite.Redeem(email); err != nil {
+		return err
+	}
+	return ErrInviteExhausted
+}
+
+// getInvite loads an invite, mapping a missing invite to ErrInviteNotFound
+func (i *Invite) getInvite(ctx context.Context, inviteID uuid.UUID) (*model.Invite, error) {
+	invite, err := i.inviteRepo.GetByID(ctx, inviteID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInviteNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invite: %w", err)
+	}
+	return invite, nil
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cline-meet/backend/internal/domain/service"
+)
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the ID of the request being served
+// Usecases log it with every failure so the logs of one request can be correlated
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID carried by ctx, or "" outside a request
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// logFailure logs a failed step that does not fail the usecase and counts it in failures
+// attrs are slog key-value pairs such as "room_id", roomID
+func logFailure(ctx context.Context, logger *slog.Logger, failures service.FailureCounter, step string, err error, attrs ...any) {
+	failures.CountFailure(step)
+
+	args := append([]any{"step", step, "error", err}, attrs...)
+	if requestID := RequestID(ctx); requestID != "" {
+		args = append(args, "request_id", requestID)
+	}
+	logger.WarnContext(ctx, "best-effort step failed", args...)
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/cline-meet/backend/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+// Maintenance handles the background jobs run by the scheduler on the leader pod
+type Maintenance struct {
+	roomRepo       repository.Room
+	messageRepo    repository.Message
+	transactor     repository.Transactor
+	events         *Events
+	sessionManager service.SessionManager
+	rooms          *Room
+	sessionTimeout time.Duration
+	failures       service.FailureCounter
+	logger         *slog.Logger
+}
+
+// NewMaintenance creates a new Maintenance usecase
+// Connected clients refresh their session on every pong, so sessionTimeout must exceed the ping period
+// Users of reaped sessions leave their room through rooms so the host role is handed over and others are notified
+// Closed rooms are announced through events in the transaction that removes them
+// Session deletions that fail without failing a job are logged to logger and counted in failures
+func NewMaintenance(
+	roomRepo repository.Room,
+	messageRepo repository.Message,
+	transactor repository.Transactor,
+	events *Events,
+	sessionManager service.SessionManager,
+	rooms *Room,
+	sessionTimeout time.Duration,
+	failures service.FailureCounter,
+	logger *slog.Logger,
+) *Maintenance {
+	return &Maintenance{
+		roomRepo:       roomRepo,
+		messageRepo:    messageRepo,
+		transactor:     transactor,
+		events:         events,
+		sessionManager: sessionManager,
+		rooms:          rooms,
+		sessionTimeout: sessionTimeout,
+		failures:       failures,
+		logger:         logger,
+	}
+}
+
+// CleanupExpiredRooms removes expired rooms, tells the remaining participants the room ended
+// and deletes the rooms' chat history and sessions
+func (m *Maintenance) CleanupExpiredRooms(ctx context.Context) error {
+	// Remove the rooms with their closed events and turn away waiting users together;
+	// each room's waiting users are deleted from the waiting room store once this commits
+	var rooms []*model.Room
+	var events []*model.Event
+	err := m.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		rooms, err = m.roomRepo.CleanupExpiredRooms(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to clean up expired rooms: %w", err)
+		}
+
+		for _, room := range rooms {
+			event := model.NewRoomClosedEvent(room.ID, model.RoomCloseReasonExpired)
+			if err := m.events.record(ctx, event); err != nil {
+				return err
+			}
+			denied, err := m.rooms.closeWaitingRoom(ctx, room, "room has expired")
+			if err != nil {
+				return err
+			}
+			events = append(append(events, event), denied...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Notify remaining participants (their connections are closed) and waiting users
+	m.events.publish(ctx, events...)
+
+	var errs []error
+	for _, room := range rooms {
+		// Delete chat history
+		if err := m.messageRepo.DeleteChatHistory(ctx, room.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete chat history of room %s: %w", room.ID, err))
+		}
+
+		// Delete participant sessions that still point at the room
+		for _, p := range room.Participants {
+			session, err := m.sessionManager.GetSession(ctx, p.UserID)
+			if err != nil || session.RoomID != room.ID {
+				continue
+			}
+			if err := m.sessionManager.DeleteSession(ctx, p.UserID); err != nil {
+				logFailure(ctx, m.logger, m.failures, "delete_session", err, "room_id", room.ID, "user_id", p.UserID)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ReapStaleSessions deletes sessions not seen within the session timeout
+// Their users leave their room as if they had left themselves
+func (m *Maintenance) ReapStaleSessions(ctx context.Context) error {
+	sessions, err := m.sessionManager.ReapStaleSessions(ctx, time.Now().Add(-m.sessionTimeout))
+	if err != nil {
+		return fmt.Errorf("failed to reap stale sessions: %w", err)
+	}
+
+	var errs []error
+	for _, session := range sessions {
+		if session.RoomID == uuid.Nil {
+			continue
+		}
+
+		// The room or user may already be gone
+		err := m.rooms.LeaveRoom(ctx, session.UserID, session.RoomID)
+		if err != nil && !errors.Is(err, ErrRoomNotFound) && !errors.Is(err, ErrUserNotFound) && !errors.Is(err, ErrNotParticipant) {
+			errs = append(errs, fmt.Errorf("failed to remove user %s from room %s: %w", session.UserID, session.RoomID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/google/uuid"
+)
+
+// stuckWaitingRoom is a waiting room store that fails to delete the waiting users of one room
+type stuckWaitingRoom struct {
+	*memory.WaitingRoom
+	roomID uuid.UUID
+}
+
+func (w *stuckWaitingRoom) DeleteWaitingUsers(ctx context.Context, roomID uuid.UUID) error {
+	if roomID == w.roomID {
+		return errors.New("connection refused")
+	}
+	return w.WaitingRoom.DeleteWaitingUsers(ctx, roomID)
+}
+
+// expire makes the room expired in the repository
+func (u *testUsecases) expire(t *testing.T, roomID uuid.UUID) {
+	t.Helper()
+
+	ctx := context.Background()
+	room, _ := u.rooms.GetByID(ctx, roomID)
+	room.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := u.rooms.Update(ctx, room); err != nil {
+		t.Fatalf("Expected no error expiring room, got %v", err)
+	}
+}
+
+func TestMaintenance_CleanupExpiredRooms_DeletesWaitingUsersPerRoom(t *testing.T) {
+	u := newTestUsecases(t)
+	ctx := context.Background()
+	host := u.createUser(t, "Host")
+	stuck := u.createRoom(t, host, RoomOptionsInput{})
+	other := u.createRoom(t, host, RoomOptionsInput{})
+	for _, room := range []*model.Room{stuck, other} {
+		u.waiting.AddWaitingUser(ctx, room.ID, uuid.New())
+		u.expire(t, room.ID)
+	}
+	u.room.waitingRoomRepo = &stuckWaitingRoom{WaitingRoom: u.waiting, roomID: stuck.ID}
+
+	// 待機室の削除はコミット後にルームごとに行い、失敗してもルームの削除は取り消さない
+	if err := u.jobs.CleanupExpiredRooms(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, room := range []*model.Room{stuck, other} {
+		if _, err := u.rooms.GetByID(ctx, room.ID); !errors.Is(err, memory.ErrRoomNotFound) {
+			t.Errorf("Expected room %s to be removed, got %v", room.ID, err)
+		}
+	}
+	if waiting, _ := u.waiting.GetWaitingUsers(ctx, other.ID); len(waiting) != 0 {
+		t.Errorf("Expected the waiting users of the other room to be deleted, got %v", waiting)
+	}
+	if u.failures.steps["delete_waiting_users"] != 1 {
+		t.Errorf("Expected 1 failed waiting room deletion, got %v", u.failures.steps)
+	}
+	if len(u.notifier.direct) != 2 {
+		t.Errorf("Expected both waiting users to be denied, got %d messages", len(u.notifier.direct))
+	}
+}
@@ -6,55 +6,63 @@ import (
 
 	"github.com/cline-meet/backend/internal/domain/model"
 	"github.com/cline-meet/backend/internal/domain/repository"
-	"github.com/cline-meet/backend/internal/domain/service"
 	"github.com/google/uuid"
 )
 
 // Message handles message-related business logic
 type Message struct {
-	messageRepo      repository.Message
-	roomRepo         repository.Room
-	userRepo         repository.User
-	realtimeNotifier service.RealtimeNotifier
+	messageRepo repository.Message
+	roomRepo    repository.Room
+	userRepo    repository.User
+	transactor  repository.Transactor
+	events      *Events
 }
 
 // NewMessage creates a new Message usecase
+// Chat messages are broadcast through the chat posted events recorded via events
 func NewMessage(
 	messageRepo repository.Message,
 	roomRepo repository.Room,
 	userRepo repository.User,
-	realtimeNotifier service.RealtimeNotifier,
+	transactor repository.Transactor,
+	events *Events,
 ) *Message {
 	return &Message{
-		messageRepo:      messageRepo,
-		roomRepo:         roomRepo,
-		userRepo:         userRepo,
-		realtimeNotifier: realtimeNotifier,
+		messageRepo: messageRepo,
+		roomRepo:    roomRepo,
+		userRepo:    userRepo,
+		transactor:  transactor,
+		events:      events,
 	}
 }
 
 // SendChatMessage sends a chat message to a room
-func (c *Message) SendMessage(ctx context.Context, senderID, roomID uuid.UUID, messageText string) error {
+func (c *Message) SendMessage(ctx context.Context, senderID, roomID uuid.UUID, messageText string) (*model.Message, error) {
 	// Get user
 	user, err := c.userRepo.GetByID(ctx, senderID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return nil, userLookupError(err)
 	}
 
 	// Get room
 	room, err := c.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
-		return fmt.Errorf("room not found: %w", err)
+		return nil, roomLookupError(err)
 	}
 
 	// Check if room is expired
 	if room.IsExpired() {
-		return fmt.Errorf("room has expired")
+		return nil, ErrRoomExpired
 	}
 
 	// Check if user is a participant
 	if !room.IsParticipant(senderID) {
-		return fmt.Errorf("user is not a participant in this room")
+		return nil, ErrNotParticipant
+	}
+
+	// Viewers cannot chat
+	if err := room.Authorize(senderID, model.PermissionSendChat); err != nil {
+		return nil, err
 	}
 
 	// Create chat message
@@ -62,21 +70,30 @@ func (c *Message) SendMessage(ctx context.Context, senderID, roomID uuid.UUID, m
 
 	// Validate message
 	if !message.IsValid() {
-		return fmt.Errorf("invalid message")
+		return nil, fmt.Errorf("%w: invalid message", ErrInvalidInput)
 	}
 
-	// Save message to Redis
-	if err := c.messageRepo.SaveChatMessage(ctx, message); err != nil {
-		return fmt.Errorf("failed to save message: %w", err)
+	// Record the chat posted event and save the message
+	event := model.NewChatPostedEvent(message)
+	err = c.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := c.events.record(ctx, event); err != nil {
+			return err
+		}
+
+		// Save message to Redis last, as it does not take part in the transaction
+		if err := c.messageRepo.SaveChatMessage(ctx, message); err != nil {
+			return fmt.Errorf("failed to save message: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Broadcast message to all room participants
-	if err := c.realtimeNotifier.BroadcastChatMessage(ctx, message); err != nil {
-		// Log error but don't fail the send operation
-		// Real-time notification failure shouldn't prevent message saving
-	}
+	c.events.publish(ctx, event)
 
-	return nil
+	return message, nil
 }
 
 // GetChatHistory retrieves chat history for a room
@@ -84,12 +101,12 @@ func (c *Message) GetHistory(ctx context.Context, userID, roomID uuid.UUID, limi
 	// Get room
 	room, err := c.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
-		return nil, fmt.Errorf("room not found: %w", err)
+		return nil, roomLookupError(err)
 	}
 
 	// Check if user is a participant
 	if !room.IsParticipant(userID) {
-		return nil, fmt.Errorf("user is not a participant in this room")
+		return nil, ErrNotParticipant
 	}
 
 	// Set default limit if not specified
@@ -106,17 +123,17 @@ func (c *Message) GetHistory(ctx context.Context, userID, roomID uuid.UUID, limi
 	return messages, nil
 }
 
-// DeleteChatHistory deletes all chat history for a room (only host can do this)
-func (c *Message) DeleteHistory(ctx context.Context, hostID, roomID uuid.UUID) error {
+// DeleteChatHistory deletes all chat history for a room (requires PermissionDeleteHistory)
+func (c *Message) DeleteHistory(ctx context.Context, actorID, roomID uuid.UUID) error {
 	// Get room
 	room, err := c.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
-		return fmt.Errorf("room not found: %w", err)
+		return roomLookupError(err)
 	}
 
-	// Check if user is the host
-	if !room.IsHost(hostID) {
-		return fmt.Errorf("only host can delete chat history")
+	// Check permission
+	if err := room.Authorize(actorID, model.PermissionDeleteHistory); err != nil {
+		return err
 	}
 
 	// Delete chat history from Redis
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math/rand/v2"
 	"time"
 
 	"github.com/cline-meet/backend/internal/domain/model"
@@ -12,39 +14,114 @@ import (
 	"github.com/google/uuid"
 )
 
+// JoinStatus describes the outcome of JoinRoom
+type JoinStatus string
+
+const (
+	// JoinStatusJoined means the user is now a participant
+	JoinStatusJoined JoinStatus = "joined"
+
+	// JoinStatusWaiting means the user is in the waiting room until the host admits them
+	JoinStatusWaiting JoinStatus = "waiting"
+)
+
+// Passcode attempt limits applied per user and room by the RateLimiter given to NewRoom
+const (
+	MaxPasscodeAttempts   = 5
+	PasscodeAttemptWindow = 15 * time.Minute
+)
+
+// ConnectionTokenTTL is how long a connection token can be used to connect
+// Clients fetch a new token right before connecting or reconnecting
+const ConnectionTokenTTL = time.Minute
+
+// Passcode length limits (bcrypt only uses the first 72 bytes)
+const (
+	minPasscodeLength = 4
+	maxPasscodeLength = 64
+)
+
+// RoomOptionsInput overrides the policy's default room options; nil fields keep the default
+type RoomOptionsInput struct {
+	MaxCapacity   *int
+	Lifetime      *time.Duration
+	MaxExtension  *time.Duration
+	IsWaitingRoom *bool
+	AutoDelete    *bool
+}
+
 // Room handles room-related business logic
 type Room struct {
 	roomRepo         repository.Room
 	userRepo         repository.User
+	waitingRoomRepo  repository.WaitingRoom
+	transactor       repository.Transactor
+	events           *Events
 	realtimeNotifier service.RealtimeNotifier
 	sessionManager   service.SessionManager
+	connectionSigner service.ConnectionSigner
+	passcodeLimiter  service.RateLimiter
+	policy           model.RoomPolicy
+	failures         service.FailureCounter
+	logger           *slog.Logger
 }
 
 // NewRoom creates a new Room usecase
+// passcodeLimiter should allow MaxPasscodeAttempts per PasscodeAttemptWindow,
+// and policy limits the options of new rooms and how far rooms can be extended
+// transactor groups the writes of usecases that change several stores, such as leaving a room,
+// with the domain events recorded through events. Steps that may fail without failing the usecase are logged to logger
+// and counted in failures. connectionSigner issues the tokens that authenticate WebSocket connections
 func NewRoom(
 	roomRepo repository.Room,
 	userRepo repository.User,
+	waitingRoomRepo repository.WaitingRoom,
+	transactor repository.Transactor,
+	events *Events,
 	realtimeNotifier service.RealtimeNotifier,
 	sessionManager service.SessionManager,
+	connectionSigner service.ConnectionSigner,
+	passcodeLimiter service.RateLimiter,
+	policy model.RoomPolicy,
+	failures service.FailureCounter,
+	logger *slog.Logger,
 ) *Room {
 	return &Room{
 		roomRepo:         roomRepo,
 		userRepo:         userRepo,
+		waitingRoomRepo:  waitingRoomRepo,
+		transactor:       transactor,
+		events:           events,
 		realtimeNotifier: realtimeNotifier,
 		sessionManager:   sessionManager,
+		connectionSigner: connectionSigner,
+		passcodeLimiter:  passcodeLimiter,
+		policy:           policy,
+		failures:         failures,
+		logger:           logger,
 	}
 }
 
+// Policy returns the room policy new rooms are validated against
+func (r *Room) Policy() model.RoomPolicy {
+	return r.policy
+}
+
 // CreateRoom creates a new meeting room
-func (r *Room) CreateRoom(ctx context.Context, hostID uuid.UUID, name string, isWaitingRoom bool) (*model.Room, error) {
-	// Validate host exists
-	_, err := r.userRepo.GetByID(ctx, hostID)
+func (r *Room) CreateRoom(ctx context.Context, hostID uuid.UUID, name string, input RoomOptionsInput) (*model.Room, error) {
+	// Validate options
+	options, err := r.resolveOptions(input)
 	if err != nil {
-		return nil, fmt.Errorf("host not found: %w", err)
+		return nil, err
+	}
+
+	// Validate host exists
+	if _, err := r.userRepo.GetByID(ctx, hostID); err != nil {
+		return nil, userLookupError(err)
 	}
 
 	// Create room
-	room := model.NewRoom(name, hostID, isWaitingRoom)
+	room := model.NewRoomWithOptions(name, hostID, options)
 
 	// Add host as first participant
 	if err := room.AddParticipant(hostID); err != nil {
@@ -52,139 +129,808 @@ func (r *Room) CreateRoom(ctx context.Context, hostID uuid.UUID, name string, is
 	}
 
 	// Save to repository
-	if err := r.roomRepo.Create(ctx, room); err != nil {
-		return nil, fmt.Errorf("failed to create room: %w", err)
+	if err := r.saveNewRoom(ctx, room); err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// ScheduleRoom creates a room for a meeting planned from startsAt to endsAt
+// Participants other than the host can join earlyJoin before the start, and the room expires
+// model.ScheduleGracePeriod after the end. The host joins when the meeting starts rather than now
+func (r *Room) ScheduleRoom(ctx context.Context, hostID uuid.UUID, name string, input RoomOptionsInput, startsAt, endsAt time.Time, earlyJoin time.Duration) (*model.Room, error) {
+	// Validate schedule
+	schedule, err := r.newSchedule(startsAt, endsAt, earlyJoin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate options
+	options, err := r.resolveOptions(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate host exists
+	if _, err := r.userRepo.GetByID(ctx, hostID); err != nil {
+		return nil, userLookupError(err)
+	}
+
+	// Create room
+	room := model.NewRoomWithOptions(name, hostID, options)
+	room.SetSchedule(schedule)
+
+	// Save to repository
+	if err := r.saveNewRoom(ctx, room); err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// saveNewRoom stores a new room together with its room created event
+func (r *Room) saveNewRoom(ctx context.Context, room *model.Room) error {
+	event := model.NewRoomCreatedEvent(room)
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.roomRepo.Create(ctx, room); err != nil {
+			return fmt.Errorf("failed to create room: %w", err)
+		}
+		return r.events.record(ctx, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.events.publish(ctx, event)
+	return nil
+}
+
+// RescheduleRoom moves a scheduled room to a new time (requires PermissionUpdateRoom)
+func (r *Room) RescheduleRoom(ctx context.Context, actorID, roomID uuid.UUID, startsAt, endsAt time.Time, earlyJoin time.Duration) (*model.Room, error) {
+	// Validate schedule
+	schedule, err := r.newSchedule(startsAt, endsAt, earlyJoin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, roomLookupError(err)
+	}
+
+	// Reschedule and save
+	err = r.updateRoom(ctx, room, func(room *model.Room) error {
+		// Check permission
+		if err := room.Authorize(actorID, model.PermissionUpdateRoom); err != nil {
+			return err
+		}
+
+		if room.Schedule == nil {
+			return fmt.Errorf("%w: room is not scheduled", ErrInvalidSchedule)
+		}
+
+		room.SetSchedule(schedule)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Notify participants about room update
+	if err := r.realtimeNotifier.NotifyRoomUpdate(ctx, room); err != nil {
+		logFailure(ctx, r.logger, r.failures, "notify_room_update", err, "room_id", room.ID)
 	}
 
 	return room, nil
 }
 
+// resolveOptions fills in the policy's defaults and validates the options against the policy
+func (r *Room) resolveOptions(input RoomOptionsInput) (model.RoomOptions, error) {
+	options := r.policy.Defaults
+	if input.MaxCapacity != nil {
+		options.MaxCapacity = *input.MaxCapacity
+	}
+	if input.Lifetime != nil {
+		options.Lifetime = *input.Lifetime
+	}
+	if input.MaxExtension != nil {
+		options.MaxExtension = *input.MaxExtension
+	}
+	if input.IsWaitingRoom != nil {
+		options.IsWaitingRoom = *input.IsWaitingRoom
+	}
+	if input.AutoDelete != nil {
+		options.AutoDelete = *input.AutoDelete
+	}
+
+	if err := r.policy.Validate(options); err != nil {
+		return model.RoomOptions{}, err
+	}
+	return options, nil
+}
+
+// newSchedule validates a schedule, which must also fit in the policy's room lifetime
+func (r *Room) newSchedule(startsAt, endsAt time.Time, earlyJoin time.Duration) (*model.Schedule, error) {
+	schedule, err := model.NewSchedule(startsAt, endsAt, earlyJoin)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.EndsAt.Sub(schedule.StartsAt) > r.policy.MaxLifetime {
+		return nil, fmt.Errorf("%w: meetings can last at most %s", ErrInvalidSchedule, r.policy.MaxLifetime)
+	}
+	return schedule, nil
+}
+
+// GetUpcomingMeetings returns the scheduled rooms hosted by a user that have not ended, soonest first
+func (r *Room) GetUpcomingMeetings(ctx context.Context, userID uuid.UUID) ([]*model.Room, error) {
+	rooms, err := r.roomRepo.GetUpcomingByHostID(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upcoming meetings: %w", err)
+	}
+
+	return rooms, nil
+}
+
 // JoinRoom adds a user to a room
-func (r *Room) JoinRoom(ctx context.Context, userID, roomID uuid.UUID) error {
+// Locked rooms reject everyone but the host, and rooms with a passcode require it from everyone but the host
+// In a waiting room everyone but the host is placed in the lobby and the host is notified
+func (r *Room) JoinRoom(ctx context.Context, userID, roomID uuid.UUID, passcode string) (JoinStatus, error) {
+	return r.join(ctx, userID, roomID, passcode, "")
+}
+
+// join adds a user to the room or its waiting room
+// invitedRole is set when the user joins through an invite, which skips the passcode and the waiting room
+func (r *Room) join(ctx context.Context, userID, roomID uuid.UUID, passcode string, invitedRole model.Role) (JoinStatus, error) {
 	// Get user
 	user, err := r.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return "", userLookupError(err)
 	}
 
 	// Get room
 	room, err := r.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
-		return fmt.Errorf("room not found: %w", err)
+		return "", roomLookupError(err)
 	}
 
 	// Check if room is expired
 	if room.IsExpired() {
-		return errors.New("room has expired")
+		return "", ErrRoomExpired
+	}
+
+	// Banned users cannot join or wait for admission
+	if room.IsBanned(userID) {
+		return "", ErrUserBanned
+	}
+
+	if !room.IsHost(userID) {
+		// Check if a scheduled room is open yet
+		if !room.IsOpen() {
+			return "", ErrRoomNotStarted
+		}
+
+		// Check if room is locked
+		if room.IsLocked {
+			return "", ErrRoomLocked
+		}
+
+		// Verify passcode
+		if invitedRole == "" {
+			if err := r.verifyPasscode(ctx, room, userID, passcode); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	// Waiting room: wait for the host to admit the user (designated co-hosts skip it)
+	if room.IsWaitingRoom && !room.IsHost(userID) && !room.IsDesignatedCoHost(userID) && invitedRole == "" {
+		if room.IsParticipant(userID) {
+			return "", ErrAlreadyInRoom
+		}
+		if err := r.waitForAdmission(ctx, room, user); err != nil {
+			return "", err
+		}
+		return JoinStatusWaiting, nil
+	}
+
+	role := model.RoleAttendee
+	if invitedRole != "" {
+		role = invitedRole
+	}
+	if err := r.addParticipant(ctx, room, user, role); err != nil {
+		return "", err
+	}
+	return JoinStatusJoined, nil
+}
+
+// AdmitUser lets a waiting user into the room (requires PermissionManageWaitingRoom)
+// The user stays in the waiting room if the room is full
+func (r *Room) AdmitUser(ctx context.Context, actorID, roomID, userID uuid.UUID) error {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return roomLookupError(err)
+	}
+
+	// Check permission
+	if err := room.Authorize(actorID, model.PermissionManageWaitingRoom); err != nil {
+		return err
+	}
+
+	// Check if user is waiting
+	waiting, err := r.waitingRoomRepo.IsWaitingUser(ctx, roomID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check waiting room: %w", err)
+	}
+	if !waiting {
+		return ErrNotWaiting
+	}
+
+	// Get user
+	user, err := r.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return userLookupError(err)
 	}
 
 	// Add participant to room
-	if err := room.AddParticipant(userID); err != nil {
-		return fmt.Errorf("failed to add participant: %w", err)
+	if err := r.addParticipant(ctx, room, user, model.RoleAttendee); err != nil {
+		return err
+	}
+
+	// Remove from waiting room
+	if err := r.waitingRoomRepo.RemoveWaitingUser(ctx, roomID, userID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to remove waiting user: %w", err)
+	}
+
+	// Notify the admitted user
+	if err := r.realtimeNotifier.SendDirectMessage(ctx, model.NewAdmitUserMessage(roomID, actorID, userID, true, "")); err != nil {
+		// The user learns about the admission from the user_joined broadcast as well
+		logFailure(ctx, r.logger, r.failures, "send_direct_message", err, "room_id", roomID, "user_id", userID)
+	}
+
+	return nil
+}
+
+// DenyUser removes a user from the waiting room without admitting them (requires PermissionManageWaitingRoom)
+func (r *Room) DenyUser(ctx context.Context, actorID, roomID, userID uuid.UUID, reason string) error {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return roomLookupError(err)
+	}
+
+	// Check permission
+	if err := room.Authorize(actorID, model.PermissionManageWaitingRoom); err != nil {
+		return err
+	}
+
+	// Remove from waiting room
+	if err := r.waitingRoomRepo.RemoveWaitingUser(ctx, roomID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrNotWaiting
+		}
+		return fmt.Errorf("failed to remove waiting user: %w", err)
+	}
+
+	// Notify the denied user
+	if err := r.realtimeNotifier.SendDirectMessage(ctx, model.NewAdmitUserMessage(roomID, actorID, userID, false, reason)); err != nil {
+		logFailure(ctx, r.logger, r.failures, "send_direct_message", err, "room_id", roomID, "user_id", userID)
+	}
+
+	return nil
+}
+
+// GetWaitingUsers returns the users waiting for admission (requires PermissionManageWaitingRoom)
+func (r *Room) GetWaitingUsers(ctx context.Context, actorID, roomID uuid.UUID) ([]uuid.UUID, error) {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, roomLookupError(err)
+	}
+
+	// Check permission
+	if err := room.Authorize(actorID, model.PermissionManageWaitingRoom); err != nil {
+		return nil, err
+	}
+
+	userIDs, err := r.waitingRoomRepo.GetWaitingUsers(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiting users: %w", err)
 	}
 
-	// Update room in repository
-	if err := r.roomRepo.Update(ctx, room); err != nil {
-		return fmt.Errorf("failed to update room: %w", err)
+	return userIDs, nil
+}
+
+// ConnectionToken issues a token for the user to connect to the room's realtime events
+// Participants and users in the waiting room can connect; the token expires after ConnectionTokenTTL
+func (r *Room) ConnectionToken(ctx context.Context, userID, roomID uuid.UUID) (string, time.Time, error) {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return "", time.Time{}, roomLookupError(err)
 	}
 
-	// Create or update user session
+	// 待機室のユーザーは入室の可否を受け取るために接続する
+	if !room.IsParticipant(userID) {
+		waiting, err := r.waitingRoomRepo.IsWaitingUser(ctx, roomID, userID)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to check waiting room: %w", err)
+		}
+		if !waiting {
+			return "", time.Time{}, ErrNotParticipant
+		}
+	}
+
+	claims := &service.ConnectionClaims{UserID: userID, RoomID: roomID, ExpiresAt: time.Now().Add(ConnectionTokenTTL)}
+	token, err := r.connectionSigner.SignConnection(claims)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign connection token: %w", err)
+	}
+
+	return token, claims.ExpiresAt, nil
+}
+
+// verifyPasscode checks the passcode, limiting attempts per user and room
+func (r *Room) verifyPasscode(ctx context.Context, room *model.Room, userID uuid.UUID, passcode string) error {
+	if !room.HasPasscode() {
+		return nil
+	}
+
+	key := fmt.Sprintf("passcode:%s:%s", room.ID, userID)
+	allowed, err := r.passcodeLimiter.Allow(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to check passcode attempts: %w", err)
+	}
+	if !allowed {
+		return ErrTooManyAttempts
+	}
+
+	if err := room.CheckPasscode(passcode); err != nil {
+		return err
+	}
+
+	// 成功したら試行回数をリセットする
+	if err := r.passcodeLimiter.Reset(ctx, key); err != nil {
+		logFailure(ctx, r.logger, r.failures, "reset_passcode_attempts", err, "room_id", room.ID, "user_id", userID)
+	}
+	return nil
+}
+
+// maxUpdateAttempts bounds how often updateRoom and updateSeries retry after losing a race
+const maxUpdateAttempts = 10
+
+// updateRoom applies change to room and saves it
+// If another request saved the room first, room is reloaded and change is applied again,
+// so change must make its checks on the room it is given; ErrConcurrentUpdate is returned
+// after maxUpdateAttempts lost races
+func (r *Room) updateRoom(ctx context.Context, room *model.Room, change func(room *model.Room) error) error {
+	for attempt := 1; ; attempt++ {
+		if err := change(room); err != nil {
+			return err
+		}
+
+		err := r.roomRepo.Update(ctx, room)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, repository.ErrConflict) {
+			return fmt.Errorf("failed to update room: %w", err)
+		}
+		if attempt == maxUpdateAttempts {
+			return ErrConcurrentUpdate
+		}
+
+		// 競合したリクエスト同士が同時にやり直さないよう少し待つ
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rand.N(time.Duration(attempt) * time.Millisecond)):
+		}
+
+		// 他のリクエストが先に保存したので読み直してやり直す
+		latest, err := r.roomRepo.GetByID(ctx, room.ID)
+		if err != nil {
+			return roomLookupError(err)
+		}
+		*room = *latest
+	}
+}
+
+// addParticipant adds a user to the room with a role, saves it and notifies the other participants
+func (r *Room) addParticipant(ctx context.Context, room *model.Room, user *model.User, role model.Role) error {
+	// Add participant to room and save it with the joined event (ErrAlreadyInRoom / ErrRoomFull)
+	event := model.NewParticipantJoinedEvent(room.ID, user.ID, user.Name)
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := r.updateRoom(ctx, room, func(room *model.Room) error {
+			return room.AddParticipantWithRole(user.ID, role)
+		})
+		if err != nil {
+			return err
+		}
+		return r.events.record(ctx, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Create or update user session once the join is committed, which waits for the caller's transaction
 	session := &service.UserSession{
-		UserID:   userID,
-		RoomID:   roomID,
-		IsHost:   room.IsHost(userID),
-		IsMuted:  false,
+		UserID:   user.ID,
+		RoomID:   room.ID,
+		IsHost:   room.IsHost(user.ID),
+		IsMuted:  !room.Can(user.ID, model.PermissionSpeak),
 		LastSeen: time.Now().Unix(),
 	}
+	r.transactor.AfterCommit(ctx, func(ctx context.Context) {
+		if err := r.sessionManager.UpdateSession(ctx, session); err != nil {
+			// Session management is not critical for basic functionality
+			logFailure(ctx, r.logger, r.failures, "update_session", err, "room_id", room.ID, "user_id", user.ID)
+		}
+	})
 
-	if err := r.sessionManager.UpdateSession(ctx, session); err != nil {
-		// Log error but don't fail the join operation
-		// Session management is not critical for basic functionality
+	// Notify other participants
+	r.events.publish(ctx, event)
+
+	return nil
+}
+
+// waitForAdmission places a user in the waiting room and notifies the host
+func (r *Room) waitForAdmission(ctx context.Context, room *model.Room, user *model.User) error {
+	if err := r.waitingRoomRepo.AddWaitingUser(ctx, room.ID, user.ID); err != nil {
+		return fmt.Errorf("failed to add waiting user: %w", err)
 	}
 
-	// Notify other participants
-	if err := r.realtimeNotifier.NotifyRoomJoined(ctx, roomID, userID, user.Name); err != nil {
-		// Log error but don't fail the join operation
-		// Real-time notification failure shouldn't prevent joining
+	// Notify host
+	if err := r.realtimeNotifier.SendDirectMessage(ctx, model.NewUserWaitingMessage(room.ID, room.HostID, user.ID, user.Name)); err != nil {
+		// The host can still list the waiting room
+		logFailure(ctx, r.logger, r.failures, "send_direct_message", err, "room_id", room.ID, "user_id", room.HostID)
 	}
 
 	return nil
 }
 
+// admitWaitingUsers admits waiting users until the room is full
+func (r *Room) admitWaitingUsers(ctx context.Context, room *model.Room) {
+	userIDs, err := r.waitingRoomRepo.GetWaitingUsers(ctx, room.ID)
+	if err != nil {
+		logFailure(ctx, r.logger, r.failures, "get_waiting_users", err, "room_id", room.ID)
+		return
+	}
+
+	for _, userID := range userIDs {
+		user, err := r.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			continue
+		}
+		if err := r.addParticipant(ctx, room, user, model.RoleAttendee); err != nil {
+			// 満員になった残りのユーザーは待機室に残す
+			if errors.Is(err, ErrRoomFull) {
+				return
+			}
+			logFailure(ctx, r.logger, r.failures, "admit_waiting_user", err, "room_id", room.ID, "user_id", userID)
+			continue
+		}
+
+		if err := r.waitingRoomRepo.RemoveWaitingUser(ctx, room.ID, userID); err != nil {
+			logFailure(ctx, r.logger, r.failures, "remove_waiting_user", err, "room_id", room.ID, "user_id", userID)
+		}
+		if err := r.realtimeNotifier.SendDirectMessage(ctx, model.NewAdmitUserMessage(room.ID, room.HostID, userID, true, "")); err != nil {
+			logFailure(ctx, r.logger, r.failures, "send_direct_message", err, "room_id", room.ID, "user_id", userID)
+		}
+	}
+}
+
+// closeWaitingRoom denies everyone still waiting when the room goes away
+// Call it within the transaction that removes the room and publish the returned events after it commits;
+// the waiting users are only deleted once the transaction commits, as waiting room stores cannot roll back
+func (r *Room) closeWaitingRoom(ctx context.Context, room *model.Room, reason string) ([]*model.Event, error) {
+	userIDs, err := r.waitingRoomRepo.GetWaitingUsers(ctx, room.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiting users: %w", err)
+	}
+
+	events := make([]*model.Event, 0, len(userIDs))
+	for _, userID := range userIDs {
+		events = append(events, model.NewAdmissionDeniedEvent(room.ID, room.HostID, userID, reason))
+	}
+	if err := r.events.record(ctx, events...); err != nil {
+		return nil, err
+	}
+
+	r.transactor.AfterCommit(ctx, func(ctx context.Context) {
+		if err := r.waitingRoomRepo.DeleteWaitingUsers(ctx, room.ID); err != nil {
+			logFailure(ctx, r.logger, r.failures, "delete_waiting_users", err, "room_id", room.ID)
+		}
+	})
+	return events, nil
+}
+
 // LeaveRoom removes a user from a room
 func (r *Room) LeaveRoom(ctx context.Context, userID, roomID uuid.UUID) error {
 	// Get user
 	user, err := r.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return userLookupError(err)
 	}
 
 	// Get room
 	room, err := r.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
-		return fmt.Errorf("room not found: %w", err)
+		return roomLookupError(err)
 	}
 
-	// Remove participant from room
-	if err := room.RemoveParticipant(userID); err != nil {
-		return fmt.Errorf("failed to remove participant: %w", err)
+	// Check if user is a participant
+	if !room.IsParticipant(userID) {
+		// Waiting users leave the waiting room instead
+		if err := r.waitingRoomRepo.RemoveWaitingUser(ctx, roomID, userID); err == nil {
+			return nil
+		}
+		return ErrNotParticipant
 	}
 
-	// If room is empty, delete it
-	if room.GetParticipantCount() == 0 {
-		if err := r.roomRepo.Delete(ctx, roomID); err != nil {
-			return fmt.Errorf("failed to delete empty room: %w", err)
+	// Remove participant from room, delete the room once empty, record the left event and delete the user's session together
+	event := model.NewParticipantLeftEvent(roomID, userID, user.Name)
+	handedOver := false
+	var denied []*model.Event
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := r.updateRoom(ctx, room, func(room *model.Room) error {
+			// 読み直した後にすでにいなくなっている場合がある
+			if !room.IsParticipant(userID) {
+				return ErrNotParticipant
+			}
+
+			// Hand the host role over before the host leaves
+			var newHostID uuid.UUID
+			handedOver = false
+			if room.IsHost(userID) {
+				if newHostID, handedOver = room.NextHost(); handedOver {
+					if err := room.TransferHost(newHostID); err != nil {
+						return fmt.Errorf("failed to transfer host: %w", err)
+					}
+				}
+			}
+
+			if err := room.RemoveParticipant(userID); err != nil {
+				return fmt.Errorf("failed to remove participant: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
-	} else {
-		// Update room in repository
-		if err := r.roomRepo.Update(ctx, room); err != nil {
-			return fmt.Errorf("failed to update room: %w", err)
+
+		if err := r.events.record(ctx, event); err != nil {
+			return err
 		}
+
+		// If room is empty, delete it and turn away waiting users unless it should stay open until it expires
+		// or the end of its schedule
+		if room.ShouldDeleteWhenEmpty() {
+			if err := r.roomRepo.Delete(ctx, roomID); err != nil {
+				return fmt.Errorf("failed to delete empty room: %w", err)
+			}
+			if denied, err = r.closeWaitingRoom(ctx, room, "room was closed"); err != nil {
+				return err
+			}
+		}
+
+		// Delete user session last, as session stores may not take part in the transaction
+		return r.deleteRoomSession(ctx, userID, roomID)
+	})
+	if err != nil {
+		return err
 	}
 
-	// Delete user session
-	if err := r.sessionManager.DeleteSession(ctx, userID); err != nil {
-		// Log error but don't fail the leave operation
+	// Notify other participants and waiting users
+	r.events.publish(ctx, event)
+	r.events.publish(ctx, denied...)
+
+	if handedOver {
+		r.notifyHostChanged(ctx, room, userID, model.HostChangeReasonLeft)
 	}
 
-	// Notify other participants
-	if err := r.realtimeNotifier.NotifyRoomLeft(ctx, roomID, userID, user.Name); err != nil {
-		// Log error but don't fail the leave operation
+	return nil
+}
+
+// TransferHost hands the host role to another participant (requires PermissionTransferHost)
+func (r *Room) TransferHost(ctx context.Context, actorID, roomID, newHostID uuid.UUID) error {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return roomLookupError(err)
+	}
+
+	// Transfer host and save
+	err = r.updateRoom(ctx, room, func(room *model.Room) error {
+		// Check permission
+		if err := room.Authorize(actorID, model.PermissionTransferHost); err != nil {
+			return err
+		}
+		if newHostID == actorID {
+			return fmt.Errorf("%w: user is already the host", ErrInvalidInput)
+		}
+
+		// Transfer host (ErrParticipantNotFound)
+		return room.TransferHost(newHostID)
+	})
+	if err != nil {
+		return err
 	}
 
+	r.notifyHostChanged(ctx, room, actorID, model.HostChangeReasonTransferred)
+
 	return nil
 }
 
-// MuteParticipant mutes a participant (only host can do this)
-func (r *Room) MuteParticipant(ctx context.Context, hostID, roomID, targetUserID uuid.UUID) error {
+// SetParticipantRole promotes or demotes a participant (requires PermissionManageRoles)
+func (r *Room) SetParticipantRole(ctx context.Context, actorID, roomID, userID uuid.UUID, role model.Role) error {
 	// Get room
 	room, err := r.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
-		return fmt.Errorf("room not found: %w", err)
+		return roomLookupError(err)
 	}
 
-	// Mute participant
-	if err := room.MuteParticipant(hostID, targetUserID); err != nil {
-		return fmt.Errorf("failed to mute participant: %w", err)
+	// Change role and save (ErrInvalidRole / ErrPermissionDenied / ErrParticipantNotFound)
+	// A participant who can no longer speak is muted, which is saved with the muted event
+	var muted *model.Event
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := r.updateRoom(ctx, room, func(room *model.Room) error {
+			wasMuted := false
+			if participant, err := room.GetParticipant(userID); err == nil {
+				wasMuted = participant.IsMuted
+			}
+			if err := room.SetRole(actorID, userID, role); err != nil {
+				return err
+			}
+
+			muted = nil
+			if participant, err := room.GetParticipant(userID); err == nil && participant.IsMuted && !wasMuted {
+				muted = model.NewParticipantMutedEvent(roomID, actorID, userID, true)
+			}
+			return nil
+		})
+		if err != nil || muted == nil {
+			return err
+		}
+		return r.events.record(ctx, muted)
+	})
+	if err != nil {
+		return err
 	}
 
-	// Update room in repository
-	if err := r.roomRepo.Update(ctx, room); err != nil {
-		return fmt.Errorf("failed to update room: %w", err)
+	// Update session and notify participants about the mute
+	if muted != nil {
+		r.updateMutedSession(ctx, muted)
+		r.events.publish(ctx, muted)
 	}
 
-	// Update session
-	session, err := r.sessionManager.GetSession(ctx, targetUserID)
-	if err == nil {
-		session.IsMuted = true
-		r.sessionManager.UpdateSession(ctx, session)
+	// Notify participants about room update
+	if err := r.realtimeNotifier.NotifyRoomUpdate(ctx, room); err != nil {
+		logFailure(ctx, r.logger, r.failures, "notify_room_update", err, "room_id", room.ID)
+	}
+
+	return nil
+}
+
+// notifyHostChanged syncs sessions and tells participants about a new host
+func (r *Room) notifyHostChanged(ctx context.Context, room *model.Room, previousHostID uuid.UUID, reason string) {
+	// Update sessions
+	for userID, isHost := range map[uuid.UUID]bool{previousHostID: false, room.HostID: true} {
+		session, err := r.sessionManager.GetSession(ctx, userID)
+		if err == nil {
+			session.IsHost = isHost
+			if err := r.sessionManager.UpdateSession(ctx, session); err != nil {
+				logFailure(ctx, r.logger, r.failures, "update_session", err, "room_id", room.ID, "user_id", userID)
+			}
+		}
 	}
 
 	// Notify participants
-	if err := r.realtimeNotifier.NotifyUserMuted(ctx, roomID, targetUserID, true); err != nil {
-		// Log error but don't fail the operation
+	if err := r.realtimeNotifier.NotifyHostChanged(ctx, room.ID, previousHostID, room.HostID, reason); err != nil {
+		logFailure(ctx, r.logger, r.failures, "notify_host_changed", err, "room_id", room.ID, "user_id", room.HostID)
+	}
+	if err := r.realtimeNotifier.NotifyRoomUpdate(ctx, room); err != nil {
+		logFailure(ctx, r.logger, r.failures, "notify_room_update", err, "room_id", room.ID)
+	}
+}
+
+// MuteParticipant mutes a participant (requires PermissionMuteParticipants)
+func (r *Room) MuteParticipant(ctx context.Context, actorID, roomID, targetUserID uuid.UUID) error {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return roomLookupError(err)
+	}
+
+	// Mute participant and save it with the muted event (ErrPermissionDenied / ErrParticipantNotFound)
+	event := model.NewParticipantMutedEvent(roomID, actorID, targetUserID, true)
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := r.updateRoom(ctx, room, func(room *model.Room) error {
+			return room.MuteParticipant(actorID, targetUserID)
+		})
+		if err != nil {
+			return err
+		}
+		return r.events.record(ctx, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Update session
+	r.updateMutedSession(ctx, event)
+
+	// Notify participants
+	r.events.publish(ctx, event)
+
+	return nil
+}
+
+// updateMutedSession syncs the session of the participant muted or unmuted by a participant muted event
+func (r *Room) updateMutedSession(ctx context.Context, event *model.Event) {
+	var payload model.ParticipantMutedPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		logFailure(ctx, r.logger, r.failures, "update_session", err, "room_id", event.RoomID)
+		return
+	}
+
+	// 接続していないユーザーにはセッションがない
+	session, err := r.sessionManager.GetSession(ctx, payload.UserID)
+	if err != nil {
+		return
+	}
+	session.IsMuted = payload.IsMuted
+	if err := r.sessionManager.UpdateSession(ctx, session); err != nil {
+		logFailure(ctx, r.logger, r.failures, "update_session", err, "room_id", event.RoomID, "user_id", payload.UserID)
+	}
+}
+
+// KickParticipant removes a participant from the room (requires PermissionRemoveParticipants)
+// With ban the user cannot rejoin for the room's lifetime; the reason is delivered to the removed user
+func (r *Room) KickParticipant(ctx context.Context, actorID, roomID, userID uuid.UUID, ban bool, reason string) error {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return roomLookupError(err)
+	}
+
+	// Get the user's name for the other participants
+	var userName string
+	if user, err := r.userRepo.GetByID(ctx, userID); err == nil {
+		userName = user.Name
+	}
+
+	// Kick participant and save it with the left event (ErrPermissionDenied / ErrParticipantNotFound)
+	event := model.NewParticipantLeftEvent(roomID, userID, userName)
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := r.updateRoom(ctx, room, func(room *model.Room) error {
+			return room.KickParticipant(actorID, userID, ban)
+		})
+		if err != nil {
+			return err
+		}
+		return r.events.record(ctx, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Tell the user why; this also closes their connection to the room
+	// Sent before the session is deleted because the session routes it to the user's pod
+	if err := r.realtimeNotifier.SendDirectMessage(ctx, model.NewKickUserMessage(roomID, actorID, userID, ban, reason)); err != nil {
+		logFailure(ctx, r.logger, r.failures, "send_direct_message", err, "room_id", roomID, "user_id", userID)
+	}
+
+	// Delete session
+	if err := r.deleteRoomSession(ctx, userID, roomID); err != nil {
+		logFailure(ctx, r.logger, r.failures, "delete_session", err, "room_id", roomID, "user_id", userID)
 	}
 
+	// Notify other participants
+	r.events.publish(ctx, event)
+
 	return nil
 }
 
@@ -193,31 +939,196 @@ func (r *Room) UnmuteParticipant(ctx context.Context, userID, roomID uuid.UUID)
 	// Get room
 	room, err := r.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
-		return fmt.Errorf("room not found: %w", err)
+		return roomLookupError(err)
 	}
 
-	// Unmute participant
-	if err := room.UnmuteParticipant(userID); err != nil {
-		return fmt.Errorf("failed to unmute participant: %w", err)
-	}
+	// Unmute participant and save it with the unmuted event
+	event := model.NewParticipantMutedEvent(roomID, userID, userID, false)
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := r.updateRoom(ctx, room, func(room *model.Room) error {
+			// Check if user is a participant
+			if !room.IsParticipant(userID) {
+				return ErrNotParticipant
+			}
 
-	// Update room in repository
-	if err := r.roomRepo.Update(ctx, room); err != nil {
-		return fmt.Errorf("failed to update room: %w", err)
+			// Viewers cannot unmute themselves (ErrPermissionDenied)
+			return room.UnmuteParticipant(userID)
+		})
+		if err != nil {
+			return err
+		}
+		return r.events.record(ctx, event)
+	})
+	if err != nil {
+		return err
 	}
 
 	// Update session
-	session, err := r.sessionManager.GetSession(ctx, userID)
-	if err == nil {
-		session.IsMuted = false
-		r.sessionManager.UpdateSession(ctx, session)
-	}
+	r.updateMutedSession(ctx, event)
 
 	// Notify participants
-	if err := r.realtimeNotifier.NotifyUserMuted(ctx, roomID, userID, false); err != nil {
-		// Log error but don't fail the operation
+	r.events.publish(ctx, event)
+
+	return nil
+}
+
+// UpdateRoom updates room settings (requires PermissionUpdateRoom)
+func (r *Room) UpdateRoom(ctx context.Context, actorID, roomID uuid.UUID, name string, isWaitingRoom bool) (*model.Room, error) {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, roomLookupError(err)
+	}
+
+	// Update settings and save
+	var wasWaitingRoom bool
+	err = r.updateRoom(ctx, room, func(room *model.Room) error {
+		// Check permission
+		if err := room.Authorize(actorID, model.PermissionUpdateRoom); err != nil {
+			return err
+		}
+
+		wasWaitingRoom = room.IsWaitingRoom
+		if name != "" {
+			room.Name = name
+		}
+		room.IsWaitingRoom = isWaitingRoom
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Disabling the waiting room admits everyone who is waiting
+	if wasWaitingRoom && !isWaitingRoom {
+		r.admitWaitingUsers(ctx, room)
+	}
+
+	// Notify participants about room update
+	if err := r.realtimeNotifier.NotifyRoomUpdate(ctx, room); err != nil {
+		logFailure(ctx, r.logger, r.failures, "notify_room_update", err, "room_id", room.ID)
+	}
+
+	return room, nil
+}
+
+// LockRoom locks or unlocks a room; a locked room accepts no new joins (requires PermissionUpdateRoom)
+func (r *Room) LockRoom(ctx context.Context, actorID, roomID uuid.UUID, locked bool) (*model.Room, error) {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, roomLookupError(err)
+	}
+
+	// Lock room and save
+	err = r.updateRoom(ctx, room, func(room *model.Room) error {
+		// Check permission
+		if err := room.Authorize(actorID, model.PermissionUpdateRoom); err != nil {
+			return err
+		}
+
+		room.IsLocked = locked
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Notify participants about room update
+	if err := r.realtimeNotifier.NotifyRoomUpdate(ctx, room); err != nil {
+		logFailure(ctx, r.logger, r.failures, "notify_room_update", err, "room_id", room.ID)
 	}
 
+	return room, nil
+}
+
+// SetPasscode sets the passcode required to join; an empty passcode removes it (requires PermissionUpdateRoom)
+func (r *Room) SetPasscode(ctx context.Context, actorID, roomID uuid.UUID, passcode string) error {
+	// Validate passcode
+	if passcode != "" && (len(passcode) < minPasscodeLength || len(passcode) > maxPasscodeLength) {
+		return fmt.Errorf("%w: passcode must be %d to %d characters", ErrInvalidInput, minPasscodeLength, maxPasscodeLength)
+	}
+
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return roomLookupError(err)
+	}
+
+	// Set passcode and save
+	return r.updateRoom(ctx, room, func(room *model.Room) error {
+		// Check permission
+		if err := room.Authorize(actorID, model.PermissionUpdateRoom); err != nil {
+			return err
+		}
+
+		return room.SetPasscode(passcode)
+	})
+}
+
+// DeleteRoom deletes a room (requires PermissionDeleteRoom)
+func (r *Room) DeleteRoom(ctx context.Context, actorID, roomID uuid.UUID) error {
+	// Get room
+	room, err := r.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return roomLookupError(err)
+	}
+
+	// Check permission
+	if err := room.Authorize(actorID, model.PermissionDeleteRoom); err != nil {
+		return err
+	}
+
+	// Delete room, record the closed event and turn away waiting users together
+	event := model.NewRoomClosedEvent(roomID, model.RoomCloseReasonDeleted)
+	var denied []*model.Event
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.roomRepo.Delete(ctx, roomID); err != nil {
+			return fmt.Errorf("failed to delete room: %w", err)
+		}
+		if err := r.events.record(ctx, event); err != nil {
+			return err
+		}
+		denied, err = r.closeWaitingRoom(ctx, room, "room was closed")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sessions are deleted once the caller's transaction commits too, as session stores cannot roll back
+	r.transactor.AfterCommit(ctx, func(ctx context.Context) {
+		for _, p := range room.Participants {
+			if err := r.deleteRoomSession(ctx, p.UserID, roomID); err != nil {
+				logFailure(ctx, r.logger, r.failures, "delete_session", err, "room_id", roomID, "user_id", p.UserID)
+			}
+		}
+	})
+
+	// Tell the participants the room ended, which also closes their connections, and the waiting users
+	r.events.publish(ctx, event)
+	r.events.publish(ctx, denied...)
+
+	return nil
+}
+
+// deleteRoomSession deletes the user's session if it still belongs to the room
+// Users who have since joined another room keep their session for that room
+func (r *Room) deleteRoomSession(ctx context.Context, userID, roomID uuid.UUID) error {
+	session, err := r.sessionManager.GetSession(ctx, userID)
+	if errors.Is(err, service.ErrSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session.RoomID != roomID {
+		return nil
+	}
+
+	if err := r.sessionManager.DeleteSession(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
 	return nil
 }
 
@@ -225,12 +1136,12 @@ func (r *Room) UnmuteParticipant(ctx context.Context, userID, roomID uuid.UUID)
 func (r *Room) GetRoom(ctx context.Context, roomID uuid.UUID) (*model.Room, error) {
 	room, err := r.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
-		return nil, fmt.Errorf("room not found: %w", err)
+		return nil, roomLookupError(err)
 	}
 
 	// Check if room is expired
 	if room.IsExpired() {
-		return nil, errors.New("room has expired")
+		return nil, ErrRoomExpired
 	}
 
 	return room, nil
@@ -254,31 +1165,45 @@ func (r *Room) GetUserRooms(ctx context.Context, userID uuid.UUID) ([]*model.Roo
 	return activeRooms, nil
 }
 
-// ExtendRoomExpiry extends the expiry time of a room (only host can do this)
-func (r *Room) ExtendRoomExpiry(ctx context.Context, hostID, roomID uuid.UUID, hours int) error {
+// ExtendRoomExpiry extends the expiry time of a room (requires PermissionExtendRoom)
+// Returns ErrExtensionLimit once the room's extensions would exceed its maximum or the policy's
+func (r *Room) ExtendRoomExpiry(ctx context.Context, actorID, roomID uuid.UUID, hours int) error {
+	// Validate input
+	if hours < 1 {
+		return fmt.Errorf("%w: hours must be positive", ErrInvalidInput)
+	}
+
 	// Get room
 	room, err := r.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
-		return fmt.Errorf("room not found: %w", err)
+		return roomLookupError(err)
 	}
 
-	// Check if user is host
-	if !room.IsHost(hostID) {
-		return errors.New("only host can extend room expiry")
-	}
+	// Extend expiry and save it with the extended event
+	var event *model.Event
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := r.updateRoom(ctx, room, func(room *model.Room) error {
+			// Check permission
+			if err := room.Authorize(actorID, model.PermissionExtendRoom); err != nil {
+				return err
+			}
 
-	// Extend expiry
-	room.ExtendExpiry(time.Duration(hours) * time.Hour)
+			// ポリシーが後から厳しくなった場合は新しい上限でも確認する（ルームの設定は変えない）
+			return room.ExtendExpiryWithin(time.Duration(hours)*time.Hour, r.policy.MaxExtension)
+		})
+		if err != nil {
+			return err
+		}
 
-	// Update room in repository
-	if err := r.roomRepo.Update(ctx, room); err != nil {
-		return fmt.Errorf("failed to update room: %w", err)
+		event = model.NewRoomExtendedEvent(room)
+		return r.events.record(ctx, event)
+	})
+	if err != nil {
+		return err
 	}
 
 	// Notify participants about room update
-	if err := r.realtimeNotifier.NotifyRoomUpdate(ctx, room); err != nil {
-		// Log error but don't fail the operation
-	}
+	r.events.publish(ctx, event)
 
 	return nil
 }
@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/cline-meet/backend/internal/infrastructure/token"
+	"github.com/google/uuid"
+)
+
+// recordingNotifier is a service.RealtimeNotifier that records what it was asked to deliver
+type recordingNotifier struct {
+	joined      []uuid.UUID
+	left        []uuid.UUID
+	closed      []uuid.UUID
+	muted       []uuid.UUID
+	direct      []*model.Message
+	hostChanges []uuid.UUID
+	mutex       sync.Mutex
+}
+
+func (n *recordingNotifier) NotifyRoomJoined(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.joined = append(n.joined, userID)
+	return nil
+}
+
+func (n *recordingNotifier) NotifyRoomLeft(ctx context.Context, roomID, userID uuid.UUID, userName string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.left = append(n.left, userID)
+	return nil
+}
+
+func (n *recordingNotifier) NotifyUserMuted(ctx context.Context, roomID, actorID, userID uuid.UUID, isMuted bool) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if isMuted {
+		n.muted = append(n.muted, userID)
+	}
+	return nil
+}
+
+func (n *recordingNotifier) BroadcastChatMessage(ctx context.Context, message *model.Message) error {
+	return nil
+}
+
+func (n *recordingNotifier) SendDirectMessage(ctx context.Context, message *model.Message) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.direct = append(n.direct, message)
+	return nil
+}
+
+func (n *recordingNotifier) NotifyRoomUpdate(ctx context.Context, room *model.Room) error {
+	return nil
+}
+
+func (n *recordingNotifier) NotifyHostChanged(ctx context.Context, roomID, previousHostID, newHostID uuid.UUID, reason string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.hostChanges = append(n.hostChanges, newHostID)
+	return nil
+}
+
+func (n *recordingNotifier) NotifyRoomClosed(ctx context.Context, roomID uuid.UUID, reason string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.closed = append(n.closed, roomID)
+	return nil
+}
+
+// countingFailures is a service.FailureCounter that counts the failed steps
+type countingFailures struct {
+	steps map[string]int
+	mutex sync.Mutex
+}
+
+func (f *countingFailures) CountFailure(step string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.steps[step]++
+}
+
+// testUsecases wires the usecases over the in-memory repositories
+type testUsecases struct {
+	rooms      *memory.Room
+	users      *memory.User
+	waiting    *memory.WaitingRoom
+	series     *memory.Series
+	outbox     *memory.Outbox
+	sessions   *memory.SessionManager
+	transactor *memory.Transactor
+	notifier   *recordingNotifier
+	failures   *countingFailures
+	room       *Room
+	seriesUC   *Series
+	jobs       *Maintenance
+}
+
+func newTestUsecases(t *testing.T) *testUsecases {
+	t.Helper()
+
+	u := &testUsecases{
+		rooms:      memory.NewRoom(),
+		users:      memory.NewUser(),
+		waiting:    memory.NewWaitingRoom(),
+		series:     memory.NewSeries(),
+		outbox:     memory.NewOutbox(),
+		sessions:   memory.NewSessionManager("test-pod"),
+		transactor: memory.NewTransactor(),
+		notifier:   &recordingNotifier{},
+		failures:   &countingFailures{steps: make(map[string]int)},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	events := NewEvents(u.outbox, u.transactor, u.notifier, u.failures, logger)
+	limiter := memory.NewRateLimiter(MaxPasscodeAttempts, PasscodeAttemptWindow)
+	signer, err := token.NewHMACSigner([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("NewHMACSigner failed: %v", err)
+	}
+	u.room = NewRoom(u.rooms, u.users, u.waiting, u.transactor, events, u.notifier, u.sessions, signer, limiter, model.DefaultRoomPolicy(), u.failures, logger)
+	u.seriesUC = NewSeries(u.series, u.rooms, u.users, u.transactor, u.room)
+	u.jobs = NewMaintenance(u.rooms, memory.NewMessage(), u.transactor, events, u.sessions, u.room, time.Minute, u.failures, logger)
+	return u
+}
+
+func (u *testUsecases) createUser(t *testing.T, name string) *model.User {
+	t.Helper()
+
+	user := model.NewUser(uuid.NewString(), strings.ToLower(name)+"@example.com", name, "")
+	if err := u.users.Create(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error creating user, got %v", err)
+	}
+	return user
+}
+
+// createRoom creates a room hosted by host, who is its first participant
+func (u *testUsecases) createRoom(t *testing.T, host *model.User, input RoomOptionsInput) *model.Room {
+	t.Helper()
+
+	room, err := u.room.CreateRoom(context.Background(), host.ID, "Room", input)
+	if err != nil {
+		t.Fatalf("Expected no error creating room, got %v", err)
+	}
+	return room
+}
+
+func (u *testUsecases) join(t *testing.T, user *model.User, roomID uuid.UUID) {
+	t.Helper()
+
+	status, err := u.room.JoinRoom(context.Background(), user.ID, roomID, "")
+	if err != nil || status != JoinStatusJoined {
+		t.Fatalf("Expected %s to join, got %s (%v)", user.Name, status, err)
+	}
+}
+
+func TestRoom_SetParticipantRole_MutesViewers(t *testing.T) {
+	u := newTestUsecases(t)
+	ctx := context.Background()
+	host := u.createUser(t, "Host")
+	guest := u.createUser(t, "Guest")
+	room := u.createRoom(t, host, RoomOptionsInput{})
+	u.join(t, guest, room.ID)
+
+	if session, err := u.sessions.GetSession(ctx, guest.ID); err != nil || session.IsMuted {
+		t.Fatalf("Expected the attendee to join unmuted, got %+v (%v)", session, err)
+	}
+
+	// 視聴者に変更するとミュートされ、セッションと他の参加者にも反映される
+	if err := u.room.SetParticipantRole(ctx, host.ID, room.ID, guest.ID, model.RoleViewer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got, _ := u.rooms.GetByID(ctx, room.ID)
+	if participant, _ := got.GetParticipant(guest.ID); !participant.IsMuted {
+		t.Error("Expected the viewer to be muted")
+	}
+	if session, err := u.sessions.GetSession(ctx, guest.ID); err != nil || !session.IsMuted {
+		t.Errorf("Expected the viewer's session to be muted, got %+v (%v)", session, err)
+	}
+	if len(u.notifier.muted) != 1 || u.notifier.muted[0] != guest.ID {
+		t.Errorf("Expected one mute notification for the viewer, got %v", u.notifier.muted)
+	}
+
+	if err := u.room.UnmuteParticipant(ctx, guest.ID, room.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied, got %v", err)
+	}
+
+	// 既にミュートされていれば再度通知しない
+	if err := u.room.SetParticipantRole(ctx, host.ID, room.ID, guest.ID, model.RoleViewer); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(u.notifier.muted) != 1 {
+		t.Errorf("Expected no further mute notification, got %v", u.notifier.muted)
+	}
+}
+
+func TestRoom_LeaveRoom_KeepsScheduledRoomsUntilTheirEnd(t *testing.T) {
+	u := newTestUsecases(t)
+	ctx := context.Background()
+	host := u.createUser(t, "Host")
+
+	startsAt := time.Now().Add(time.Hour).Truncate(time.Minute)
+	scheduled, err := u.room.ScheduleRoom(ctx, host.ID, "Planning", RoomOptionsInput{}, startsAt, startsAt.Add(time.Hour), 0)
+	if err != nil {
+		t.Fatalf("Expected no error scheduling room, got %v", err)
+	}
+	_, occurrence := u.createSeriesRoom(t, host)
+
+	// 予定の終了前に空になっても、参加者が再び入れるように残す
+	for _, room := range []*model.Room{scheduled, occurrence} {
+		u.join(t, host, room.ID)
+		if err := u.room.LeaveRoom(ctx, host.ID, room.ID); err != nil {
+			t.Fatalf("Expected no error leaving, got %v", err)
+		}
+		if _, err := u.rooms.GetByID(ctx, room.ID); err != nil {
+			t.Errorf("Expected room %s to be kept until its end, got %v", room.ID, err)
+		}
+	}
+	if len(u.notifier.closed) != 0 {
+		t.Errorf("Expected no room to be closed, got %v", u.notifier.closed)
+	}
+}
+
+func TestRoom_ExtendRoomExpiry_KeepsRoomLimitUnderStricterPolicy(t *testing.T) {
+	u := newTestUsecases(t)
+	ctx := context.Background()
+	host := u.createUser(t, "Host")
+	room := u.createRoom(t, host, RoomOptionsInput{})
+
+	// ポリシーが後から厳しくなった
+	u.room.policy.MaxExtension = time.Hour
+	if err := u.room.ExtendRoomExpiry(ctx, host.ID, room.ID, 2); !errors.Is(err, ErrExtensionLimit) {
+		t.Errorf("Expected ErrExtensionLimit, got %v", err)
+	}
+	if err := u.room.ExtendRoomExpiry(ctx, host.ID, room.ID, 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, _ := u.rooms.GetByID(ctx, room.ID)
+	if got.MaxExtensionMinutes != room.MaxExtensionMinutes || got.ExtendedMinutes != 60 {
+		t.Errorf("Expected 60 minutes used of the room's own %d, got %d of %d", room.MaxExtensionMinutes, got.ExtendedMinutes, got.MaxExtensionMinutes)
+	}
+}
+
+func TestRoom_LeaveRoom(t *testing.T) {
+	u := newTestUsecases(t)
+	ctx := context.Background()
+	host := u.createUser(t, "Host")
+	guest := u.createUser(t, "Guest")
+	stranger := u.createUser(t, "Stranger")
+	room := u.createRoom(t, host, RoomOptionsInput{})
+	u.join(t, guest, room.ID)
+
+	if err := u.room.LeaveRoom(ctx, stranger.ID, room.ID); !errors.Is(err, ErrNotParticipant) {
+		t.Errorf("Expected ErrNotParticipant, got %v", err)
+	}
+
+	if err := u.room.LeaveRoom(ctx, guest.ID, room.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got, err := u.rooms.GetByID(ctx, room.ID)
+	if err != nil || got.IsParticipant(guest.ID) || got.HostID != host.ID {
+		t.Fatalf("Expected the room to stay with its host only, got %+v (%v)", got, err)
+	}
+	if _, err := u.sessions.GetSession(ctx, guest.ID); err == nil {
+		t.Error("Expected the guest's session to be deleted")
+	}
+	if len(u.notifier.left) != 1 || u.notifier.left[0] != guest.ID || len(u.notifier.hostChanges) != 0 {
+		t.Errorf("Expected the guest to leave without a host change, got left %v and host changes %v", u.notifier.left, u.notifier.hostChanges)
+	}
+
+	// 最後の参加者が退出すると削除される
+	if err := u.room.LeaveRoom(ctx, host.ID, room.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := u.rooms.GetByID(ctx, room.ID); !errors.Is(err, memory.ErrRoomNotFound) {
+		t.Errorf("Expected the empty room to be deleted, got %v", err)
+	}
+}
+
+func TestRoom_LeaveRoom_HandsHostOver(t *testing.T) {
+	u := newTestUsecases(t)
+	ctx := context.Background()
+	host := u.createUser(t, "Host")
+	guest := u.createUser(t, "Guest")
+	coHost := u.createUser(t, "CoHost")
+	room := u.createRoom(t, host, RoomOptionsInput{})
+	u.join(t, guest, room.ID)
+	u.join(t, coHost, room.ID)
+	if err := u.room.SetParticipantRole(ctx, host.ID, room.ID, coHost.ID, model.RoleCoHost); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 先に参加した参加者より共同ホストを優先して引き継ぐ
+	if err := u.room.LeaveRoom(ctx, host.ID, room.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got, _ := u.rooms.GetByID(ctx, room.ID)
+	if got.HostID != coHost.ID || got.RoleOf(coHost.ID) != model.RoleHost || got.IsParticipant(host.ID) {
+		t.Errorf("Expected the co-host to take over, got host %s with role %s", got.HostID, got.RoleOf(coHost.ID))
+	}
+	if len(u.notifier.hostChanges) != 1 || u.notifier.hostChanges[0] != coHost.ID {
+		t.Errorf("Expected one host change to the co-host, got %v", u.notifier.hostChanges)
+	}
+	if session, err := u.sessions.GetSession(ctx, coHost.ID); err != nil || !session.IsHost {
+		t.Errorf("Expected the new host's session to be updated, got %+v (%v)", session, err)
+	}
+}
+
+func TestRoom_DeleteRoom(t *testing.T) {
+	u := newTestUsecases(t)
+	ctx := context.Background()
+	host := u.createUser(t, "Host")
+	guest := u.createUser(t, "Guest")
+	room := u.createRoom(t, host, RoomOptionsInput{})
+	u.join(t, guest, room.ID)
+	waitingID := uuid.New()
+	u.waiting.AddWaitingUser(ctx, room.ID, waitingID)
+
+	if err := u.room.DeleteRoom(ctx, guest.ID, room.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied, got %v", err)
+	}
+
+	if err := u.room.DeleteRoom(ctx, host.ID, room.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := u.rooms.GetByID(ctx, room.ID); !errors.Is(err, memory.ErrRoomNotFound) {
+		t.Errorf("Expected the room to be deleted, got %v", err)
+	}
+	if len(u.notifier.closed) != 1 || u.notifier.closed[0] != room.ID {
+		t.Errorf("Expected the room to be closed, got %v", u.notifier.closed)
+	}
+
+	// 参加者のセッションと待機中のユーザーも片付ける
+	for _, user := range []*model.User{host, guest} {
+		if _, err := u.sessions.GetSession(ctx, user.ID); err == nil {
+			t.Errorf("Expected %s's session to be deleted", user.Name)
+		}
+	}
+	if waiting, _ := u.waiting.GetWaitingUsers(ctx, room.ID); len(waiting) != 0 {
+		t.Errorf("Expected the waiting users to be deleted, got %v", waiting)
+	}
+	if len(u.notifier.direct) != 1 || u.notifier.direct[0].TargetUserID != waitingID {
+		t.Errorf("Expected the waiting user to be denied, got %+v", u.notifier.direct)
+	}
+}
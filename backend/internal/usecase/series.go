@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// SeriesInput describes a meeting series to create
+type SeriesInput struct {
+	Name          string
+	IsWaitingRoom bool
+	MaxCapacity   int            // 0はポリシーの既定値
+	MaxExtension  *time.Duration // nilはポリシーの既定値
+	AutoDelete    *bool          // nilはポリシーの既定値
+	CoHostIDs     []uuid.UUID
+	StartsAt      time.Time
+	Duration      time.Duration
+	EarlyJoin     time.Duration
+	RRule         string
+	TimeZone      string
+}
+
+// Series handles recurring meeting logic
+// Occurrences are computed from the series' RRULE and materialized as scheduled rooms on demand
+type Series struct {
+	seriesRepo repository.Series
+	roomRepo   repository.Room
+	userRepo   repository.User
+	transactor repository.Transactor
+	rooms      *Room
+}
+
+// NewSeries creates a new Series usecase
+// Cancelled occurrences delete their room through rooms so waiting users and sessions are cleaned up;
+// the rooms are deleted in the same transaction as the series change
+func NewSeries(
+	seriesRepo repository.Series,
+	roomRepo repository.Room,
+	userRepo repository.User,
+	transactor repository.Transactor,
+	rooms *Room,
+) *Series {
+	return &Series{
+		seriesRepo: seriesRepo,
+		roomRepo:   roomRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+		rooms:      rooms,
+	}
+}
+
+// CreateSeries creates a meeting series hosted by hostID
+func (s *Series) CreateSeries(ctx context.Context, hostID uuid.UUID, input SeriesInput) (*model.Series, error) {
+	// Validate input against the room policy
+	policy := s.rooms.Policy()
+	if input.MaxCapacity == 0 {
+		input.MaxCapacity = policy.Defaults.MaxCapacity
+	}
+	if input.MaxCapacity < 1 || input.MaxCapacity > policy.MaxCapacity {
+		return nil, fmt.Errorf("%w: maxCapacity must be between 1 and %d", ErrInvalidInput, policy.MaxCapacity)
+	}
+	if input.Duration > policy.MaxLifetime {
+		return nil, fmt.Errorf("%w: meetings can last at most %s", ErrInvalidSchedule, policy.MaxLifetime)
+	}
+	maxExtension := policy.Defaults.MaxExtension
+	if input.MaxExtension != nil {
+		maxExtension = *input.MaxExtension
+	}
+	if maxExtension < 0 || maxExtension > policy.MaxExtension {
+		return nil, fmt.Errorf("%w: maxExtension must be between 0 and %s", ErrInvalidRoomOptions, policy.MaxExtension)
+	}
+	autoDelete := policy.Defaults.AutoDelete
+	if input.AutoDelete != nil {
+		autoDelete = *input.AutoDelete
+	}
+
+	series, err := model.NewSeries(input.Name, hostID, input.IsWaitingRoom, input.StartsAt, input.Duration, input.EarlyJoin, input.RRule, input.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	series.MaxCapacity = input.MaxCapacity
+	series.MaxExtensionMinutes = int(maxExtension / time.Minute)
+	series.AutoDelete = autoDelete
+	series.SetCoHosts(input.CoHostIDs)
+
+	// Validate host and co-hosts exist
+	if _, err := s.userRepo.GetByID(ctx, hostID); err != nil {
+		return nil, userLookupError(err)
+	}
+	for _, id := range series.CoHostIDs {
+		if _, err := s.userRepo.GetByID(ctx, id); err != nil {
+			return nil, userLookupError(err)
+		}
+	}
+
+	// Save to repository
+	if err := s.seriesRepo.Create(ctx, series); err != nil {
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+
+	return series, nil
+}
+
+// GetSeries retrieves a series by ID
+func (s *Series) GetSeries(ctx context.Context, seriesID uuid.UUID) (*model.Series, error) {
+	return s.getSeries(ctx, seriesID)
+}
+
+// ListOccurrences returns the next n occurrences of a series that have not ended
+// Occurrences that already have a room include its ID
+func (s *Series) ListOccurrences(ctx context.Context, seriesID uuid.UUID, n int) ([]model.Occurrence, error) {
+	// Validate input
+	if n < 1 || n > model.MaxListedOccurrences {
+		return nil, fmt.Errorf("%w: count must be between 1 and %d", ErrInvalidInput, model.MaxListedOccurrences)
+	}
+
+	// Get series
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := series.Occurrences(time.Now(), n)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attach materialized rooms
+	rooms, err := s.roomRepo.GetBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series rooms: %w", err)
+	}
+	for i := range occurrences {
+		if room := findOccurrenceRoom(rooms, occurrences[i].StartsAt); room != nil {
+			roomID := room.ID
+			occurrences[i].RoomID = &roomID
+		}
+	}
+
+	return occurrences, nil
+}
+
+// MaterializeOccurrence returns the room for the occurrence starting at startsAt, creating it if needed
+// Any user can materialize an occurrence so that invitees can join without the host
+func (s *Series) MaterializeOccurrence(ctx context.Context, userID, seriesID uuid.UUID, startsAt time.Time) (*model.Room, error) {
+	// Validate user exists
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, userLookupError(err)
+	}
+
+	// Get series
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrence, err := series.Occurrence(startsAt)
+	if err != nil {
+		return nil, err
+	}
+	if !occurrence.EndsAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: occurrence has already ended", ErrRoomExpired)
+	}
+
+	// Return the existing room
+	if room, err := s.occurrenceRoom(ctx, seriesID, occurrence.StartsAt); err != nil || room != nil {
+		return room, err
+	}
+
+	room, err := series.NewOccurrenceRoom(occurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save to repository
+	if err := s.rooms.saveNewRoom(ctx, room); err != nil {
+		// 同時に作成された場合は既存のルームを返す
+		if existing, lookupErr := s.occurrenceRoom(ctx, seriesID, occurrence.StartsAt); lookupErr == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// CancelOccurrence cancels the occurrence starting at startsAt (host and co-hosts only)
+// A room already materialized for it is deleted
+func (s *Series) CancelOccurrence(ctx context.Context, actorID, seriesID uuid.UUID, startsAt time.Time) error {
+	// Get series
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+
+	// Check permission
+	if !series.CanManage(actorID) {
+		return fmt.Errorf("%w: only the host and co-hosts can cancel occurrences", ErrPermissionDenied)
+	}
+
+	// Save the exception and delete the materialized room together
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.updateSeries(ctx, series, func(series *model.Series) error {
+			return series.CancelOccurrence(startsAt)
+		})
+		if err != nil {
+			return err
+		}
+
+		room, err := s.occurrenceRoom(ctx, seriesID, startsAt)
+		if err != nil || room == nil {
+			return err
+		}
+		return s.rooms.DeleteRoom(ctx, room.HostID, room.ID)
+	})
+}
+
+// DeleteSeries deletes a series (host only)
+// Rooms of occurrences that have not started yet are deleted; rooms of past or ongoing occurrences are kept
+func (s *Series) DeleteSeries(ctx context.Context, actorID, seriesID uuid.UUID) error {
+	// Get series
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+
+	// Check permission
+	if actorID != series.HostID {
+		return fmt.Errorf("%w: only the host can delete the series", ErrPermissionDenied)
+	}
+
+	// Delete upcoming rooms before the series, all or nothing
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		rooms, err := s.roomRepo.GetBySeriesID(ctx, seriesID)
+		if err != nil {
+			return fmt.Errorf("failed to get series rooms: %w", err)
+		}
+		now := time.Now()
+		for _, room := range rooms {
+			if room.Occurrence.StartsAt.After(now) {
+				if err := s.rooms.DeleteRoom(ctx, room.HostID, room.ID); err != nil && !errors.Is(err, ErrRoomNotFound) {
+					return err
+				}
+			}
+		}
+
+		if err := s.seriesRepo.Delete(ctx, seriesID); err != nil {
+			return fmt.Errorf("failed to delete series: %w", err)
+		}
+		return nil
+	})
+}
+
+// updateSeries applies change to series and saves it
+// If another request saved the series first, series is reloaded and change is applied again;
+// ErrConcurrentUpdate is returned after maxUpdateAttempts lost races
+func (s *Series) updateSeries(ctx context.Context, series *model.Series, change func(series *model.Series) error) error {
+	for attempt := 1; ; attempt++ {
+		if err := change(series); err != nil {
+			return err
+		}
+
+		err := s.seriesRepo.Update(ctx, series)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, repository.ErrConflict) {
+			return fmt.Errorf("failed to update series: %w", err)
+		}
+		if attempt == maxUpdateAttempts {
+			return ErrConcurrentUpdate
+		}
+
+		// 他のリクエストが先に保存したので読み直してやり直す
+		latest, err := s.getSeries(ctx, series.ID)
+		if err != nil {
+			return err
+		}
+		*series = *latest
+	}
+}
+
+// getSeries loads a series, mapping a missing series to ErrSeriesNotFound
+func (s *Series) getSeries(ctx context.Context, seriesID uuid.UUID) (*model.Series, error) {
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, seriesLookupError(err)
+	}
+	return series, nil
+}
+
+// occurrenceRoom returns the room materialized for an occurrence, or nil if there is none
+func (s *Series) occurrenceRoom(ctx context.Context, seriesID uuid.UUID, startsAt time.Time) (*model.Room, error) {
+	rooms, err := s.roomRepo.GetBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series rooms: %w", err)
+	}
+	return findOccurrenceRoom(rooms, startsAt), nil
+}
+
+func findOccurrenceRoom(rooms []*model.Room, startsAt time.Time) *model.Room {
+	for _, room := range rooms {
+		if room.Occurrence.StartsAt.Equal(startsAt) {
+			return room
+		}
+	}
+	return nil
+}
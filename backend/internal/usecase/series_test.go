@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cline-meet/backend/internal/domain/model"
+	"github.com/cline-meet/backend/internal/infrastructure/memory"
+	"github.com/google/uuid"
+)
+
+// undeletableSeries is a series repository whose deletes fail
+type undeletableSeries struct {
+	*memory.Series
+}
+
+func (undeletableSeries) Delete(ctx context.Context, id uuid.UUID) error {
+	return errors.New("connection refused")
+}
+
+// createSeriesRoom creates a daily series hosted by host and materializes its first occurrence
+func (u *testUsecases) createSeriesRoom(t *testing.T, host *model.User) (*model.Series, *model.Room) {
+	t.Helper()
+
+	ctx := context.Background()
+	series, err := u.seriesUC.CreateSeries(ctx, host.ID, SeriesInput{
+		Name:     "Standup",
+		StartsAt: time.Now().Add(24 * time.Hour).Truncate(time.Minute),
+		Duration: 30 * time.Minute,
+		RRule:    "FREQ=DAILY;COUNT=3",
+		TimeZone: "UTC",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error creating series, got %v", err)
+	}
+	room, err := u.seriesUC.MaterializeOccurrence(ctx, host.ID, series.ID, series.StartsAt)
+	if err != nil {
+		t.Fatalf("Expected no error materializing the occurrence, got %v", err)
+	}
+	return series, room
+}
+
+func TestSeries_DeleteSeries_RollbackPublishesNothing(t *testing.T) {
+	u := newTestUsecases(t)
+	ctx := context.Background()
+	host := u.createUser(t, "Host")
+	series, room := u.createSeriesRoom(t, host)
+	u.join(t, host, room.ID)
+
+	// 部屋の削除は成功してもシリーズの削除に失敗すれば全体が取り消される
+	failing := NewSeries(undeletableSeries{u.series}, u.rooms, u.users, u.transactor, u.room)
+	if err := failing.DeleteSeries(ctx, host.ID, series.ID); err == nil {
+		t.Fatal("Expected the failed series delete to be returned")
+	}
+
+	if _, err := u.rooms.GetByID(ctx, room.ID); err != nil {
+		t.Errorf("Expected the room to be kept, got %v", err)
+	}
+	if len(u.notifier.closed) != 0 {
+		t.Errorf("Expected no room closed notification for a rolled back delete, got %v", u.notifier.closed)
+	}
+	if session, err := u.sessions.GetSession(ctx, host.ID); err != nil || session.RoomID != room.ID {
+		t.Errorf("Expected the host to keep the session, got %+v (%v)", session, err)
+	}
+
+	if err := u.seriesUC.DeleteSeries(ctx, host.ID, series.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(u.notifier.closed) != 1 || u.notifier.closed[0] != room.ID {
+		t.Errorf("Expected the room to be closed once, got %v", u.notifier.closed)
+	}
+	if _, err := u.sessions.GetSession(ctx, host.ID); err == nil {
+		t.Error("Expected the host session to be deleted")
+	}
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/cline-meet/backend/internal/domain/model"
 	"github.com/cline-meet/backend/internal/domain/repository"
@@ -15,18 +17,25 @@ type User struct {
 	userRepo         repository.User
 	realtimeNotifier service.RealtimeNotifier
 	sessionManager   service.SessionManager
+	failures         service.FailureCounter
+	logger           *slog.Logger
 }
 
 // NewUser creates a new User usecase
+// Session cleanup that fails without failing the usecase is logged to logger and counted in failures
 func NewUser(
 	userRepo repository.User,
 	realtimeNotifier service.RealtimeNotifier,
 	sessionManager service.SessionManager,
+	failures service.FailureCounter,
+	logger *slog.Logger,
 ) *User {
 	return &User{
 		userRepo:         userRepo,
 		realtimeNotifier: realtimeNotifier,
 		sessionManager:   sessionManager,
+		failures:         failures,
+		logger:           logger,
 	}
 }
 
@@ -44,7 +53,7 @@ func (u *User) CreateUser(ctx context.Context, googleID, email, name, avatarURL
 
 	// Validate user data
 	if !user.IsValid() {
-		return nil, fmt.Errorf("invalid user data: email and name are required")
+		return nil, fmt.Errorf("%w: email and name are required", ErrInvalidInput)
 	}
 
 	// Save to repository
@@ -59,7 +68,7 @@ func (u *User) CreateUser(ctx context.Context, googleID, email, name, avatarURL
 func (u *User) GetUserByID(ctx context.Context, userID uuid.UUID) (*model.User, error) {
 	user, err := u.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, userLookupError(err)
 	}
 
 	return user, nil
@@ -69,7 +78,7 @@ func (u *User) GetUserByID(ctx context.Context, userID uuid.UUID) (*model.User,
 func (u *User) GetUserByGoogleID(ctx context.Context, googleID string) (*model.User, error) {
 	user, err := u.userRepo.GetByGoogleID(ctx, googleID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, userLookupError(err)
 	}
 
 	return user, nil
@@ -79,7 +88,7 @@ func (u *User) GetUserByGoogleID(ctx context.Context, googleID string) (*model.U
 func (u *User) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	user, err := u.userRepo.GetByEmail(ctx, email)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, userLookupError(err)
 	}
 
 	return user, nil
@@ -90,7 +99,7 @@ func (u *User) UpdateProfile(ctx context.Context, userID uuid.UUID, name, avatar
 	// Get existing user
 	user, err := u.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return userLookupError(err)
 	}
 
 	// Update profile
@@ -98,7 +107,7 @@ func (u *User) UpdateProfile(ctx context.Context, userID uuid.UUID, name, avatar
 
 	// Validate updated data
 	if !user.IsValid() {
-		return fmt.Errorf("invalid user data: email and name are required")
+		return fmt.Errorf("%w: email and name are required", ErrInvalidInput)
 	}
 
 	// Save to repository
@@ -114,13 +123,13 @@ func (u *User) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 	// Check if user exists
 	_, err := u.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return userLookupError(err)
 	}
 
 	// Delete user session if exists
 	if err := u.sessionManager.DeleteSession(ctx, userID); err != nil {
-		// Log error but don't fail the delete operation
 		// Session deletion failure shouldn't prevent user deletion
+		logFailure(ctx, u.logger, u.failures, "delete_session", err, "user_id", userID)
 	}
 
 	// Delete user from repository
@@ -135,10 +144,13 @@ func (u *User) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 func (u *User) LoginUser(ctx context.Context, googleID, email, name, avatarURL string) (*model.User, error) {
 	// Try to get existing user
 	user, err := u.userRepo.GetByGoogleID(ctx, googleID)
-	if err != nil {
+	if errors.Is(err, repository.ErrNotFound) {
 		// User doesn't exist, create new user
 		return u.CreateUser(ctx, googleID, email, name, avatarURL)
 	}
+	if err != nil {
+		return nil, userLookupError(err)
+	}
 
 	// User exists, update profile if needed
 	if user.Name != name || user.AvatarURL != avatarURL {